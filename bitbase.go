@@ -0,0 +1,425 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`fmt`
+	`io`
+	`strings`
+)
+
+// Win/draw bitbases for king and a piece vs. bare king endgames. The bits
+// are set for the positions won by the stronger side that is always white.
+// KPK bitbase index:
+//
+// 00000000 00000000 0000000X Color
+// 00000000 00000000 0XXXXXX0 White King square (0..63)
+// 00000000 000XXXXX X0000000 Black King square (0..63)
+// 00000XXX XXX00000 00000000 White Pawn square (0..48)
+//
+// Pawnless bitbases use symmetry to keep white king within A1-D1-D4 triangle
+// so the index is color + 2 * (triangle + 10 * (black king + 64 * piece)). The
+// KQKR bitbase adds black rook: ... + 64 * (queen + 64 * rook).
+var bitbases = map[Piece][]uint64{
+	Pawn:  bitbase[:],
+	Rook:  bitbaseKRK[:],
+	Queen: bitbaseKQK[:],
+}
+
+var bitbaseNames = map[string]Piece{
+	`KPK`: Pawn,
+	`KRK`: Rook,
+	`KQK`: Queen,
+}
+
+// White king square to triangle index, -1 if the square is outside A1-D1-D4,
+// and back.
+var bitbaseTriangle [64]int
+var bitbaseSquares = [10]int{ A1, B1, C1, D1, B2, C2, D2, C3, D3, D4 }
+
+func init() {
+	for square := A1; square <= H8; square++ {
+		bitbaseTriangle[square] = -1
+	}
+	for i, square := range bitbaseSquares {
+		bitbaseTriangle[square] = i
+	}
+}
+
+// Returns bitbase index for the position where white is the stronger side.
+// The squares are the piece's one and, for KQKR, the black rook's one.
+func bitbaseIndex(piece Piece, color, wKing, bKing int, squares ...int) int {
+	if piece == Pawn {
+		return color + (wKing << 1) + (bKing << 7) + ((squares[0] - 8) << 13)
+	}
+
+	// Mirror the board so that white king ends up within the triangle.
+	if col(wKing) > D1 {
+		wKing, bKing = wKing ^ 7, bKing ^ 7
+		for i := range squares {
+			squares[i] ^= 7
+		}
+	}
+	if row(wKing) > A4H4 {
+		wKing, bKing = wKing ^ 56, bKing ^ 56
+		for i := range squares {
+			squares[i] ^= 56
+		}
+	}
+	if row(wKing) > col(wKing) {
+		wKing, bKing = transpose(wKing), transpose(bKing)
+		for i := range squares {
+			squares[i] = transpose(squares[i])
+		}
+	}
+
+	index := 0
+	for i := len(squares) - 1; i >= 0; i-- {
+		index = index * 64 + squares[i]
+	}
+
+	return color + ((bitbaseTriangle[wKing] + 10 * (bKing + 64 * index)) << 1)
+}
+
+// Flips the square along A1-H8 diagonal.
+func transpose(sq int) int {
+	return square(col(sq), row(sq))
+}
+
+// Returns true if the side with the piece wins the king and a piece vs. bare
+// king endgame, or KQKR endgame when the piece is queen and the other side
+// has a rook. The position gets flipped if the side with the piece is black.
+func (e *Evaluation) bitbaseWin(piece Piece) bool {
+	p := e.position
+	color := int(p.color)
+	wKing, bKing := int(p.king[White]), int(p.king[Black])
+	squares := [2]int{ p.outposts[piece].last(), p.outposts[BlackRook].last() }
+
+	// Don't trust the endgame handler that brought us here without the
+	// piece, ex. picked by material balance index that has overflowed.
+	if p.outposts[piece].empty() && p.outposts[piece | Black].empty() {
+		return false
+	}
+
+	if p.outposts[piece].empty() {
+		color ^= 1
+		wKing, bKing = 64 + ^int(p.king[Black]), 64 + ^int(p.king[White])
+		squares = [2]int{ 64 + ^p.outposts[piece | Black].last(), 64 + ^p.outposts[Rook].last() }
+	}
+
+	table, size := bitbases[piece], 1
+	if piece == Queen && (p.outposts[Rook] | p.outposts[BlackRook]).any() {
+		table, size = bitbaseKQKR[:], 2
+	}
+	index := bitbaseIndex(piece, color, wKing, bKing, squares[:size]...)
+
+	return table[index / 64] & (1 << uint(index & 0x3F)) != 0
+}
+
+// Generates the bitbase by retrograde analysis. Starting with the positions
+// that are known to be won or drawn it keeps iterating over the rest until
+// no more positions can be resolved.
+func GenerateBitbase(name string) ([]uint64, error) {
+	if name == `KQKR` {
+		return generateQueenVsRook(), nil
+	}
+	piece, ok := bitbaseNames[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bitbase %q", name)
+	}
+
+	const (Invalid = iota; Unclear = 1; Draw = 2; Win = 4)
+
+	base := make([]int, len(bitbases[piece]) * 64)
+	p := new(Position) // Only needed to look up sliding piece moves.
+
+	// Invokes the callback for every valid combination of squares.
+	each := func(callback func(color, wKing, bKing, square int)) {
+		for wKing := A1; wKing <= H8; wKing++ {
+			if piece != Pawn && bitbaseTriangle[wKing] < 0 {
+				continue
+			}
+			for bKing := A1; bKing <= H8; bKing++ {
+				for square := let(piece == Pawn, A2, A1); square <= let(piece == Pawn, H7, H8); square++ {
+					callback(White, wKing, bKing, square)
+					callback(Black, wKing, bKing, square)
+				}
+			}
+		}
+	}
+
+	// Squares attacked by the white piece.
+	attacks := func(square int, board Bitmask) Bitmask {
+		switch piece {
+		case Pawn:
+			return pawnAttacks[White][square]
+		case Rook:
+			return p.rookMovesAt(square, board)
+		}
+		return p.rookMovesAt(square, board) | p.bishopMovesAt(square, board)
+	}
+
+	initial := func(color, wKing, bKing, square int) int {
+		// Reject the index if squares violate chess rules.
+		if wKing == square || bKing == square || distance[wKing][bKing] <= 1 {
+			return Invalid
+		}
+
+		board := bit[wKing] | bit[bKing]
+		check := attacks(square, board).on(bKing)
+
+		if color == White {
+			// Reject the index if black king is in check.
+			if check {
+				return Invalid
+			}
+			if piece == Pawn {
+				promo := square + 8
+
+				// White king in front of the pawn on the 7th rank is stalemated.
+				if kingMoves[wKing] & ^(kingMoves[bKing] | bit[square]) == 0 && promo == wKing {
+					return Draw
+				}
+				// The pawn can be promoted if: a) black king is too far to capture
+				// it or b) promotion square is protected by the white king.
+				if promo >= A8 && (distance[bKing][promo] > 1 || distance[wKing][promo] == 1) {
+					return Win
+				}
+			}
+		} else {
+			// Black king captures undefended pawn or piece.
+			if distance[bKing][square] == 1 && distance[wKing][square] > 1 {
+				return Draw
+			}
+			// Stalemate: black king can't step along the line of the
+			// sliding piece either.
+			if !check && (kingMoves[bKing] & ^(kingMoves[wKing] | attacks(square, bit[wKing]))).empty() {
+				return Draw
+			}
+		}
+
+		return Unclear
+	}
+
+	iterate := func(color, wKing, bKing, square int) (outcome int) {
+		if color == White {
+			for moves := kingMoves[wKing]; moves.any(); {
+				outcome |= base[bitbaseIndex(piece, Black, moves.pop(), bKing, square)]
+			}
+			if piece == Pawn {
+				if row := row(square); row < A7H7 {
+					push := square + 8
+					outcome |= base[bitbaseIndex(piece, Black, wKing, bKing, push)]
+
+					if row == A2H2 && push != wKing && push != bKing {
+						outcome |= base[bitbaseIndex(piece, Black, wKing, bKing, push + 8)]
+					}
+				}
+			} else {
+				for moves := attacks(square, bit[wKing] | bit[bKing]) & ^(bit[wKing] | bit[bKing]); moves.any(); {
+					outcome |= base[bitbaseIndex(piece, Black, wKing, bKing, moves.pop())]
+				}
+			}
+			if outcome & Win != 0 {
+				outcome = Win
+			} else if outcome & Unclear != 0 {
+				outcome = Unclear
+			} else {
+				outcome = Draw
+			}
+		} else {
+			for moves := kingMoves[bKing]; moves.any(); {
+				outcome |= base[bitbaseIndex(piece, White, wKing, moves.pop(), square)]
+			}
+			if outcome & Draw != 0 {
+				outcome = Draw
+			} else if outcome & Unclear != 0 {
+				outcome = Unclear
+			} else {
+				outcome = Win
+			}
+		}
+
+		return
+	}
+
+	// Initial pass.
+	each(func(color, wKing, bKing, square int) {
+		base[bitbaseIndex(piece, color, wKing, bKing, square)] = initial(color, wKing, bKing, square)
+	})
+
+	// Continuous iterations until no updates are made.
+	for updates := 1; updates > 0; {
+		updates = 0
+		each(func(color, wKing, bKing, square int) {
+			index := bitbaseIndex(piece, color, wKing, bKing, square)
+			if base[index] == Unclear {
+				if base[index] = iterate(color, wKing, bKing, square); base[index] != Unclear {
+					updates++
+				}
+			}
+		})
+	}
+
+	// Pack findings into 64-bit bitbase entries.
+	table := make([]uint64, len(bitbases[piece]))
+	for i := 0; i < len(base); i++ {
+		if base[i] == Win {
+			table[i / 64] |= 1 << uint(i & 0x3F)
+		}
+	}
+
+	return table, nil
+}
+
+// Generates KQKR bitbase the same way. Unlike the bitbases above both sides
+// have a piece to capture: black draws (or even wins) by capturing the queen,
+// and white gets KQK after capturing the rook. The bits are only set for the
+// positions won by white.
+func generateQueenVsRook() []uint64 {
+	const (Invalid = iota; Unclear = 1; Draw = 2; Win = 4)
+
+	base := make([]uint8, len(bitbaseKQKR) * 64)
+	p := new(Position) // Only needed to look up sliding piece moves.
+
+	queenMoves := func(square int, board Bitmask) Bitmask {
+		return p.rookMovesAt(square, board) | p.bishopMovesAt(square, board)
+	}
+
+	// KQK outcome with black to move after white captures the rook.
+	captured := func(wKing, bKing, queen int) uint8 {
+		index := bitbaseIndex(Queen, Black, wKing, bKing, queen)
+		if bitbaseKQK[index / 64] & (1 << uint(index & 0x3F)) != 0 {
+			return Win
+		}
+		return Draw
+	}
+
+	// Returns the squares of the position and whether the side that has
+	// just moved left its king in check.
+	squares := func(index int) (color, wKing, bKing, queen, rook int, check bool) {
+		color, index = index & 1, index >> 1
+		wKing, bKing = bitbaseSquares[index % 10], (index / 10) % 64
+		queen, rook = (index / 640) % 64, index / 40960
+
+		board := bit[wKing] | bit[bKing] | bit[queen] | bit[rook]
+		if color == White {
+			check = queenMoves(queen, board).on(bKing)
+		} else {
+			check = p.rookMovesAt(rook, board).on(wKing)
+		}
+		return
+	}
+
+	iterate := func(color, wKing, bKing, queen, rook int) (outcome uint8) {
+		board := bit[wKing] | bit[bKing] | bit[queen] | bit[rook]
+		if color == White {
+			for moves := kingMoves[wKing] & ^(kingMoves[bKing] | bit[queen]); moves.any(); {
+				if square := moves.pop(); square == rook {
+					outcome |= captured(square, bKing, queen)
+				} else if !p.rookMovesAt(rook, board ^ bit[wKing]).on(square) {
+					outcome |= base[bitbaseIndex(Queen, Black, square, bKing, queen, rook)]
+				}
+			}
+			for moves := queenMoves(queen, board) & ^(bit[wKing] | bit[bKing]); moves.any(); {
+				if square := moves.pop(); square == rook {
+					outcome |= captured(wKing, bKing, square)
+				} else if !p.rookMovesAt(rook, board ^ bit[queen] | bit[square]).on(wKing) {
+					outcome |= base[bitbaseIndex(Queen, Black, wKing, bKing, square, rook)]
+				}
+			}
+			if outcome & Win != 0 {
+				return Win
+			} else if outcome & Unclear != 0 {
+				return Unclear
+			}
+			return Draw // <-- Including white having no moves.
+		}
+
+		for moves := kingMoves[bKing] & ^(kingMoves[wKing] | bit[rook]); moves.any(); {
+			if square := moves.pop(); square == queen {
+				outcome |= Draw
+			} else if !queenMoves(queen, board ^ bit[bKing]).on(square) {
+				outcome |= base[bitbaseIndex(Queen, White, wKing, square, queen, rook)]
+			}
+		}
+		for moves := p.rookMovesAt(rook, board) & ^(bit[wKing] | bit[bKing]); moves.any(); {
+			if square := moves.pop(); square == queen {
+				outcome |= Draw
+			} else if !queenMoves(queen, board ^ bit[rook] | bit[square]).on(bKing) {
+				outcome |= base[bitbaseIndex(Queen, White, wKing, bKing, queen, square)]
+			}
+		}
+		if outcome == 0 { // Checkmate or stalemate.
+			if queenMoves(queen, board).on(bKing) {
+				return Win
+			}
+			return Draw
+		} else if outcome & Draw != 0 {
+			return Draw
+		} else if outcome & Unclear != 0 {
+			return Unclear
+		}
+		return Win
+	}
+
+	// Initial pass.
+	for index := range base {
+		_, wKing, bKing, queen, rook, check := squares(index)
+		if !check && distance[wKing][bKing] > 1 && (bit[wKing] | bit[bKing] | bit[queen] | bit[rook]).count() == 4 {
+			base[index] = Unclear
+		}
+	}
+
+	// Continuous iterations until no updates are made.
+	for updates := 1; updates > 0; {
+		updates = 0
+		for index := range base {
+			if base[index] == Unclear {
+				color, wKing, bKing, queen, rook, _ := squares(index)
+				if base[index] = iterate(color, wKing, bKing, queen, rook); base[index] != Unclear {
+					updates++
+				}
+			}
+		}
+	}
+
+	// Pack findings into 64-bit bitbase entries.
+	table := make([]uint64, len(bitbaseKQKR))
+	for i := 0; i < len(base); i++ {
+		if base[i] == Win {
+			table[i / 64] |= 1 << uint(i & 0x3F)
+		}
+	}
+
+	return table
+}
+
+// Writes Go source of the generated bitbase.
+func WriteBitbase(w io.Writer, name string, table []uint64) (err error) {
+	variable, size := `bitbase` + name, `2*10*64`
+	if name == `KPK` {
+		variable, size = `bitbase`, `2*64*48`
+	} else if name == `KQKR` {
+		size = `2*10*64*64`
+	}
+
+	fmt.Fprintf(w, "// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.\n")
+	fmt.Fprintf(w, "// Use of this source code is governed by a MIT-style license that can\n")
+	fmt.Fprintf(w, "// be found in the LICENSE file.\n\n")
+	fmt.Fprintf(w, "// Generated by cmd/bitbase, do not edit.\n\n")
+	fmt.Fprintf(w, "package donna\n\n")
+	fmt.Fprintf(w, "var %s = [%s]uint64 {\n", variable, size)
+	for i := 0; i < len(table); i += 4 {
+		values := make([]string, 0, 4)
+		for _, value := range table[i : min(i + 4, len(table))] {
+			values = append(values, fmt.Sprintf(`0x%016X`, value))
+		}
+		fmt.Fprintf(w, "\t%s,\n", strings.Join(values, `, `))
+	}
+	_, err = fmt.Fprintf(w, "}\n")
+
+	return
+}
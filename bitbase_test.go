@@ -0,0 +1,128 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `bytes`; `context`; `testing`)
+
+func bitbaseDiff(name string, table []uint64) (diff int) {
+	generated, _ := GenerateBitbase(name)
+	for i := range generated {
+		if generated[i] != table[i] {
+			diff++
+		}
+	}
+	return
+}
+
+// Generated bitbases match the ones compiled in.
+func TestBitbase000(t *testing.T) {
+	expect.Eq(t, bitbaseDiff(`KPK`, bitbase[:]), 0)
+}
+
+func TestBitbase010(t *testing.T) {
+	expect.Eq(t, bitbaseDiff(`KRK`, bitbaseKRK[:]), 0)
+}
+
+func TestBitbase020(t *testing.T) {
+	expect.Eq(t, bitbaseDiff(`KQK`, bitbaseKQK[:]), 0)
+}
+
+func TestBitbase030(t *testing.T) {
+	expect.Eq(t, bitbaseDiff(`KQKR`, bitbaseKQKR[:]), 0)
+}
+
+func TestBitbase040(t *testing.T) {
+	_, err := GenerateBitbase(`KNNK`)
+	expect.Ne(t, err, nil)
+}
+
+// Generated source code.
+func TestBitbase050(t *testing.T) {
+	var buffer bytes.Buffer
+	WriteBitbase(&buffer, `KRK`, bitbaseKRK[:])
+	expect.Contain(t, buffer.String(), "var bitbaseKRK = [2*10*64]uint64 {\n\t0x")
+
+	buffer.Reset()
+	WriteBitbase(&buffer, `KPK`, bitbase[:])
+	expect.Contain(t, buffer.String(), "var bitbase = [2*64*48]uint64 {\n\t0x")
+
+	buffer.Reset()
+	WriteBitbase(&buffer, `KQKR`, bitbaseKQKR[:])
+	expect.Contain(t, buffer.String(), "var bitbaseKQKR = [2*10*64*64]uint64 {\n\t0x")
+}
+
+// Symmetric positions share the same index.
+func TestBitbase060(t *testing.T) {
+	index := bitbaseIndex(Rook, White, G7, A1, C5)
+	expect.Eq(t, bitbaseIndex(Rook, White, B2, H8, F4), index)
+	expect.Eq(t, bitbaseIndex(Rook, White, G2, A8, C4), index)
+	expect.Eq(t, bitbaseIndex(Rook, White, B7, H1, F5), index)
+
+	index = bitbaseIndex(Queen, Black, G7, A1, C5, E3)
+	expect.Eq(t, bitbaseIndex(Queen, Black, B2, H8, F4, D6), index)
+	expect.Eq(t, bitbaseIndex(Queen, Black, G2, A8, C4, E6), index)
+	expect.Eq(t, bitbaseIndex(Queen, Black, B7, H1, F5, D3), index)
+}
+
+// KRK and KQK: stalemates and hanging pieces.
+func TestBitbase100(t *testing.T) {
+	score := NewGame(`Kc1,Rh2`, `M,Ka1`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestBitbase110(t *testing.T) {
+	score := NewGame(`Ke1,Rd7`, `M,Ke8`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestBitbase120(t *testing.T) {
+	score := NewGame(`Ke1,Rd7`, `Ke8`).start().Evaluate()
+	expect.True(t, score > onePawn * 5)
+}
+
+func TestBitbase130(t *testing.T) {
+	score := NewGame(`M,Ka1`, `Kh8,Qb3`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestBitbase140(t *testing.T) {
+	score := NewGame(`M,Kf2`, `Kh1,Qg3`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestBitbase150(t *testing.T) {
+	score := NewGame(`Kf2`, `Kh3,Qg3`).start().Evaluate()
+	expect.True(t, score < -onePawn * 5)
+}
+
+// Three knights overflow material balance index and make it look like KPK;
+// the bitbase doesn't get probed without the pawn.
+func TestBitbase160(t *testing.T) {
+	game := NewGame(`Ke1`, `Ke8,Nb8,Nc6,Ng8`)
+	expect.True(t, game.start().Evaluate() < 0)
+
+	result, err := game.Search(context.Background(), Limits{ Depth: 4 })
+	expect.Eq(t, err, nil)
+	expect.True(t, result.Score < 0)
+}
+
+// KQKR: rook skewers the king and the queen, or it's too late for that.
+func TestBitbase170(t *testing.T) {
+	game := NewGame(`Kd2,Qa2`, `M,Kg7,Rh8`)
+	expect.Eq(t, game.start().Evaluate(), 0)
+
+	result, err := game.Search(context.Background(), Limits{ Depth: 6 })
+	expect.Eq(t, err, nil)
+	expect.Eq(t, result.Move.Notation(), `h8h2`)
+	expect.True(t, result.Score > onePawn * 5)
+}
+
+func TestBitbase180(t *testing.T) {
+	score := NewGame(`M,Kd2,Qa2`, `Kg7,Rh8`).start().Evaluate()
+	expect.True(t, score > onePawn * 5)
+
+	score = NewGame(`M,Kg7,Rh8`, `Kd2,Qa2`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
@@ -104,15 +104,10 @@ func (b *Book) move(p *Position, entry Entry) Move {
 	from, to := entry.from(), entry.to()
 
 	// Check if this is a castle move. In Polyglot they are represented
-	// as E1-H1, E1-A1, E8-H8, and E8-A8.
-	if from == E1 && to == H1 {
-		return NewCastle(p, from, G1)
-	} else if from == E1 && to == A1 {
-		return NewCastle(p, from, C1)
-	} else if from == E8 && to == H8 {
-		return NewCastle(p, from, G8)
-	} else if from == E8 && to == A8 {
-		return NewCastle(p, from, C8)
+	// as the king capturing its own rook, i.e. E1-H1, E1-A1, E8-H8, and
+	// E8-A8.
+	if piece := p.pieces[from]; piece.isKing() && p.pieces[to] == rook(piece.color()) {
+		return NewCastle(p, from, let(to > from, G1, C1) + 56 * int(piece.color()))
 	} else {
 		// Special treatment for non-promo pawn moves since they might
 		// cause en-passant.
@@ -160,3 +160,12 @@ func TestBook110(t *testing.T) { // 1. d4 d5
 	expect.Eq(t, p.enpassant, uint8(0))
 	expect.Eq(t, p.castles, uint8(0x0F))
 }
+
+// Only the king capturing its own rook is a castle.
+func TestBook200(t *testing.T) {
+	book, p := &Book{}, NewGame(`Kg2,Qe1`, `Kg8`).start()
+	expect.False(t, book.move(p, polyglotEntry(E1, H1)).isCastle())
+
+	p = NewGame(`6k1/8/8/8/8/8/8/5KR1 w G - 0 1`).start()
+	expect.Eq(t, book.move(p, polyglotEntry(F1, G1)), NewCastle(p, F1, G1))
+}
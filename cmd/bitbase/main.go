@@ -0,0 +1,64 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Generates Donna's endgame bitbases by retrograde analysis and saves them as
+// Go source files. Run it from the repository root to rebuild all bitbases:
+//
+//	go run ./cmd/bitbase KPK KRK KQK KQKR
+//
+// KQKR bitbase is built off KQK one compiled into the engine so that it gets
+// regenerated last. There is no KBNK bitbase: the ending is won anyway, and
+// its distances to mate would take megabytes rather than bits per position.
+//
+package main
+
+import (
+	`github.com/michaeldv/donna`
+	`flag`
+	`fmt`
+	`os`
+	`path/filepath`
+	`strings`
+)
+
+func main() {
+	dir := flag.String(`dir`, `.`, `directory to save generated files`)
+	flag.Parse()
+
+	names := flag.Args()
+	if len(names) == 0 {
+		names = []string{ `KPK`, `KRK`, `KQK`, `KQKR` }
+	}
+
+	for _, name := range names {
+		if err := generate(*dir, strings.ToUpper(name)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func generate(dir, name string) error {
+	table, err := donna.GenerateBitbase(name)
+	if err != nil {
+		return err
+	}
+
+	filename := `data_bitbase.go`
+	if name != `KPK` {
+		filename = `data_bitbase_` + strings.ToLower(name) + `.go`
+	}
+
+	file, err := os.Create(filepath.Join(dir, filename))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = donna.WriteBitbase(file, name, table); err == nil {
+		fmt.Printf("%s: %d bytes saved to %s\n", name, len(table) * 8, file.Name())
+	}
+
+	return err
+}
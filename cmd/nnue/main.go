@@ -0,0 +1,71 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Quantizes floating point network weights saved as JSON by the trainer and
+// writes the network file to be loaded with EvalFile option:
+//
+//	go run ./cmd/nnue net.json net.nnue
+//
+// The JSON object has "scale" (centipawns per unit of output), "weights" (768
+// arrays of hidden layer weights, one per feature), "biases" (hidden layer
+// biases), "output" (output weights, side to move's half first), and "bias".
+package main
+
+import (
+	`github.com/michaeldv/donna`
+	`encoding/json`
+	`fmt`
+	`os`
+)
+
+type Weights struct {
+	Scale   int         `json:"scale"`
+	Weights [][]float64 `json:"weights"`
+	Biases  []float64   `json:"biases"`
+	Output  []float64   `json:"output"`
+	Bias    float64     `json:"bias"`
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, `Usage: nnue net.json net.nnue`)
+		os.Exit(1)
+	}
+
+	if err := convert(os.Args[1], os.Args[2]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func convert(input, output string) error {
+	file, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var weights Weights
+	if err = json.NewDecoder(file).Decode(&weights); err != nil {
+		return err
+	}
+
+	network, err := donna.NewNetwork(weights.Scale, weights.Weights, weights.Biases, weights.Output, weights.Bias)
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if err = network.Save(out); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %d neuron network to %s\n", network.Size(), output)
+
+	return out.Close()
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Tunes Donna's evaluation parameters on positions labelled with game results
+// and saves them as evaluation profile to be loaded with EvalProfile option:
+//
+//	go run ./cmd/tune -params mobilityKnight,rookOnOpen -out tuned.json quiet.epd
+//
+// Each line of the EPD file is a position followed by the result of the game,
+// ex. c9 "1-0"; or [0.5]. Quiet positions work best; use -q to resolve the
+// positions with quiescence search first.
+package main
+
+import (
+	`github.com/michaeldv/donna`
+	`flag`
+	`fmt`
+	`os`
+	`runtime`
+	`strings`
+)
+
+func main() {
+	threads := flag.Int(`threads`, runtime.NumCPU(), `number of evaluation threads`)
+	passes := flag.Int(`passes`, 0, `maximum number of tuning passes, 0 to run till no improvement`)
+	names := flag.String(`params`, ``, `comma separated parameters to tune, all if empty`)
+	profile := flag.String(`in`, ``, `evaluation profile to start with`)
+	output := flag.String(`out`, `tuned.json`, `evaluation profile to save tuned parameters to`)
+	quiet := flag.Bool(`q`, false, `resolve positions with quiescence search`)
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, `Usage: tune [options] positions.epd [more.epd ...]`)
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+
+	tuner := donna.NewTuner(*threads)
+	if *profile != `` {
+		if err := load(tuner.Profile(), *profile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	for _, name := range flag.Args() {
+		file, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		_, err = tuner.Read(file, *quiet)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Printf("Loaded %d positions\n", tuner.Size())
+	fmt.Printf("K = %.4f, error = %.6f\n", tuner.FitK(), tuner.Error())
+
+	var selected []string
+	if *names != `` {
+		selected = strings.Split(*names, `,`)
+	}
+	_, err := tuner.Tune(selected, *passes, func(pass int, err float64) {
+		fmt.Printf("Pass %d, error = %.6f\n", pass, err)
+		if e := save(tuner.Profile(), *output); e != nil {
+			fmt.Fprintln(os.Stderr, e)
+		}
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Saved tuned parameters to %s\n", *output)
+}
+
+func load(profile *donna.Profile, fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return profile.Load(file)
+}
+
+func save(profile *donna.Profile, fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = profile.Save(file); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Fits Donna's win/draw/loss model to self-play games. The games should be
+// saved by cutechess-cli so that every move comes with the engine's score,
+// ex. {+0.35/12 0.51s}. Paste the fitted model into wdl.go:
+//
+//	go run ./cmd/wdlfit /tmp/selfplay.pgn
+//
+package main
+
+import (
+	`github.com/michaeldv/donna`
+	`fmt`
+	`os`
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, `Usage: wdlfit games.pgn [more.pgn ...]`)
+		os.Exit(1)
+	}
+
+	var samples []donna.WdlSample
+	for _, name := range os.Args[1:] {
+		file, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		more, err := donna.ReadWdlSamples(file)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		samples = append(samples, more...)
+	}
+
+	fmt.Printf("Fitting %d positions...\n", len(samples))
+	fmt.Printf("var wdlModel = %v\n", donna.FitWdl(samples))
+}
@@ -24,7 +24,10 @@ const Version = `4.0`
 const (
 	MaxPly = 64
 	MaxDepth = 64
+	MaxThreads = 64
+	MaxMultiPV = 64
 	Checkmate = 0x7FFF - 1	// = 32,766
+	TablebaseWin = Checkmate - 2 * MaxPly // Tablebase win, below checkmate scores.
 	DrawScore = 0
 	ExistingScore = -1
 	Unknown = 0x7FFF	// = math.MaxInt16 = 32,767
@@ -82,18 +85,13 @@ var bit = [64]Bitmask{
 
 var castleKingside = [2]uint8{ 1, 4 }
 var castleQueenside = [2]uint8{ 2, 8 }
-var castleRights = [64]uint8{
-	13, 15, 15, 15, 12, 15, 15, 14,
-	15, 15, 15, 15, 15, 15, 15, 15,
-	15, 15, 15, 15, 15, 15, 15, 15,
-	15, 15, 15, 15, 15, 15, 15, 15,
-	15, 15, 15, 15, 15, 15, 15, 15,
-	15, 15, 15, 15, 15, 15, 15, 15,
-	15, 15, 15, 15, 15, 15, 15, 15,
-	 7, 15, 15, 15,  3, 15, 15, 11,
-}
 
 var reMove = regexp.MustCompile(`([KQRBNEC]?)([a-h])([1-8])`)
+var reNotation = regexp.MustCompile(`^[a-h][1-8][a-h][1-8][qrbnQRBN]?$`)
+var reScore = regexp.MustCompile(`^([+-]?\d+\.\d+)/\d+`) // Score comment, ex. {+0.35/12 0.51s}.
+var reSan = regexp.MustCompile(`^([KQRBN]?)([a-h]?)([1-8]?)x?([a-h][1-8])=?([QRBN]?)$`)
+var reResult = regexp.MustCompile(`\[([01](?:\.\d+)?)\]`) // Game result label, ex. [0.5].
+var rePair = regexp.MustCompile(`\[\s+(-?\d+),\s+(-?\d+)\s+\]`) // Indented JSON pair, ex. [\n 12,\n 34\n].
 
 var maskRank = [8]Bitmask{ // 0 to 8
 	0x00000000000000FF, 0x000000000000FF00, 0x0000000000FF0000, 0x00000000FF000000,
@@ -121,24 +119,6 @@ var homeTurf = [2]Bitmask{
 	0x000000003C3C3C00, 0x003C3C3C00000000, // 0x000000003c7e7e00, 0x007e7e3c00000000, ?!
 }
 
-// Castle squares that should be *empty* in order for the castle to be valid.
-var gapKing = [2]Bitmask{
-	bit[F1]|bit[G1], bit[F8]|bit[G8],
-}
-
-var gapQueen = [2]Bitmask{
-	bit[B1]|bit[C1]|bit[D1], bit[B8]|bit[C8]|bit[D8],
-}
-
-// Castle squares that should be *safe* in order for the castle to be valid.
-var castleKing = [2]Bitmask{
-	bit[E1]|bit[F1]|bit[G1], bit[E8]|bit[F8]|bit[G8],
-}
-
-var castleQueen = [2]Bitmask{
-	bit[C1]|bit[D1]|bit[E1], bit[C8]|bit[D8]|bit[E8],
-}
-
 // Base offsets to polyglotRandom table for each of the pieces. Note that we're
 // mapping our piece representation to polyglot, i.e. (Piece-1) for whites and
 // (Piece-3) for blacks.
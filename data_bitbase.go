@@ -2,6 +2,8 @@
 // Use of this source code is governed by a MIT-style license that can
 // be found in the LICENSE file.
 
+// Generated by cmd/bitbase, do not edit.
+
 package donna
 
 var bitbase = [2*64*48]uint64 {
@@ -1542,153 +1544,3 @@ var bitbase = [2*64*48]uint64 {
 	0xFFFFFFFFFFFFFFFF, 0x703F303FFFFFFFFF, 0x5555555555555555, 0x00550055FD555555,
 	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
 }
-
-
-/*******************************************************************************
-
-import `fmt`
-
-// 00000000 00000000 0000000X Color
-// 00000000 00000000 0XXXXXX0 White King square (0..63)
-// 00000000 000XXXXX X0000000 Black King square (0..63)
-// 00000XXX XXX00000 00000000 White Pawn square (0..48)
-
-var bitbase [2*64*48]uint64
-
-// Generates KPK bitbase.
-func Bitbase() {
-	var base[2*64*64*48]int
-
-	const (Invalid = iota; Unclear = 1; Draw = 2; Win = 4)
-
-	pack := func(color, wKing, bKing, wPawn int) int {
-		return color + (wKing << 1) + (bKing << 7) + ((wPawn - 8) << 13)
-	}
-
-	unpack := func(index int) (int, int, int, int) {
-		return index & 1,
-		       (index >> 1) & 0x3F,
-		       (index >> 7) & 0x3F,
-		       ((index >> 13) & 0x3F) + 8
-	}
-
-	initial := func(index int) int {
-		color, wKing, bKing, wPawn := unpack(index)
-
-		// The wPawn can be promoted if: a) black king is too far to capture it or
-		// b) promotion square is protected by the white king.		
-		promoted := func () bool {
-			square := wPawn + 8
-			return square >= A8 && (distance[bKing][square] > 1 || distance[wKing][square] == 1)
-		}
-
-		undefended := func () bool {
-			return distance[bKing][wPawn] == 1 && distance[wKing][wPawn] > 1
-		}
-
-		cornered := func () bool {
-			return kingMoves[wKing] & ^(kingMoves[bKing] | bit[wPawn]) == 0 && wPawn + 8 == wKing
-		}
-
-		stalemate := func () bool {
-			return kingMoves[bKing] & ^(kingMoves[wKing] | pawnMoves[White][wPawn]) == 0
-		}
-
-		// Reject the index if squares violate chess rules.
-		if wKing == wPawn || bKing == wPawn || distance[wKing][bKing] <= 1 {
-			return Invalid
-		}
-
-		if color == White {
-			// Reject the index if black king is in check.
-			if pawnMoves[White][wPawn] & bit[bKing] != 0 {
-				return Invalid
-			}
-			if cornered() {
-				return Draw
-			}
-			if promoted() {
-				return Win
-			}
-		} else if undefended() || stalemate() {
-			return Draw
-		}
-		return Unclear
-	}
-
-	iterate := func(index int) (outcome int) {
- 		color, wKing, bKing, wPawn := unpack(index)
-
-		if color == White {
-			moves := kingMoves[wKing]
-			for moves != 0 {
-				outcome |= base[pack(Black, moves.pop(), bKing, wPawn)]
-			}
-			if row := row(wPawn); row < A7H7 {
-				push := wPawn + 8
-				outcome |= base[pack(Black, wKing, bKing, push)]
-
-				if row == A2H2 && push != wKing && push != bKing {
-					outcome |= base[pack(Black, wKing, bKing, push + 8)]
-				}
-			}
-			if outcome & Win != 0 {
-				outcome = Win
-			} else if outcome & Unclear != 0 {
-				outcome = Unclear
-			} else {
-				outcome = Draw
-			}
-		} else {
-			moves := kingMoves[bKing]
-			for moves != 0 {
-				outcome |= base[pack(White, wKing, moves.pop(), wPawn)]
-			}
-			if outcome & Draw != 0 {
-				outcome = Draw
-			} else if outcome & Unclear != 0 {
-				outcome = Unclear
-			} else {
-				outcome = Win
-			}
-		}
-
-       		return
-	}
-
-	// Initial pass.
-	for i := 0; i < len(base); i++ {
-		base[i] = initial(i)
-	}
-
-	// Continuous iterations until no updates are made.
-	for {
-		updates := 0
-		fmt.Print(`.`)
-		for i := 0; i < len(base); i++ {
-			if base[i] == Unclear {
-				base[i] = iterate(i)
-				if base[i] != Unclear {
-					updates++
-				}
-			}
-		}
-		if updates == 0 {
-			break
-		}
-	}
-	fmt.Println()
-
-	// Pack findings into 64-bit bitbase entries.
-	for i := 0; i < len(base); i++ {
-		if base[i] == Win {
-			bitbase[i / 64] |= 1 << uint(i & 0x3F)
-		}
-	}
-
-	// Print the resulting bitbase.
-	for i := 0; i < len(bitbase); i += 4 {
-		fmt.Printf("\t0x%016X, 0x%016X, 0x%016X, 0x%016X,\n", bitbase[i], bitbase[i+1], bitbase[i+2], bitbase[i+3])
-	}
-}
-*******************************************************************************/
@@ -0,0 +1,330 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Generated by cmd/bitbase, do not edit.
+
+package donna
+
+var bitbaseKQK = [2*10*64]uint64 {
+	0xCA80000000000000, 0xFCAAAFCA8A3CA820, 0x00000000AAAFCAAA, 0xCF3CC030CC000000,
+	0xFFFFCFFFFCFFFFCC, 0xC000A8F30FCAA8A8, 0xFCFFFFC0CFFC003F, 0xFFCAAAA8FFFFCFFF,
+	0xFFFC02BA803FFCF3, 0xFFFFCFFFFCFFFFC0, 0xC3FFFCFFFFCAAAA8, 0xFCFFFFC2EBA83FFF,
+	0xFFCAAAA8FFFFCFFF, 0xFFFCFFFFCFFFFCFF, 0xFFFFCFFFFCEEBA8F, 0xCFFFFCFFFFCAAAA8,
+	0xA8FFFFCFFFFCFFFF, 0xFFCAAAA8FFFFCEEB, 0xFFFCFFFFCFFFFCFF, 0xEEBA8FFFFCFFFFCF,
+	0x2000020000000820, 0xF2AAAF2A8A32A820, 0x00000820AAAF2AAA, 0xCF33C03030000200,
+	0xFFFF3FFFF3FFFF3C, 0x2000F3A20A2FFCF3, 0xF3FFFF30CFF3002A, 0xBA2FFFF3FFFF3FFF,
+	0xAEA203FF303FF3A2, 0xFFFF3FFFF3FFFF30, 0x33FFF3AABA2FFFF3, 0xF3BAEA23FFF33FFF,
+	0xBA2FFFF3FFFF3FFF, 0xFFF3FFFF3FFFF3AA, 0xFFFF3BAEA2FFFF3F, 0x3FFFF3AABA2FFFF3,
+	0xF3FFFF3FFFF3FFFF, 0xBA2FFFF3BAEA2FFF, 0xFFF3FFFF3FFFF3AA, 0xFFFF3FFFF3FFFF3F,
+	0x80000002080AA880, 0xCAAAACAA8A0A0020, 0x080F74C0AAACAAAA, 0xCF0F002080000002,
+	0xFFFCFFFFCFFFFCFC, 0xF0008AF30CFAA88A, 0xCFFFFCF08A8A003C, 0xFCFFFFCFFFFCFFFF,
+	0xFFCF03FCF02E8AF3, 0xFFFCFFFFCFABA8A0, 0xF2EE8AFFFCFFFFCF, 0x8AFFFCF3FFCF3FFC,
+	0xFCFFFFCFFFFCFABA, 0xFFCFFFFCFAEE8AFF, 0xABA8AFFFCFFFFCFF, 0xFAEE8AFFFCFFFFCF,
+	0xCFFFFCFFFFCFFFFC, 0xFCFFFFCFFFFCFFFF, 0xFFCFFFFCFAEE8AFF, 0xFFFCFFFFCFFFFCFF,
+	0x000000AA000AA830, 0x2AAAA2A008200000, 0x000FFC30AAA2AAAA, 0x08200000000000F3,
+	0xFFF3FFFF3FFFF3F0, 0xA0003FA202AFFC3F, 0x3FAAA2A0CF3F0022, 0xF3FAAE2AFFF3FFFF,
+	0xFF3F03A2A03F3FF3, 0xFFF3FAAA2AFFF3F0, 0xA3FF3FFFF3FFFF3F, 0x3FFFF3F3FF3F3BA2,
+	0xF3FFFF3FAAA2AFFF, 0xFF3FFBA2AFFF3FFF, 0xFFF3FFFF3FFFF3FF, 0xAFFF3FFFF3FFFF3F,
+	0x3FFFF3FFFF3FFBA2, 0xF3FFFF3FFFF3FFFF, 0xFF3FFBA2AFFF3FFF, 0xFFF3FFFF3FFFF3FF,
+	0x0A8002AA0C0AA8F0, 0xAA02080000000000, 0x0C0FFCF0AAAAAAAA, 0x000000000C0003F3,
+	0xFFFFFFFFFF020800, 0xF000AAF30FFFFCFF, 0xAAFFFFF08AAA003F, 0xAAAFFFFFFFFFFAAA,
+	0xAAAA03FFF03FFFA3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFAFAAA, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xAA8002AA0C0AA8F0, 0x000000000000A820, 0x0C0FFCF0AAAAA000, 0x0000C030FC0003F3,
+	0xFFFFF00000000000, 0xA000FFF30FFFFCFF, 0xFFAAAAA0CFFF002A, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFBAAAAFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFBAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xAA8002AA0C0AA8F0, 0x0000000A8A2AA820, 0x0C0FFCF000000000, 0xCF3FC030FC0003F3,
+	0x000000000000000C, 0xF000FFF30FFFFCFF, 0xAAFFFFF08AAA003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF02AAA03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF2AAAAFFFFFFFFFF, 0xAAFFFFF3FFFF3FFF,
+	0xAAAFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFEA, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFFFFFFEAAAA,
+	0xAAFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xAA8002AA0C0AA8F0, 0x00AAAAAA8A2AA820, 0x0C0FFCF000000000, 0xCF3FC030FC0003F3,
+	0x0000000000FFFFFC, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xAAAA03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xA3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF2AAA,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFAAAAAFF, 0xAAAAAFFFFFFFFFFF, 0xFFFFFFAAAAAFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC0030000000000, 0xFFFFFFFFCF3FFC30, 0x00000000FFFFFFFF, 0x8F2A8030A8000200,
+	0xABFAAABFAAABFAA8, 0xF000FF0000A0000A, 0xFFFFFFF0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFFFFFF0, 0xA3FFFFFFFFFAAAAA, 0xFFFFFFF3FFFF2AAA,
+	0xFFFAAAAAFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFAAAAA,
+	0xFFAAAAAFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xF080020800008820, 0xFFFFCFFFCC3FFC00, 0x00008820FFCFFFFC, 0x8C2A8000A0000200,
+	0xABCAAABCAAABCAA8, 0xF0002A0002A0882A, 0xFFFFCFF0CCFF000F, 0x8AAFFCFFFFCFFFFC,
+	0xFCFF028AA03CFFA2, 0xFFCFFFFCFFFFCFF0, 0xF3FCFFAA8AAFFCFF, 0xFFFFCFF2E8AA3FCF,
+	0x8AAFFCFFFFCFFFFC, 0xFCFFFFCFFFFCFFAA, 0xFFCFFFFCFFEE8AAF, 0xFFFCFFAA8AAFFCFF,
+	0xAAFFCFFFFCFFFFCF, 0x8AAFFCFFFFCFFEE8, 0xFCFFFFCFFFFCFFAA, 0xEE8AAFFCFFFFCFFF,
+	0x8280002A08055050, 0xFFFF3FFFC33F2820, 0x080AA0A0FF3FFFF3, 0x822A002080000022,
+	0xAB2AAAB2AAAB2AA8, 0x8000A8220A8F70FF, 0xFFFF3FF0C3FF002A, 0x3FFAA2AAFF3FFFF3,
+	0xA2AA033FF022AAF3, 0xFF3FFFF3FFFF3FF0, 0xF2E2AAFF3FFFF3FF, 0xFFBA2AA3F3FF3F3F,
+	0x3FFFF3FFFF3FFFF3, 0xF3FFFF3FFAE2AAFF, 0xFF3FFBA2AAFF3FFF, 0xFAE2AAFF3FFFF3FF,
+	0xFFFF3FFFF3FFFF3F, 0x3FFFF3FFBA2AAFF3, 0xF3FFFF3FFAE2AAFF, 0xFF3FFFF3FFFF3FFF,
+	0x028000FC0C0FCCD0, 0xFFFCFFF288202800, 0x080A8CA0FCFFFFCF, 0x88200000000000A0,
+	0xA8AAAA8AAAA8AAA0, 0x0000A0F00FFFCCFF, 0xFFFCFFF088A0000A, 0xAAAFCFFFFCFFFFCF,
+	0xCFFF00AAA00FFFA0, 0xFCFFFFCFFFA8AAA0, 0xA3CFFFFCFFFACAAA, 0xAAFCFFF3CFFF38AA,
+	0xFFFFCFFFFCFFFA8A, 0xCFFFF8AAAFCFFFFC, 0xA8AAAFCFFFFCFFFF, 0xAFCFFFFCFFFFCFFF,
+	0xFFFCFFFFCFFFF8AA, 0xFFFFCFFFFCFFFFCF, 0xCFFFF8AAAFCFFFFC, 0xFCFFFFCFFFFCFFFF,
+	0x0FC001FF0C0FFCD0, 0xFF22080200002000, 0x080ABCA0FFFFFFFF, 0x00000000080002A3,
+	0xAAAAAAAAAA220800, 0x0000FFF30FFFFCFF, 0xFF22080000800008, 0xFFFFFFFFFFFFFFFF,
+	0xAAAA03FFF02AAAF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFBAAAAFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFBAAAAAAAAAFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0x7FC003FF0C0FFCD0, 0x000000000000FC10, 0x080ABCA055555000, 0x00008020A80002A3,
+	0xAAAAA00000000000, 0xF000FFF30FFFFCFF, 0x000000000000003F, 0xFFFFFFFFFFFFF000,
+	0xFFFF02AAA03FFFF3, 0xAAAAAFFFFFAAAAA0, 0xF2AAAAFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFEA, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFEAAAA,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCD0, 0x0000000FC71FFC30, 0x080ABCA000000000, 0x8A2A8020A80002A3,
+	0x0000000000000008, 0xF000FFF30FFFFCFF, 0x00000000CFFF003F, 0xFFFFFFFF00000000,
+	0xAAAA03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xA3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF2AAA,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFAAAAAFF, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFAAAAAFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCD0, 0x00FDF7FFCF3FFC30, 0x080ABCA000000000, 0x8A2A8020A80002A3,
+	0x0000000000AAAAA8, 0xF000FFF30FFFFCFF, 0x00FFFFF0CFFF003F, 0xFFFFFFFF00000000,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFAAAAAFFFFFFF, 0xAAAAAFFFFFFFFFFF, 0xFAAAAAFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFAA, 0xAAAAAFFFFFFFFFFF,
+	0xFA8002D5040AA8A0, 0xFFFFFFFFCF3FFC30, 0x00000000FFFFFFFF, 0xCF3FC030FC000300,
+	0xFFFFFFFFFFFFFFFC, 0xA000AA0000000000, 0xAABEAAA0CAAA002A, 0x20000200BEAAABEA,
+	0xFFFF03FFF03FFF00, 0xFFFFFFFFFFFFFFF0, 0xF2AAAAFFFFFAAAAA, 0xFFFFFFF3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFAAAAA,
+	0xFFFFFFFAAAAAFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xAD4003AA08055450, 0xFFFFFFFFCF3FA820, 0x00008800FFFFFFFF, 0xCF3FC030F0000000,
+	0xFFFFFFFFFFFFFFFC, 0xA000000000008800, 0xAABEAAA0CAAA002A, 0xA0008A00BEAAABEA,
+	0xFFFF03FFF00A0000, 0xFFFFFFFFFFFFFFF0, 0xA3FFFFAAAAAFFFFF, 0xFFFFFFF3FFFF2AAA,
+	0xAAAFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFAAAAAFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFAAAAAFFFFFF,
+	0xFA0002F30C0A28A0, 0xFFF3FFFA0A2AD030, 0x000F34D0F3FFFF3F, 0x0F3F8020080000A2,
+	0xF3FFFF3FFFF3FFFC, 0x000000A2000A28AA, 0xAAB2AAA00AAA0020, 0xA00F3FFFB2AAAB2A,
+	0x3FFF02A0002A00A2, 0xF3FFFF3FFFF3FFF0, 0xF22AAAF3FFFA2AAA, 0xFFF3FFF22AAA33FF,
+	0xFFFF3FFFF3FFFF3F, 0x3FFFF3FFFA2AAAF3, 0xF3FFFF3FFFE2AAAF, 0xFA2AAAF3FFFF3FFF,
+	0xAAF3FFFF3FFFF3FF, 0xFFFF3FFFF3FFFE2A, 0x3FFFF3FFFA2AAAF3, 0xE2AAAF3FFFF3FFFF,
+	0xAC40018A080CFCF0, 0xFF8AAAACCF3F8820, 0x0C0CFCF0CFFFFCFF, 0x88008000080000C3,
+	0xCFFFFCFFFFCFFFF8, 0x000000820AA8E8AA, 0xAA8AAAA088000000, 0xFFFCFFFF8AAAA8AA,
+	0xA8000280002800C3, 0xCFFFFCFFFFCFFFF0, 0xA0FFFF8AAAACFFFF, 0xFF8AAAA0FFFF0AAA,
+	0xFFF8AAAACFFFFCFF, 0xFFFFCAAAACFFFFCF, 0xCFFFF8AAAACFFFFC, 0xACFFFFCFFFFCFFFF,
+	0xFFCFFFFCFFFFCAAA, 0xFFFCFFFF8AAAACFF, 0xFFFFCAAAACFFFFCF, 0xCFFFFCFFFFCFFFFC,
+	0x7A8002FF0C0FFCF0, 0xAAFFFFFA8A2AD410, 0x0C0FFCF0FFFFFAAA, 0x000080000C0003F3,
+	0xFFFFFFFFFFA20008, 0x0000AAB20AABE8AA, 0xAAA2000000000000, 0xFFFFFFFFAAAAAAAA,
+	0x20000200003FFFF3, 0xFFFFFFFFFFA20000, 0xF2AAAAFFFFFFFFFF, 0xAAFFFFF2AAAA3FFF,
+	0xAAAFFFFFFFFFFAAA, 0xAAAAFFFFFFFFFFEA, 0xAAAAAFFFFFFFFFFA, 0xFFFFFFFFFFFEAAAA,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xAFC003FF0C0FFCF0, 0xFFAAAAAD471FA820, 0x0C0FFCF0AAAAAFFF, 0x0000C030FC0003F3,
+	0xFFFFF00000000000, 0xA000AAB20AABE8AA, 0x000000000000002A, 0xFFFFFFFFAAAAA000,
+	0x000003FFF03FFFF3, 0xFFFFF00000000000, 0xA3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF2AAA,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFAAAAAFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCF0, 0xAADDF7FA8A2AFC30, 0x0C0FFCF055555AAA, 0xCF3FC030FC0003F3,
+	0x000000000000000C, 0xA000AAB20AABE8AA, 0x000000008AAA002A, 0xFFFFFFFF00000000,
+	0xFFFF03FFF03FFFF3, 0x0000000000000000, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFAAAAAFFFFFFF, 0xFFFFFAAAAAFFFFFF, 0xFAAAAAFFFFFFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xAAAFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFAA, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0x0000000000FFFFFC, 0xA000AAB20AABE8AA, 0x00AAAAA08AAA002A, 0xFFFFFFFF00000000,
+	0xFFFF03FFF03FFFF3, 0x0000000000FFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xAAAAFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFA, 0xAFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFAAAA, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFAAAAAFF, 0xAAAAAFFFFFFFFFFF,
+	0xAFC003FF0C0AA8A0, 0xFFFFFFFFCF3FA820, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000FF0000000000, 0xFFFFFFF0CFFF003F, 0x00000000FFFFFFFF,
+	0xAAAA02AAA02AAA00, 0xEAAAAEAAAAEAAAA0, 0xF3FFFF0000000000, 0xFFFFFFF3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF, 0xAFFFFFFFFFFAAAAA,
+	0xFFFFFFFFFFFFAAAA, 0xFFFAAAAAFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFFC003AA080FFCF0, 0xFFFFFFFACA2AFC30, 0x080FFCF0FFFFFFFF, 0xCF3F8020AC0003A2,
+	0xFFFFFFFFFFFFFFFC, 0xF000000000008000, 0xFFFFFFF0CFFF003F, 0x00008000FFFFFFFF,
+	0xAAAA02AAA0000000, 0xEAAAAEAAAAEAAAA0, 0xF080000800008000, 0xFFFFFFF3FFFF3FFF,
+	0xAAAFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFAAAAAFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFAAAAAF,
+	0xFAC002FF0C0FF4D0, 0xFFBAAAAFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0x8A2AC030F80002F3,
+	0xFFFFFFFFFFFFFFF8, 0x000000A0000F74D5, 0xFFFFFFF0CFFF0000, 0x000AAAAAFFFFFFFF,
+	0xAAAA0000000000A0, 0xEAAAAEAAAAEAAAA0, 0x028000A8000FFFFF, 0xFFFFFFF3FFFF2800,
+	0xFFFAAAAAFFFFFFFF, 0xAAAAFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFA, 0xFAAAAAFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFAAAAAFFFFFF,
+	0xA3C0033F0C02E8A0, 0xAA3FFFF3CF3F3820, 0x0803FCF03FFFF2AA, 0xCF3F0020A0000322,
+	0x3FFFF3FFFF2AAAA0, 0x000000330FF3FCFF, 0xFF3FFFF080000000, 0xAAA2AAAA3FFFF3FF,
+	0x8000000000000022, 0x2AAAA2AAAA2AAAA0, 0x0280003FFFF3FFFF, 0xFF3FFFF280002800,
+	0xAAA3FFFF3FFFF3FF, 0xFFFF2AAAA3FFFF2A, 0x3FFFF3FFFF2AAAA3, 0xA3FFFF3FFFF2AAAA,
+	0xAA3FFFF3FFFF2AAA, 0xFFF3FFFF3FFFF2AA, 0xFFFF2AAAA3FFFF3F, 0x2AAAA3FFFF3FFFF3,
+	0xFFC003BA080FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0AAAAAFFF, 0x8A2AC030F80002F3,
+	0xFFFFFAAAAAFFFFF8, 0x0000FFF30FFFFCFF, 0xFFA0000000000000, 0xAAAEAAAAFFFFFFFF,
+	0x00000000002AAAE2, 0xAAAAAAAAAAA00000, 0x03FFFFFFFFFFFFFF, 0xFFA0000200002000,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFAAAAAFF, 0xFFFFFAAAAAFFFFFA, 0xFFFFFFAAAAAFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFAAAAAAAAAAFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFA8002FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3F8020AC0003F3,
+	0xAAAAAFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0x000000000000003F, 0xAAAEAAAAFFFFF000,
+	0x000002AAA02AAAE2, 0xAAAAA00000000000, 0xF3FFFFFFFFFFFFFF, 0x0000000000003FFF,
+	0xFFFFFFFFFFFFF000, 0xFFFFAAAAAFFFFFFF, 0xAAAAAFFFFFAAAAAF, 0xFAAAAAFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFAAAAAF,
+	0xAFC003FF0C0FFCF0, 0xAAFFFFFFCF3FA820, 0x0C0FFCF0FFFFFAAA, 0x8A2AC030FC0003F3,
+	0xFFFFFAAAAAFFFFF8, 0xF000FFF30FFFFCFF, 0x00000000CFFF003F, 0xAAAEAAAA00000000,
+	0xAAAA02AAA02AAAE2, 0x0000000000000000, 0xF3FFFFFFFFFFFFFF, 0x00000003FFFF3FFF,
+	0xFFFFFFFF00000000, 0xAAAAFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFA, 0xAFFFFFFFFFFFFFFF,
+	0xAAFFFFFFFFFFAAAA, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFAAAAAFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xAAAAAFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0x00FFFFF0CFFF003F, 0xAAAEAAAA00000000,
+	0xAAAA02AAA02AAAE2, 0x0000000000AAAAA0, 0xF3FFFFFFFFFFFFFF, 0x00FFFFF3FFFF3FFF,
+	0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFAAAAAFFF, 0xFFFFAAAAAFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFACA2AFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3F8020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000AAF30FFAA8AA, 0xFFFFFFF0CFFF003F, 0x00000000FFFFFFFF,
+	0xFFFF03FFF03FFF00, 0xFFFFFFFFFFFFFFF0, 0xA2AAAA0000000000, 0xAAAAAAA2AAAA2AAA,
+	0x00000000AAAAAAAA, 0xFFFFFFFFFFFFFF00, 0xFFFFFFFFFFFFFFFF, 0xFAAAAAFFFFFAAAAA,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFBAAAAFCF3FFC30, 0x080FFCF0FFFFFFFF, 0x8A2AC030FC0003A2,
+	0xFFFFFFFFFFFFFFF8, 0xA000FFA20AAFFCF5, 0xFFFFFFF0CFFF002A, 0x00000000FFFFFFFF,
+	0xFFFF03FFF0000000, 0xFFFFFFFFFFFFFFF0, 0xA000000000000000, 0xAAAAAAA2AAAA2AAA,
+	0x00000000AAAAAAAA, 0xFFFFFFFFF0000000, 0xFFFFFFFFFFFFFFFF, 0xAFFFFFAAAAAFFFFF,
+	0xFFFFFFFFFFFFAAAA, 0xAAAFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFA,
+	0xFAC002FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFEAA, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFEAAAAC, 0xF000AAF30FFAA8AA, 0xFFFFFFF08AAA003F, 0x000F75FFFFFFFFFF,
+	0xFFFF000000000080, 0xFFFFFFFFFFFFFFF0, 0x00000080000AAAAA, 0xAAAAAAA2AAAA0000,
+	0x000FFFFFAAAAAAAA, 0xFFFF800008000080, 0xFFFFFFFFFFFFFFFF, 0xFAAAAAFFFFFAAAAA,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFAAAAAF,
+	0xAFC003FF0C0FF4D0, 0xFFFFFFFFCF3FF820, 0x0C0AA8A0AAAAAFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xA000FFA20AAFFCFF, 0xFFAAAAA0CFFF002A, 0xFFFFFFFFFFFFFFFF,
+	0x00000000000000F3, 0xFFFFFFFFFFFFFFF0, 0x000000AAAAAAAAAA, 0xAAAAAAA000000000,
+	0xFFFFFFFFAAAAAAAA, 0x00008000080000FF, 0xFFFFFFFFFFFFFFF8, 0xAFFFFFAAAAAFFFFF,
+	0xFFAAAAAFFFFFAAAA, 0xFFFAAAAAFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0EE8A0, 0xFFFFFFFA8A2AFC30, 0x080FFCF0FFFFFFFF, 0x8A2AC030FC0003E2,
+	0xAAAAAFFFFFFFFFF8, 0xF000AAF30FFFFCFF, 0xAAFFFFF08AAA003F, 0xFFFFFFFFFFFFFAAA,
+	0x00000000003FFFF3, 0xFFFFFFFFFF800000, 0x02AAAAAAAAAAAAAA, 0xAA80000000000000,
+	0xFFFFFFFFAAAAAAAA, 0x000080000FFFFFFF, 0xFFFFFFFFFF800008, 0xFAAAAAFFFFFFFFFF,
+	0xAAFFFFFAAAAAFFFF, 0xAAAFFFFFFFFFFAAA, 0xAAAAFFFFFFFFFFAA, 0xAAAAAFFFFFFFFFFA,
+	0xFFC003BA080FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFAAAAAC, 0xA000FFF30FFFFCFF, 0xFFAAAAA0CFFF002A, 0xFFFFFFFFAAAAAFFF,
+	0x000003FFF03FFFF3, 0xFFFFF00000000000, 0xA2AAAAAAAAAAAAAA, 0x0000000000002AAA,
+	0xFFFFFFFFAAAAA000, 0x0000FFFFFFFFFFFF, 0xFFFFF00000000000, 0xAFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFAAAA, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFAAAAAF,
+	0xFA8002FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3F8020AC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFFFCFF, 0xAAFFFFF08AAA003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFF3, 0x0000000000000000, 0xA2AAAAAAAAAAAAAA, 0x00000002AAAA2AAA,
+	0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x000000000000000F, 0xFFFFFFFFFFFFFFFF,
+	0xAAFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFAAAAAFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FA820, 0x0C0FFCF0AAAAAFFF, 0x8A2AC030FC0003F3,
+	0xAAAAAFFFFFFFFFF8, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0x0000000000FFFFF0, 0xA2AAAAAAAAAAAAAA, 0x00AAAAA2AAAA2AAA,
+	0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x0000000000FFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFAAAAAFFF, 0xAAAAFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFA,
+	0xFFC003FF0C0AA8A0, 0xFFBAAAAFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xA000FFF30FFAA8AA, 0xFFFFFFF0CFFF002A, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFF0000000000, 0xFFFFFFF3FFFF3FFF,
+	0x00000000FFFFFFFF, 0xAAAAAAAAAAAAAA00, 0xAAAAAAAAAAAAAAAA, 0xFFFFFF0000000000,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xAAFFFFFFCF3FFC30, 0x080FFCF0FFFFFEAA, 0xCF3FC030FC0003A2,
+	0xFFFFFFFFFFEAAAAC, 0xF000FFA20AAFFCFF, 0xFFFFFFF08AAA003F, 0xAAAFFDFFFFFFFFFF,
+	0xFFFF02AAA03FFFA2, 0xFFFFFFFFFFFFFFF0, 0xF000000000000000, 0xFFFFFFF3FFFF3FFF,
+	0x00000000FFFFFFFF, 0xAAAAAAAAA0000000, 0xAAAAAAAAAAAAAAAA, 0xF000000000000000,
+	0xFFFFFFFFFFFFFFFF, 0xAAA55555FFFFFFFF, 0xFFFFAAAAAFFFFFAA, 0xFFFFFFFFFFFFFFFF,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030F80002F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000AAF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xAAAA03FFF02AAAF3, 0xFFFFFFFFFFFFFFF0, 0x00000000000F7FFF, 0xFFFFFFF3FFFF0000,
+	0x000AAAAAFFFFFFFF, 0xAAAA000000000000, 0xAAAAAAAAAAAAAAAA, 0x00000000000FFFFF,
+	0xFFFFFFFFFFFF0000, 0xFFFAAAAAFFFFFFFF, 0xAAAAFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFA,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xA000FFF30FFAA8AA, 0xAAFFFFF0CFFF002A, 0xAAAFFFFFFFFFFAAA,
+	0xFFFF02AAA03FFFA2, 0xFFFFFFFFFFAAAAA0, 0x000000FFFFFFFFFF, 0xFFFFFFF000000000,
+	0xAAAAAAAAFFFFFFFF, 0x00000000000000AA, 0xAAAAAAAAAAAAAAA0, 0x000000FFFFFFFFFF,
+	0xFFFFFFF000000000, 0xAAAFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFAA, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FF4D0, 0xFFFFFFFA8A2AFC30, 0x0C0AA8A0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFA20AAFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFAAAAAFFF,
+	0xAAAA03FFF02AAAF3, 0xFFFFFAAAAAFFFFF0, 0x03FFFFFFFFFFFFFF, 0xFF00000000000000,
+	0xAAAAAAAAFFFFFFFF, 0x000000000AAAAAAA, 0xAAAAAAAAAA000000, 0x0FFFFFFFFFFFFFFF,
+	0xFF00000000000000, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFAAAAAFF, 0xFFFFFAAAAAFFFFFA,
+	0xFFC003FF0C0EE8A0, 0xFFAAAAAFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003E2,
+	0xFFFFFFFFFFAAAAAC, 0xF000AAF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF02AAA03FFFF3, 0xAAAAAFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0x0000000000003FFF,
+	0xAAAAAAAAFFFFF000, 0x0000AAAAAAAAAAAA, 0xAAAAA00000000000, 0xFFFFFFFFFFFFFFFF,
+	0x000000000000FFFF, 0xFFFFFFFFFFFFF000, 0xFFFFAAAAAFFFFFFF, 0xAAAAAFFFFFAAAAAF,
+	0xFFC003BA080FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030F80002F3,
+	0xFFFFFAAAAAFFFFFC, 0xA000FFF30FFFFCFF, 0xAAFFFFF0CFFF002A, 0xFFFFFFFFFFFFFAAA,
+	0xAAAA03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0x00000003FFFF3FFF,
+	0xAAAAAAAA00000000, 0xAAAAAAAAAAAAAAAA, 0x000000000000000A, 0xFFFFFFFFFFFFFFFF,
+	0x0000000FFFFFFFFF, 0xFFFFFFFF00000000, 0xAAAAFFFFFFFFFFFF, 0x55555AAAAAFFFFFA,
+	0xFA8002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3F8020AC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0x00FFFFF3FFFF3FFF,
+	0xAAAAAAAA00000000, 0xAAAAAAAAAAAAAAAA, 0x0000000000AAAAAA, 0xFFFFFFFFFFFFFFFF,
+	0x00FFFFFFFFFFFFFF, 0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFAAAAAF,
+	0xFFC003FF0C0AA8A0, 0xAAFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFEAA, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFEAAAAC, 0xF000FFF30FFAA8AA, 0xFFFFFFF08AAA003F, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF02AAA03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF2AAAAFFFFFAAAAA, 0xFFFFFFF3FFFF3FFF,
+	0x00000000FFFFFFFF, 0xFFFFFFFFFFFFFF00, 0xFFFFFFFFFFFFFFFF, 0xAAAAAA0000000000,
+	0xAAAAAAAAAAAAAAAA, 0x00000000AAAAAAAA, 0xFFFFFFFFFFFFFF00, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0AAAAAFFF, 0xCF3FC030FC0003A2,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFA20AAFFCFF, 0xFFAAAAA0CFFF003F, 0xAAAFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFA2, 0xFFFFFFFFFFFFFFF0, 0xA3FFFFAAAAAFFFFF, 0xFFFFFFF3FFFF2AAA,
+	0x00000000FFFFFFFF, 0xFFFFFFFFF0000000, 0xFFFFFFFFFFFFFFFF, 0xA000000000000000,
+	0xAAAAAAAAAAAAAAAA, 0x00000000AAAAAAAA, 0xFFFFFFFFF0000000, 0xFFFFFFFFFFFFFFFF,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFAAAAA0, 0xF2AAAAFFFFFAAAAA, 0xFFFFFFF2AAAA3FFF,
+	0x000FFFFFFFFFFFFF, 0xFFFF000000000000, 0xFFFFFFFFFFFFFFFF, 0x00000000000AAAAA,
+	0xAAAAAAAAAAAA0000, 0x00055555AAAAAAAA, 0xFFFF000000000000, 0xFFFFFFFFFFFFFFFF,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFAAAAAAAAAAFFF,
+	0xFFFF02AAA03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xA3FFFFAAAAAFFFFF, 0xFFAAAAA3FFFF2AAA,
+	0xFFFFFFFFFFFFFFFF, 0x00000000000000FF, 0xFFFFFFFFFFFFFFF0, 0x000000AAAAAAAAAA,
+	0xAAAAAAA000000000, 0xFFFFFFFFAAAAAAAA, 0x00000000000000FF, 0xFFFFFFFFFFFFFFF0,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFF30FFAA8AA, 0xFFFFFFF08AAA003F, 0xAAAFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFA2, 0xAAAAAFFFFFFFFFF0, 0xF2AAAAFFFFFFFFFF, 0xAAFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFAAA, 0x000000000FFFFFFF, 0xFFFFFFFFFF000000, 0x0AAAAAAAAAAAAAAA,
+	0xAA00000000000000, 0xFFFFFFFFAAAAAAAA, 0x000000000FFFFFFF, 0xFFFFFFFFFF000000,
+	0xFFC003FF0C0FF4D0, 0xFFAAAAAFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFA20AAFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFAAAAA0, 0xA3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF2AAA,
+	0xFFFFFFFFAAAAAFFF, 0x0000FFFFFFFFFFFF, 0xFFFFF00000000000, 0xAAAAAAAAAAAAAAAA,
+	0x000000000000AAAA, 0xFFFFFFFFAAAAA000, 0x0000FFFFFFFFFFFF, 0x5555500000000000,
+	0xFFC003FF0C0EE8A0, 0xAAFFFFFFCF3FFC30, 0x080FFCF0FFFFFAAA, 0xCF3FC030FC0003E2,
+	0xFFFFFAAAAAFFFFFC, 0xF000AAF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF02AAA03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0x000000000000000F, 0xAAAAAAAAAAAAAAAA,
+	0x0000000AAAAAAAAA, 0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x000000000000000F,
+	0xFFC003BA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030F80002F3,
+	0xAAAAAFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFFFFFFAAAAAFFF,
+	0xAAAA03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0x0000000000FFFFFF, 0xAAAAAAAAAAAAAAAA,
+	0x00AAAAAAAAAAAAAA, 0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x0000000000FFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x0C0AA8A0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFAA8AA, 0xFFAAAAA0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xA3FFFFFFFFFAAAAA, 0xFFFFFFF3FFFF2AAA,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFF0000000000,
+	0xFFFFFFFFFFFFFFFF, 0x00000000FFFFFFFF, 0xAAAAAAAAAAAAAA00, 0xAAAAAAAAAAAAAAAA,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003A2,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFA20AAFFCFF, 0xAAFFFFF0CFFF003F, 0xAAAFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFA2, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFAAAAAFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xAAAFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFAA, 0xFFFFFFFFFFFFFFFF, 0xF000000000000000,
+	0xFFFFFFFFFFFFFFFF, 0x00000000FFFFFFFF, 0xAAAAAAAAA0000000, 0xAAAAAAAAAAAAAAAA,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFAAAAAFFFFF0, 0xF2AAAAFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xAAAAFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFA, 0x00000000000FFFFF,
+	0xFFFFFFFFFFFF0000, 0x000AAAAAFFFFFFFF, 0xAAAA000000000000, 0xAAAAAAAAAAAAAAAA,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF02AAA03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xA3FFFFFFFFFAAAAA, 0xAAFFFFF3FFFF2AAA,
+	0xAAAFFFFFFFFFFAAA, 0xFFFFAAAAAFFFFFAA, 0xFFFFFFFFFFAAAAAF, 0x000000FFFFFFFFFF,
+	0xFFFFFFF000000000, 0xAAAAAAAAFFFFFFFF, 0x00000000000000AA, 0xAAAAAAAAAAAAAAA0,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFAAAAAFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFAAAAAFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xAAAAFFFFFAAAAAFF, 0xFFFFFAAAAAFFFFFA, 0x0FFFFFFFFFFFFFFF,
+	0xFF00000000000000, 0xAAAAAAAAFFFFFFFF, 0x000000000AAAAAAA, 0xAAAAAAAAAA000000,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFF30FFAA8AA, 0xFFAAAAA0CFFF003F, 0xAAAFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFA2, 0xFFFFFFFFFFAAAAA0, 0xF2AAAAFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xAAAAAFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0x000000000000FFFF, 0xAAAAAAAAFFFFF000, 0x0000AAAAAAAAAAAA, 0xAAAAA00000000000,
+	0xFFC003FF0C0FF4D0, 0xAAFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFA20AAFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF02AAAF3, 0xFFFFFAAAAAFFFFF0, 0xA3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF2AAA,
+	0xFFFFFFFFFFFFFAAA, 0xAAAAFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0x0000000FFFFFFFFF, 0xAAAAAAAA00000000, 0xAAAAAAAAAAAAAAAA, 0x000000000000000A,
+	0xFFC003FF0C0EE8A0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0AAAAAFFF, 0xCF3FC030FC0003E2,
+	0xAAAAAFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF02AAA03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0x00FFFFFFFFFFFFFF, 0xAAAAAAAA00000000, 0xAAAAAAAAAAAAAAAA, 0x0000000000AAAAAA,
+}
@@ -0,0 +1,330 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Generated by cmd/bitbase, do not edit.
+
+package donna
+
+var bitbaseKRK = [2*10*64]uint64 {
+	0xCA80000000000000, 0xFCAAAFCA8A3CA820, 0x04000000AAAFCAAA, 0xCF3CC030CC000051,
+	0xFFFFCFFFFCFFFFCC, 0xC000FCF30FCAA8A8, 0xFCFFFFC0CFFC003F, 0xFFCAAAA8FFFFCFFF,
+	0xFFFC03FFC03FFCF3, 0xFFFFCFFFFCFFFFC0, 0xC3FFFCFFFFCAAAA8, 0xFCFFFFC3FFFC3FFF,
+	0xFFCAAAA8FFFFCFFF, 0xFFFCFFFFCFFFFCFF, 0xFFFFCFFFFCFFFFCF, 0xCFFFFCFFFFCAAAA8,
+	0xFCFFFFCFFFFCFFFF, 0xFFCAAAA8FFFFCFFF, 0xFFFCFFFFCFFFFCFF, 0xFFFFCFFFFCFFFFCF,
+	0x2000020000000820, 0xF2AAAF2A8A32A820, 0x00055C70AAAF2AAA, 0xCF33C03034000300,
+	0xFFFF3FFFF3FFFF3C, 0x3000F3A20A2FFCF3, 0xF3FFFF30CFF3003F, 0xBA2FFFF3FFFF3FFF,
+	0xFFF303FF303FF3A2, 0xFFFF3FFFF3FFFF30, 0x33FFF3AABA2FFFF3, 0xF3FFFF33FFF33FFF,
+	0xBA2FFFF3FFFF3FFF, 0xFFF3FFFF3FFFF3AA, 0xFFFF3FFFF3FFFF3F, 0x3FFFF3AABA2FFFF3,
+	0xF3FFFF3FFFF3FFFF, 0xBA2FFFF3FFFF3FFF, 0xFFF3FFFF3FFFF3AA, 0xFFFF3FFFF3FFFF3F,
+	0x80000002080AA880, 0xCAAAACAA8A0A0020, 0x0C0FFCC0AAACAAAA, 0xCF0F4030D0000053,
+	0xFFFCFFFFCFFFFCFC, 0xF0008AF30CFFFCCF, 0xCFFFFCF0CFCF003C, 0xFCFFFFCFFFFCFFFF,
+	0xFFCF03FCF02E8AF3, 0xFFFCFFFFCFFFFCF0, 0xF2EE8AFFFCFFFFCF, 0xCFFFFCF3FFCF3FFC,
+	0xFCFFFFCFFFFCFFFF, 0xFFCFFFFCFAEE8AFF, 0xFFFCFFFFCFFFFCFF, 0xFAEE8AFFFCFFFFCF,
+	0xCFFFFCFFFFCFFFFC, 0xFCFFFFCFFFFCFFFF, 0xFFCFFFFCFAEE8AFF, 0xFFFCFFFFCFFFFCFF,
+	0x000000AA000AA830, 0x2AAAA2A008200000, 0x000FFC30AAA2AAAA, 0x4D350000040001F3,
+	0xFFF3FFFF3FFFF3F4, 0xA0003FF303FFFC3F, 0x3FFFF3F0CF3F0022, 0xF3FFFF3FFFF3FFFF,
+	0xFF3F03A2A03F3FF3, 0xFFF3FFFF3FFFF3F0, 0xA3FF3FFFF3FFFF3F, 0x3FFFF3F3FF3F3BA2,
+	0xF3FFFF3FFFF3FFFF, 0xFF3FFBA2AFFF3FFF, 0xFFF3FFFF3FFFF3FF, 0xAFFF3FFFF3FFFF3F,
+	0x3FFFF3FFFF3FFBA2, 0xF3FFFF3FFFF3FFFF, 0xFF3FFBA2AFFF3FFF, 0xFFF3FFFF3FFFF3FF,
+	0x0A8002AA0C0AA8F0, 0xAA02080000000000, 0x0C0FFCF0AAAAAAAA, 0x000040105C0003F3,
+	0xFFFFFFFFFF575D50, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xAA8002AA0C0AA8F0, 0x000000000000A820, 0x0C0FFCF0AAAAA000, 0x4515C030FC0003F3,
+	0xFFFFF55555000004, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xAA8002AA0C0AA8F0, 0x0000000A8A2AA820, 0x0C0FFCF000000000, 0xCF3FC030FC0003F3,
+	0x555550000055555C, 0xF000FFF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xAA8002AA0C0AA8F0, 0x00AAAAAA8A2AA820, 0x0C0FFCF000000000, 0xCF3FC030FC0003F3,
+	0x0000055555FFFFFC, 0xF000FFF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC0035504000000, 0xFFFFFFFFCF3FFC30, 0x00000000FFFFFFFF, 0x8F2A8030A8000200,
+	0xABFAAABFAAABFAA8, 0xF000FF5105F0000A, 0xFFFFFFF0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFAAAAA, 0xFFFFFFF3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFAAAAA,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xF5C0030800055450, 0xFFFFCFFFCC3FFC00, 0x00008820FFCFFFFC, 0x8C2A8000A0000200,
+	0xABCAAABCAAABCAA8, 0xF0007F0002A5DC7F, 0xFFFFCFF0CCFF000F, 0x8AAFFCFFFFCFFFFC,
+	0xFCFF03CFF03CFFA2, 0xFFCFFFFCFFFFCFF0, 0xF3FCFFAA8AAFFCFF, 0xFFFFCFF3FCFF3FCF,
+	0x8AAFFCFFFFCFFFFC, 0xFCFFFFCFFFFCFFAA, 0xFFCFFFFCFFFFCFFF, 0xFFFCFFAA8AAFFCFF,
+	0xFFFFCFFFFCFFFFCF, 0x8AAFFCFFFFCFFFFC, 0xFCFFFFCFFFFCFFAA, 0xFFCFFFFCFFFFCFFF,
+	0xD280007F0C0FF0D0, 0xFFFF3FFFC33F7C30, 0x080AA0A0FF3FFFF3, 0x822A002080000022,
+	0xAB2AAAB2AAAB2AA8, 0xD000A8730FDFF0FF, 0xFFFF3FF0C3FF003F, 0x3FFFF3FFFF3FFFF3,
+	0xF3FF033FF022AAF3, 0xFF3FFFF3FFFF3FF0, 0xF2E2AAFF3FFFF3FF, 0xFFFF3FF3F3FF3F3F,
+	0x3FFFF3FFFF3FFFF3, 0xF3FFFF3FFAE2AAFF, 0xFF3FFFF3FFFF3FFF, 0xFAE2AAFF3FFFF3FF,
+	0xFFFF3FFFF3FFFF3F, 0x3FFFF3FFFF3FFFF3, 0xF3FFFF3FFAE2AAFF, 0xFF3FFFF3FFFF3FFF,
+	0x07C001FC0C0FCCD0, 0xFFFCFFF7CD352800, 0x080A8CA0FCFFFFCF, 0x88200000000000A0,
+	0xA8AAAA8AAAA8AAA0, 0x0000F5F00FFFCCFF, 0xFFFCFFF0CDF5000A, 0xFFFFCFFFFCFFFFCF,
+	0xCFFF00AAA00FFFF0, 0xFCFFFFCFFFFCFFF0, 0xA3CFFFFCFFFFCFFF, 0xFFFCFFF3CFFF38AA,
+	0xFFFFCFFFFCFFFFCF, 0xCFFFF8AAAFCFFFFC, 0xFCFFFFCFFFFCFFFF, 0xAFCFFFFCFFFFCFFF,
+	0xFFFCFFFFCFFFF8AA, 0xFFFFCFFFFCFFFFCF, 0xCFFFF8AAAFCFFFFC, 0xFCFFFFCFFFFCFFFF,
+	0x5FC003FF0C0FFCD0, 0xFF775D5200007410, 0x080ABCA0FFFFFFFF, 0x00000000080002A3,
+	0xAAAAAAAAAA220800, 0x5000FFF30FFFFCFF, 0xFF775D500080001D, 0xFFFFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFFC003FF0C0FFCD0, 0x550000054515FC30, 0x080ABCA0FFFFF555, 0x00008020A80002A3,
+	0xAAAAA00000000000, 0xF000FFF30FFFFCFF, 0x550000004555003F, 0xFFFFFFFFFFFFF555,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCD0, 0x0055555FCF3FFC30, 0x080ABCA055555000, 0x8A2A8020A80002A3,
+	0x0000000000000008, 0xF000FFF30FFFFCFF, 0x00555550CFFF003F, 0xFFFFFFFF55555000,
+	0xFFFF03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCD0, 0x55FFFFFFCF3FFC30, 0x080ABCA000000555, 0x8A2A8020A80002A3,
+	0x0000000000AAAAA8, 0xF000FFF30FFFFCFF, 0x55FFFFF0CFFF003F, 0xFFFFFFFF00000555,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x04000000FFFFFFFF, 0xCF3FC030FC000351,
+	0xFFFFFFFFFFFFFFFC, 0xA000AA0000000000, 0xAABEAAA0CAAA002A, 0x75500200BEAAABEA,
+	0xFFFF03FFF03FFF51, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFAAAAA, 0xFFFFFFF3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFAAAAA,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x0005DC50FFFFFFFF, 0xCF3FC030F4000100,
+	0xFFFFFFFFFFFFFFFC, 0xA000000000008800, 0xAABEAAA0CAAA002A, 0xA005DF55BEAAABEA,
+	0xFFFF03FFF01F5500, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFAAAAAFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFAAAAAFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF,
+	0xFA0002F30C0F3CF0, 0xFFF3FFFF0F3FF030, 0x040F3CF0F3FFFF3F, 0x0F3FC030580000F3,
+	0xF3FFFF3FFFF3FFFC, 0x000000A2000A28AA, 0xAAB2AAA00AAA0020, 0xF55F3FFFB2AAAB2A,
+	0x3FFF03F5502A00F3, 0xF3FFFF3FFFF3FFF0, 0xF22AAAF3FFFF3FFF, 0xFFF3FFF33FFF33FF,
+	0xFFFF3FFFF3FFFF3F, 0x3FFFF3FFFA2AAAF3, 0xF3FFFF3FFFF3FFFF, 0xFA2AAAF3FFFF3FFF,
+	0xFFF3FFFF3FFFF3FF, 0xFFFF3FFFF3FFFF3F, 0x3FFFF3FFFA2AAAF3, 0xF3FFFF3FFFF3FFFF,
+	0xACC003CF0C0CFCF0, 0xFFCFFFFCCF3F8820, 0x0C0CFCF0CFFFFCFF, 0xCD1580000C0001C3,
+	0xCFFFFCFFFFCFFFFC, 0x000000820AA8E8AA, 0xAA8AAAA088000000, 0xFFFCFFFF8AAAA8AA,
+	0xFD550280003D55C3, 0xCFFFFCFFFFCFFFF0, 0xA0FFFFCFFFFCFFFF, 0xFFCFFFF0FFFF0AAA,
+	0xFFFCFFFFCFFFFCFF, 0xFFFFCAAAACFFFFCF, 0xCFFFFCFFFFCFFFFC, 0xACFFFFCFFFFCFFFF,
+	0xFFCFFFFCFFFFCAAA, 0xFFFCFFFFCFFFFCFF, 0xFFFFCAAAACFFFFCF, 0xCFFFFCFFFFCFFFFC,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x0000C0105C0003F3,
+	0xFFFFFFFFFFF75558, 0x0000AAB20AABE8AA, 0xAAA2000000000000, 0xFFFFFFFFAAAAAAAA,
+	0x20000355503FFFF3, 0xFFFFFFFFFFF75550, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0x4515C030FC0003F3,
+	0xFFFFF55555000004, 0xA000AAB20AABE8AA, 0x000000000000002A, 0xFFFFFFFFAAAAA000,
+	0x555503FFF03FFFF3, 0xFFFFF55555000000, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0x555550000055555C, 0xA000AAB20AABE8AA, 0x000000008AAA002A, 0xFFFFFFFF00000000,
+	0xFFFF03FFF03FFFF3, 0x5555500000555550, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0x0000055555FFFFFC, 0xA000AAB20AABE8AA, 0x00AAAAA08AAA002A, 0xFFFFFFFF00000000,
+	0xFFFF03FFF03FFFF3, 0x0000055555FFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000FF5105500000, 0xFFFFFFF0CFFF003F, 0x00000000FFFFFFFF,
+	0xAAAA02AAA02AAA00, 0xEAAAAEAAAAEAAAA0, 0xF3FFFF5555500000, 0xFFFFFFF3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFAAAAA,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003A2,
+	0xFFFFFFFFFFFFFFFC, 0xF00055000005D455, 0xFFFFFFF0CFFF003F, 0x00008000FFFFFFFF,
+	0xAAAA02AAA0000000, 0xEAAAAEAAAAEAAAA0, 0xF1D555080005D555, 0xFFFFFFF3FFFF3FFF,
+	0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFAAAAAFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0x500000F1055FFCFF, 0xFFFFFFF0CFFF0015, 0x000AAAAAFFFFFFFF,
+	0xAAAA0000000000A0, 0xEAAAAEAAAAEAAAA0, 0x528000FD555FFFFF, 0xFFFFFFF3FFFF3D55,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF, 0xFAAAAAFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF,
+	0xA3C0033F0C03FCF0, 0xFF3FFFF3CF3F3820, 0x0C03FCF03FFFF3FF, 0xCF3F0020A0000333,
+	0x3FFFF3FFFF3FFFF0, 0x000055330FF3FCFF, 0xFF3FFFF0C5550000, 0xAAA2AAAA3FFFF3FF,
+	0x8000000000000022, 0x2AAAA2AAAA2AAAA0, 0x03D5553FFFF3FFFF, 0xFF3FFFF3D5552800,
+	0xFFF3FFFF3FFFF3FF, 0xFFFF2AAAA3FFFF3F, 0x3FFFF3FFFF3FFFF3, 0xA3FFFF3FFFF3FFFF,
+	0xFF3FFFF3FFFF2AAA, 0xFFF3FFFF3FFFF3FF, 0xFFFF2AAAA3FFFF3F, 0x3FFFF3FFFF3FFFF3,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0x5000FFF30FFFFCFF, 0xFFF5555000000015, 0xAAAEAAAAFFFFFFFF,
+	0x00000000002AAAE2, 0xAAAAAAAAAAA00000, 0x53FFFFFFFFFFFFFF, 0xFFF5555200003555,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0x550000004555003F, 0xAAAEAAAAFFFFF555,
+	0x000002AAA02AAAE2, 0xAAAAA00000000000, 0xF3FFFFFFFFFFFFFF, 0x5500000155553FFF,
+	0xFFFFFFFFFFFFF555, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFFFCFF, 0x00555550CFFF003F, 0xAAAEAAAA55555000,
+	0xAAAA02AAA02AAAE2, 0x0000000000000000, 0xF3FFFFFFFFFFFFFF, 0x00555553FFFF3FFF,
+	0xFFFFFFFF55555000, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFF30FFFFCFF, 0x55FFFFF0CFFF003F, 0xAAAEAAAA00000555,
+	0xAAAA02AAA02AAAE2, 0x0000000000AAAAA0, 0xF3FFFFFFFFFFFFFF, 0x55FFFFF3FFFF3FFF,
+	0xFFFFFFFF00000555, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFF30FFAA8AA, 0xFFFFFFF0CFFF003F, 0x55500000FFFFFFFF,
+	0xFFFF03FFF03FFF51, 0xFFFFFFFFFFFFFFF0, 0xA2AAAA0000000000, 0xAAAAAAA2AAAA2AAA,
+	0x55500000AAAAAAAA, 0xFFFFFFFFFFFFFF55, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFAAAAA,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003A2,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFA20AAFFCFF, 0xFFFFFFF0CFFF003F, 0x00055555FFFFFFFF,
+	0xFFFF03FFF0155500, 0xFFFFFFFFFFFFFFF0, 0xA000000000000000, 0xAAAAAAA2AAAA2AAA,
+	0x00055555AAAAAAAA, 0xFFFFFFFFF5555500, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFAAAAAFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0x555FFFFFFFFFFFFF,
+	0xFFFF0155500000D1, 0xFFFFFFFFFFFFFFF0, 0x00000080000AAAAA, 0xAAAAAAA2AAAA0000,
+	0x555FFFFFAAAAAAAA, 0xFFFFD555580000D5, 0xFFFFFFFFFFFFFFFF, 0xFAAAAAFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFFFFFFFFFFFFFF,
+	0x55550000001555F3, 0xFFFFFFFFFFFFFFF0, 0x000000AAAAAAAAAA, 0xAAAAAAA000000000,
+	0xFFFFFFFFAAAAAAAA, 0x555580000D5555FF, 0xFFFFFFFFFFFFFFFD, 0xAFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFAAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFFFFFFFFF,
+	0x00000155503FFFF3, 0xFFFFFFFFFFD55550, 0x02AAAAAAAAAAAAAA, 0xAA80000000000000,
+	0xFFFFFFFFAAAAAAAA, 0x0000D5555FFFFFFF, 0xFFFFFFFFFFD55558, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFAAAAAFFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0x555503FFF03FFFF3, 0xFFFFF55555000000, 0xA2AAAAAAAAAAAAAA, 0x0000000000002AAA,
+	0xFFFFFFFFAAAAA000, 0x5555FFFFFFFFFFFF, 0xFFFFF55555000005, 0xFFFFFFFFFFFFFFFF,
+	0xFFAAAAAFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFF3, 0x5555500000555550, 0xA2AAAAAAAAAAAAAA, 0x00000002AAAA2AAA,
+	0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x555550000055555F, 0xFFFFFFFFFFFFFFFF,
+	0xAAFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0x0000055555FFFFF0, 0xA2AAAAAAAAAAAAAA, 0x00AAAAA2AAAA2AAA,
+	0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x0000055555FFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFF30FFAA8AA, 0xFFFFFFF0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFF5555500000, 0xFFFFFFF3FFFF3FFF,
+	0x00000000FFFFFFFF, 0xAAAAAAAAAAAAAA00, 0xAAAAAAAAAAAAAAAA, 0xFFFFFF5555500000,
+	0xFFFFFFFFFFFFFFFF, 0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003A2,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFA20AAFFCFF, 0xFFFFFFF0CFFF003F, 0xAAAFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFA2, 0xFFFFFFFFFFFFFFF0, 0xF155550000055555, 0xFFFFFFF3FFFF3FFF,
+	0x00000000FFFFFFFF, 0xAAAAAAAAA0000000, 0xAAAAAAAAAAAAAAAA, 0xF555550000055555,
+	0xFFFFFFFFFFFFFFFF, 0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFFFFFF0, 0x50000055555FFFFF, 0xFFFFFFF3FFFF1555,
+	0x000AAAAAFFFFFFFF, 0xAAAA000000000000, 0xAAAAAAAAAAAAAAAA, 0x50000055555FFFFF,
+	0xFFFFFFFFFFFF5555, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF02AAA03FFFF3, 0xFFFFFFFFFFFFFFF0, 0x015555FFFFFFFFFF, 0xFFFFFFF155550000,
+	0xAAAAAAAAFFFFFFFF, 0x00000000000000AA, 0xAAAAAAAAAAAAAAA0, 0x055555FFFFFFFFFF,
+	0xFFFFFFF555550000, 0xFFFFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0x53FFFFFFFFFFFFFF, 0xFF55555000001555,
+	0xAAAAAAAAFFFFFFFF, 0x000000000AAAAAAA, 0xAAAAAAAAAA000000, 0x5FFFFFFFFFFFFFFF,
+	0xFF55555000005555, 0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0x5500000155553FFF,
+	0xAAAAAAAAFFFFF555, 0x0000AAAAAAAAAAAA, 0xAAAAA00000000000, 0xFFFFFFFFFFFFFFFF,
+	0x550000055555FFFF, 0xFFFFFFFFFFFFF555, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF,
+	0xFFC003FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0x00555553FFFF3FFF,
+	0xAAAAAAAA55555000, 0xAAAAAAAAAAAAAAAA, 0x000000000000000A, 0xFFFFFFFFFFFFFFFF,
+	0x0055555FFFFFFFFF, 0xFFFFFFFF55555000, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0x55FFFFF3FFFF3FFF,
+	0xAAAAAAAA00000555, 0xAAAAAAAAAAAAAAAA, 0x0000000000AAAAAA, 0xFFFFFFFFFFFFFFFF,
+	0x55FFFFFFFFFFFFFF, 0xFFFFFFFF00000555, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFF30FFAA8AA, 0xFFFFFFF0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFAAAAA, 0xFFFFFFF3FFFF3FFF,
+	0x55500000FFFFFFFF, 0xFFFFFFFFFFFFFF55, 0xFFFFFFFFFFFFFFFF, 0xAAAAAA0000000000,
+	0xAAAAAAAAAAAAAAAA, 0x55500000AAAAAAAA, 0xFFFFFFFFFFFFFF55, 0xFFFFFFFFFFFFFFFF,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003A2,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFA20AAFFCFF, 0xFFFFFFF0CFFF003F, 0xAAAFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFA2, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFAAAAAFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0x00055555FFFFFFFF, 0xFFFFFFFFF5555500, 0xFFFFFFFFFFFFFFFF, 0xA000000000000000,
+	0xAAAAAAAAAAAAAAAA, 0x00055555AAAAAAAA, 0xFFFFFFFFF5555500, 0xFFFFFFFFFFFFFFFF,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFFFFFF0, 0xF2AAAAFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0x555FFFFFFFFFFFFF, 0xFFFF555550000055, 0xFFFFFFFFFFFFFFFF, 0x00000000000AAAAA,
+	0xAAAAAAAAAAAA0000, 0x555FFFFFAAAAAAAA, 0xFFFF555550000055, 0xFFFFFFFFFFFFFFFF,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF02AAA03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xA3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF2AAA,
+	0xFFFFFFFFFFFFFFFF, 0x55550000055555FF, 0xFFFFFFFFFFFFFFF5, 0x000000AAAAAAAAAA,
+	0xAAAAAAA000000000, 0xFFFFFFFFAAAAAAAA, 0x55550000055555FF, 0xFFFFFFFFFFFFFFF5,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFFFF, 0x000055555FFFFFFF, 0xFFFFFFFFFF555550, 0x0AAAAAAAAAAAAAAA,
+	0xAA00000000000000, 0xFFFFFFFFAAAAAAAA, 0x000055555FFFFFFF, 0xFFFFFFFFFF555550,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0x5555FFFFFFFFFFFF, 0xFFFFF55555000005, 0xAAAAAAAAAAAAAAAA,
+	0x000000000000AAAA, 0xFFFFFFFFAAAAA000, 0x5555FFFFFFFFFFFF, 0xFFFFF55555000005,
+	0xFFC003FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0x555550000055555F, 0xAAAAAAAAAAAAAAAA,
+	0x0000000AAAAAAAAA, 0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x555550000055555F,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0x0000055555FFFFFF, 0xAAAAAAAAAAAAAAAA,
+	0x00AAAAAAAAAAAAAA, 0xFFFFFFFF00000000, 0xFFFFFFFFFFFFFFFF, 0x0000055555FFFFFF,
+	0xFFC003FF0C0AA8A0, 0xFFFFFFFFCF3FFC30, 0x0C0AA8A0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFF30FFAA8AA, 0xFFFFFFF0CFFF003F, 0xFFFAAAAAFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFAAAAA, 0xFFFFFFF3FFFF3FFF,
+	0xFFFAAAAAFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFF5555500000,
+	0xFFFFFFFFFFFFFFFF, 0x00000000FFFFFFFF, 0xAAAAAAAAAAAAAA00, 0xAAAAAAAAAAAAAAAA,
+	0xFFC003AA080FFCF0, 0xFFFFFFFFCF3FFC30, 0x080FFCF0FFFFFFFF, 0xCF3FC030FC0003A2,
+	0xFFFFFFFFFFFFFFFC, 0xF000FFA20AAFFCFF, 0xFFFFFFF0CFFF003F, 0xAAAFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFA2, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFAAAAAFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xAAAFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFAA, 0xFFFFFFFFFFFFFFFF, 0xF555550000055555,
+	0xFFFFFFFFFFFFFFFF, 0x00000000FFFFFFFF, 0xAAAAAAAAA0000000, 0xAAAAAAAAAAAAAAAA,
+	0xFAC002FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030F80002F3,
+	0xFFFFFFFFFFFFFFFC, 0xF000AAF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF02AAAF3, 0xFFFFFFFFFFFFFFF0, 0xF2AAAAFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFAAAAAFF, 0xFFFFFFFFFFFFFFFF, 0x50000055555FFFFF,
+	0xFFFFFFFFFFFF5555, 0x000AAAAAFFFFFFFF, 0xAAAA000000000000, 0xAAAAAAAAAAAAAAAA,
+	0xAFC003FF0C0FFCF0, 0xFFFFFFFFCF3FF820, 0x0C0FFCF0FFFFFFFF, 0xCF3FC020AC0003F3,
+	0xFFFFFFFFFFFFFFFC, 0xA000FFF30FFFFCFF, 0xFFFFFFF0CFFF002A, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF02AAA03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xA3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF2AAA,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFAAAAAFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0x055555FFFFFFFFFF,
+	0xFFFFFFF555550000, 0xAAAAAAAAFFFFFFFF, 0x00000000000000AA, 0xAAAAAAAAAAAAAAA0,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFA8A2AFC30, 0x0C0FFCF0FFFFFFFF, 0x8A2AC030FC0003F3,
+	0xFFFFFFFFFFFFFFF8, 0xF000FFF30FFFFCFF, 0xFFFFFFF08AAA003F, 0xFFFFFFFFFFFFFFFF,
+	0xAAAA03FFF03FFFF3, 0xFFFFFFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF2AAAA3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xAAAAFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFA, 0x5FFFFFFFFFFFFFFF,
+	0xFF55555000005555, 0xAAAAAAAAFFFFFFFF, 0x000000000AAAAAAA, 0xAAAAAAAAAA000000,
+	0xFFC003FF0C0FFCF0, 0xFFAAAAAFCF3FFC30, 0x0C0FFCF0FFFFFFFF, 0xCF3FC030FC0003F3,
+	0xFFFFFFFFFFAAAAAC, 0xF000FFF30FFFFCFF, 0xFFAAAAA0CFFF003F, 0xFFFFFFFFFFFFFFFF,
+	0xFFFF03FFF03FFFF3, 0xFFFFFFFFFFAAAAA0, 0xF3FFFFFFFFFFFFFF, 0xFFAAAAA3FFFF3FFF,
+	0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF, 0xFFFFFFFFFFAAAAAF, 0xFFFFFFFFFFFFFFFF,
+	0x550000055555FFFF, 0xAAAAAAAAFFFFF555, 0x0000AAAAAAAAAAAA, 0xAAAAA00000000000,
+	0xFFC003FF0C0FFCF0, 0xAAFFFFFFCF3FFC30, 0x0C0FFCF0FFFFFAAA, 0xCF3FC030FC0003F3,
+	0xFFFFFAAAAAFFFFFC, 0xF000FFF30FFFFCFF, 0xAAFFFFF0CFFF003F, 0xFFFFFFFFFFFFFAAA,
+	0xFFFF03FFF03FFFF3, 0xFFFFFAAAAAFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xAAFFFFF3FFFF3FFF,
+	0xFFFFFFFFFFFFFAAA, 0xFFFFFFFFFFFFFFFF, 0xFFFFFAAAAAFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0x0055555FFFFFFFFF, 0xAAAAAAAA55555000, 0xAAAAAAAAAAAAAAAA, 0x000000000000000A,
+	0xFFC003FF0C0FFCF0, 0xFFFFFFFFCF3FFC30, 0x0C0FFCF0AAAAAFFF, 0xCF3FC030FC0003F3,
+	0xAAAAAFFFFFFFFFFC, 0xF000FFF30FFFFCFF, 0xFFFFFFF0CFFF003F, 0xFFFFFFFFAAAAAFFF,
+	0xFFFF03FFF03FFFF3, 0xAAAAAFFFFFFFFFF0, 0xF3FFFFFFFFFFFFFF, 0xFFFFFFF3FFFF3FFF,
+	0xFFFFFFFFAAAAAFFF, 0xFFFFFFFFFFFFFFFF, 0xAAAAAFFFFFFFFFFF, 0xFFFFFFFFFFFFFFFF,
+	0x55FFFFFFFFFFFFFF, 0xAAAAAAAA00000555, 0xAAAAAAAAAAAAAAAA, 0x0000000000AAAAAA,
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+// Package donna implements Donna chess engine. Besides running as UCI engine
+// or interactive shell the package can be embedded into other Go programs:
+//
+//	engine := donna.NewEngine(`cache`, 64)
+//	game := engine.NewGame(`rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1`)
+//	if _, err := game.Start(); err != nil {
+//		return err
+//	}
+//	result, err := game.Search(ctx, donna.Limits{ Depth: 10 })
+//	fmt.Println(result.Move.Notation(), result.Score, result.PV)
+//
+package donna
+
+import (
+	`context`
+	`errors`
+	`fmt`
+	`time`
+)
+
+// Search limits. Zero value means no limit; when no limits are set at all the
+// search continues until the context gets cancelled.
+type Limits struct {
+	Depth       int           // Search X plies only.
+	Nodes       int           // Search X nodes only.
+	MoveTime    time.Duration // Search exactly X per move.
+	TimeLeft    time.Duration // Time left for all remaining moves.
+	TimeInc     time.Duration // Time increment after the move is made.
+	MovesToGo   int           // Number of moves to make till time control.
+	MultiPV     int           // Number of best lines to search (engine's default if 0).
+	Mate        int           // Search for mate in X moves only.
+	SearchMoves []Move        // Search these root moves only.
+}
+
+// Search result as seen by the side to move.
+type Result struct {
+	Move        Move          // Best move or Move(0) if there are no moves.
+	Score       int           // Score in centipawns.
+	Mate        int           // Mate in X moves; negative if getting mated.
+	PV          []Move        // Principal variation starting with the best move.
+	Depth       int           // Depth of the last completed iteration.
+	Nodes       int           // Number of regular and quiescence nodes searched.
+	Time        time.Duration // Time spent searching.
+	Lines       []Result      // Best lines in MultiPV mode, best first.
+}
+
+// Returns nodes per second search speed.
+func (r Result) NPS() int {
+	if r.Time <= 0 {
+		return 0
+	}
+	return int(int64(r.Nodes) * int64(time.Second) / int64(r.Time))
+}
+
+// Sets up the initial position of the game and returns it. Unlike internal
+// start() the errors in FEN or algebraic notation are reported rather than
+// ignored.
+func (game *Game) Start() (position *Position, err error) {
+	defer func() {
+		if recover() != nil {
+			position, err = nil, fmt.Errorf("donna: invalid position %q", game.initial)
+		}
+	}()
+
+	if position = game.start(); position == nil {
+		return nil, fmt.Errorf("donna: invalid position %q", game.initial)
+	}
+	if position.outposts[King].count() != 1 || position.outposts[BlackKing].count() != 1 {
+		return nil, fmt.Errorf("donna: position %q must have one king per side", game.initial)
+	}
+	if position.isInCheck(position.color ^ 1) {
+		return nil, fmt.Errorf("donna: side to move can capture the king in %q", game.initial)
+	}
+
+	return position, nil
+}
+
+// Searches the game's current position within given limits. The search stops
+// early when the context gets cancelled, in which case the best move found so
+// far is returned along with the context's error if no move was found yet.
+func (game *Game) Search(ctx context.Context, limits Limits) (Result, error) {
+	if game.position() == nil || game.position().worker == nil {
+		return Result{}, errors.New(`donna: the game has not been started`)
+	}
+
+	options := Options{
+		maxDepth:    limits.Depth,
+		maxNodes:    limits.Nodes,
+		moveTime:    int64(limits.MoveTime / time.Millisecond),
+		timeLeft:    int64(limits.TimeLeft / time.Millisecond),
+		timeInc:     int64(limits.TimeInc / time.Millisecond),
+		movesToGo:   int64(limits.MovesToGo),
+		mate:        limits.Mate,
+		searchMoves: limits.SearchMoves,
+	}
+
+	engine := game.engine
+	if limits.MultiPV > 0 {
+		defer func(multiPV int) { engine.multiPV = multiPV }(engine.multiPV)
+		engine.multiPV = min(limits.MultiPV, MaxMultiPV)
+	}
+	if options.timeLeft > 0 || options.timeInc > 0 {
+		engine.varyingLimits(options)
+	} else {
+		options.infinite = options.maxDepth == 0 && options.maxNodes == 0 && options.moveTime == 0 && options.mate == 0
+		engine.fixedLimit(options)
+	}
+
+	engine.clock.halt.Store(false)
+	result := game.think(ctx)
+	if result.Move == Move(0) && ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+
+	return result, nil
+}
+
+// Packs up search results.
+func (game *Game) result(move Move, score, depth int, duration int64) Result {
+	nodes, qnodes := game.nodes()
+	result := Result{
+		Move:  move,
+		Score: score,
+		Depth: depth,
+		Nodes: nodes + qnodes,
+		Time:  time.Duration(duration) * time.Millisecond,
+	}
+
+	if move != Move(0) {
+		if game.rootpv.size > 0 && game.rootpv.moves[0] == move {
+			result.PV = append(result.PV, game.rootpv.moves[:game.rootpv.size]...)
+		} else {
+			result.PV = []Move{ move }
+		}
+	}
+
+	result.Mate = mateIn(score)
+
+	if len(game.lines) > 1 {
+		for _, line := range game.lines {
+			result.Lines = append(result.Lines, Result{
+				Move:  line.moves[0],
+				Score: line.score,
+				Mate:  mateIn(line.score),
+				PV:    append([]Move{}, line.moves[:line.size]...),
+				Depth: depth,
+			})
+		}
+	}
+
+	return result
+}
+
+// Converts checkmate score to number of moves till checkmate.
+func mateIn(score int) int {
+	if !isMate(score) {
+		return 0
+	} else if score > 0 {
+		return (Checkmate - score + 1) / 2
+	}
+	return (-Checkmate - score) / 2
+}
+
+// Returns evaluation parameters of the engine. Changes made with Load() apply
+// to the games started afterwards.
+func (e *Engine) Profile() *Profile {
+	return e.profile
+}
+
+// Returns all legal moves in the position.
+func (p *Position) Moves() []Move {
+	return NewGen(p, MaxPly).generateAllMoves().validOnly().allMoves()
+}
+
+// Makes the move and returns new position. Illegal moves are rejected with an
+// error; use NewMoveFromString() to convert `e2e4` or `Ng1-f3` notation.
+func (p *Position) MakeMove(move Move) (*Position, error) {
+	if move == Move(0) || !NewGen(p, MaxPly).generateAllMoves().validOnly().amongValid(move) {
+		return p, fmt.Errorf("donna: illegal move %v", move)
+	}
+
+	return p.playMove(move), nil
+}
+
+// Takes back the last move and returns previous position.
+func (p *Position) UndoMove() *Position {
+	return p.takeBack()
+}
+
+// Returns the position in Forsyth–Edwards notation.
+func (p *Position) FEN() string {
+	return p.fen()
+}
+
+// Returns the position in Donna chess format.
+func (p *Position) DCF() string {
+	return p.dcf()
+}
+
+// Returns the move in coordinate notation as expected by UCI, ex. `e2e4`.
+func (m Move) Notation() string {
+	return m.notation()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `context`; `testing`; `time`)
+
+// Invalid positions get reported.
+func TestDonna000(t *testing.T) {
+	_, err := NewGame(`8/8/8/8 w`).Start()
+	expect.True(t, err != nil)
+	_, err = NewGame(`Ka1,Qb1`, `Kc2`).Start() // Black king is in check, White to move.
+	expect.True(t, err != nil)
+	_, err = NewGame(`Ka1,Ra2`, `Ra8`).Start() // No black king.
+	expect.True(t, err != nil)
+}
+
+// Legal moves, making and taking them back.
+func TestDonna010(t *testing.T) {
+	p, err := NewGame().Start()
+	expect.Eq(t, err, nil)
+	expect.Eq(t, len(p.Moves()), 20)
+
+	move, _ := NewMoveFromString(p, `e2e4`)
+	p, err = p.MakeMove(move)
+	expect.Eq(t, err, nil)
+	expect.Eq(t, move.Notation(), `e2e4`)
+	expect.Eq(t, p.FEN(), `rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1`)
+
+	_, err = p.MakeMove(move) // Can't make White's move again.
+	expect.True(t, err != nil)
+
+	p = p.UndoMove()
+	expect.Eq(t, p.FEN(), `rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1`)
+}
+
+// Fixed depth search reports mate along with principal variation.
+func TestDonna020(t *testing.T) {
+	game := NewGame(`Kf8,Rh1,g6`, `Kh8,Bg8,g7,h7`)
+	game.Start()
+	result, err := game.Search(context.Background(), Limits{ Depth: 3 })
+
+	expect.Eq(t, err, nil)
+	expect.Eq(t, result.Move, `Rh1-h6`)
+	expect.Eq(t, result.Mate, 2)
+	expect.Eq(t, result.PV[0], result.Move)
+	expect.True(t, result.Depth > 0)
+	expect.True(t, result.Nodes > 0)
+}
+
+// Cancelled context stops infinite search.
+func TestDonna030(t *testing.T) {
+	game := NewGame()
+	game.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), 100 * time.Millisecond)
+	defer cancel()
+	result, err := game.Search(ctx, Limits{})
+
+	expect.Eq(t, err, nil)
+	expect.True(t, result.Move != Move(0))
+}
+
+// MultiPV search returns best lines sorted by score, each starting with its
+// own root move.
+func TestDonna040(t *testing.T) {
+	game := NewGame(`Kg1,Qd1,Rf1,b2,c3,g2,h2`, `Kg8,Qd8,Rf8,b7,c6,g7,h7`)
+	game.Start()
+	single, _ := game.Search(context.Background(), Limits{ Depth: 6 })
+	game.Start()
+	result, err := game.Search(context.Background(), Limits{ Depth: 6, MultiPV: 3 })
+
+	expect.Eq(t, err, nil)
+	expect.Eq(t, len(single.Lines), 0)
+	expect.Eq(t, len(result.Lines), 3)
+	expect.Eq(t, result.Move, result.Lines[0].Move)
+	expect.Eq(t, result.Score, result.Lines[0].Score)
+	expect.True(t, result.Lines[0].Score >= result.Lines[1].Score)
+	expect.True(t, result.Lines[1].Score >= result.Lines[2].Score)
+	expect.True(t, result.Lines[0].Move != result.Lines[1].Move)
+	expect.True(t, result.Lines[1].Move != result.Lines[2].Move)
+	expect.True(t, result.Lines[0].Move != result.Lines[2].Move)
+	expect.Eq(t, game.engine.multiPV, 0)
+}
+
+// MultiPV lines can't outnumber legal moves.
+func TestDonna050(t *testing.T) {
+	game := NewEngine(`multipv`, 5).NewGame(`Ka1,b2`, `Kh8,h7`)
+	game.Start()
+	result, _ := game.Search(context.Background(), Limits{ Depth: 4 })
+
+	expect.Eq(t, len(game.position().Moves()), 4)
+	expect.Eq(t, len(result.Lines), 4)
+}
+
+// Root search could be restricted to given moves; a single move gets searched
+// to full depth rather than played right away.
+func TestDonna200(t *testing.T) {
+	game := NewGame(`Kg1,Qd1,Rf1,b2,c3,g2,h2`, `Kg8,Qd8,Rf8,b7,c6,g7,h7`)
+	p, _ := game.Start()
+	h3, _ := NewMoveFromString(p, `h2h3`)
+	b4, _ := NewMoveFromString(p, `b2b4`)
+	result, err := game.Search(context.Background(), Limits{ Depth: 5, SearchMoves: []Move{ h3, b4 } })
+
+	expect.Eq(t, err, nil)
+	expect.True(t, result.Move == h3 || result.Move == b4)
+
+	game.Start()
+	result, _ = game.Search(context.Background(), Limits{ Depth: 5, SearchMoves: []Move{ b4 } })
+	expect.Eq(t, result.Move, b4)
+	expect.Eq(t, result.Depth, 5)
+	expect.Eq(t, result.PV[0], b4)
+}
@@ -4,24 +4,27 @@
 
 package donna
 
-import (`fmt`; `os`; `time`)
+import (`fmt`; `os`; `sync`; `sync/atomic`; `time`)
 
-const Ping = 125 // Check time 8 times a second.
+const Ping = 125 // Time reserve to make a move, in milliseconds.
 
 type Clock struct {
-	halt        bool     // Stop search immediately when set to true.
-	softStop    int64    // Target soft time limit to make a move.
-	hardStop    int64    // Immediate stop time limit.
-	extra       float32  // Extra time factor based on search volatility.
+	halt        atomic.Bool  // Stop search immediately when set to true.
+	ponderhit   chan bool    // The opponent has made the expected move.
+	ticking     bool         // The search is on the clock.
+	softStop    int64        // Target soft time limit to make a move.
+	hardStop    int64        // Immediate stop time limit.
+	extra       float32      // Extra time factor based on search volatility.
 	start       time.Time
-	ticker      *time.Ticker
 }
 
 type Options struct {
-	ponder      bool     // (-) Pondering mode.
+	ponder      bool     // Pondering mode: search until "ponderhit" or "stop".
 	infinite    bool     // (-) Search until the "stop" command.
 	maxDepth    int      // Search X plies only.
-	maxNodes    int      // (-) Search X nodes only.
+	maxNodes    int      // Search X nodes only.
+	mate        int      // Search for mate in X moves only.
+	searchMoves []Move   // Search these root moves only.
 	moveTime    int64    // Search exactly X milliseconds per move.
 	movesToGo   int64    // Number of moves to make till time control.
 	timeLeft    int64    // Time left for all remaining moves.
@@ -29,37 +32,63 @@ type Options struct {
 }
 
 type Engine struct {
-	log         bool     // Enable logging.
 	uci	    bool     // Use UCI protocol.
-	trace       bool     // Trace evaluation scores.
+	repl        bool     // Use interactive shell.
 	fancy       bool     // Represent pieces as UTF-8 characters.
 	status      uint8    // Engine status.
+	threads     int      // Number of search threads.
+	multiPV     int      // Number of best lines to search and show.
+	chess960    bool     // Chess960 (Fischer Random) castle notation.
+	limited     bool     // Weaken play to match UCI_Elo rating.
+	elo         int      // Playing strength rating for UCI_Elo.
+	contempt    int      // Draw score penalty in centipawns.
+	dynamic     bool     // Scale contempt down as material comes off.
+	showWdl     bool     // Report expected win/draw/loss along with the score.
 	logFile     string   // Log file name.
 	bookFile    string   // Polyglot opening book file name.
 	cacheSize   float64  // Default cache size.
+	cacheFile   string   // File to save the cache to and load it from.
+	cacheLoaded bool     // Cache has been loaded from file; next new game keeps it.
+	syzygyPath  string   // Syzygy tablebase directories.
+	syzygyLimit int      // Largest number of pieces to probe the tablebase for.
+	syzygy      *Tablebase
+	syzygyOnce  sync.Once
+	profile     *Profile // Evaluation parameters.
+	network     *Network // Neural network evaluator loaded with EvalFile.
 	clock       Clock
 	options     Options
+	game        *Game    // The game the engine is playing.
 }
 
-// Use single statically allocated variable.
-var engine Engine
-
+// Creates new engine instance. Each engine owns its game along with the search
+// workers so that multiple engines could coexist within the same process.
 func NewEngine(args ...interface{}) *Engine {
-	engine = Engine{}
+	engine := &Engine{ syzygyLimit: 6, elo: MaxElo, profile: NewProfile() }
+	engine.clock.ponderhit = make(chan bool, 1)
 	for i := 0; i < len(args); i += 2 {
 		switch value := args[i+1]; args[i] {
-		case `log`:
-			engine.log = value.(bool)
 		case `logfile`:
 			engine.logFile = value.(string)
 		case `bookfile`:
 			engine.bookFile = value.(string)
+		case `cachefile`:
+			engine.cacheFile = value.(string)
+		case `syzygypath`:
+			engine.syzygyPath = value.(string)
+		case `syzygylimit`:
+			engine.syzygyLimit = value.(int)
 		case `uci`:
 			engine.uci = value.(bool)
-		case `trace`:
-			engine.trace = value.(bool)
 		case `fancy`:
 			engine.fancy = value.(bool)
+		case `threads`:
+			engine.threads = value.(int)
+		case `multipv`:
+			engine.multiPV = value.(int)
+		case `elo`:
+			engine.setElo(value.(int), true)
+		case `contempt`:
+			engine.contempt = value.(int)
 		case `depth`:
 			engine.options.maxDepth = value.(int)
 		case `movetime`:
@@ -74,7 +103,7 @@ func NewEngine(args ...interface{}) *Engine {
 		}
 	}
 
-	return &engine
+	return engine
 }
 
 // Dumps the string to standard output.
@@ -148,76 +177,102 @@ func (e *Engine) factor(depth int, volatility float32) *Engine {
 	return e
 }
 
-// Starts the clock setting ticker callback function. The callback function is
-// different for fixed and variable time controls.
+// Starts the clock unless we're pondering or the search is not limited by
+// time. There is no separate ticker: the main search thread checks the clock
+// as it goes, see checkClock().
 func (e *Engine) startClock() *Engine {
-	e.clock.halt = false
-
-	if e.options.moveTime == 0 && e.options.timeLeft == 0 {
+	if e.options.ponder || (e.options.moveTime == 0 && e.options.timeLeft == 0) {
 		return e
 	}
 
 	e.clock.start = time.Now()
-	e.clock.ticker = time.NewTicker(time.Millisecond * Ping)
-
-	if e.fixedTime() {
-		return e.fixedTimeTicker()
-	}
+	e.clock.ticking = true
 
-	// How long a minute is depends on which side of the bathroom door you're on.
-	return e.varyingTimeTicker()
+	return e
 }
 
-// Stop the clock so that the ticker callback function is longer invoked.
-func (e *Engine) stopClock() *Engine {
-	if e.clock.ticker != nil {
-		e.clock.ticker.Stop()
-		e.clock.ticker = nil
+// Lets the search know that the opponent has made the expected move. Could be
+// called from any goroutine; the search picks it up next time it checks the
+// clock.
+func (e *Engine) ponderHit() *Engine {
+	select {
+	case e.clock.ponderhit <- true:
+	default: // Already on its way.
 	}
+
 	return e
 }
 
-// Ticker callback for fixed time control (ex. 5s per move). Search gets terminated
-// when we've got the move and the elapsed time approaches time-per-move limit.
-func (e *Engine) fixedTimeTicker() *Engine {
-	go func() {
-		if e.clock.ticker == nil {
-			return // Nothing to do if the clock has been stopped.
-		}
-		for now := range e.clock.ticker.C {
-			if game.rootpv.size == 0 {
-				continue // Haven't found the move yet.
-			}
-			if e.elapsed(now) >= e.options.moveTime - Ping {
-				e.clock.halt = true
-				return
+// Switches from pondering to regular search once "ponderhit" has arrived. The
+// search goes on as is but now it's on our clock.
+func (e *Engine) checkPonderHit() *Engine {
+	select {
+	case <-e.clock.ponderhit:
+		if e.options.ponder {
+			e.options.ponder = false
+			if !e.fixedDepth() {
+				e.startClock()
 			}
 		}
-	}()
+	default:
+	}
 
 	return e
 }
 
-// Ticker callback for the variable time control (ex. 40 moves in 5 minutes). Search
-// termination depends on multiple factors with hard stop being the ultimate limit.
-func (e *Engine) varyingTimeTicker() *Engine {
-	go func() {
-		if e.clock.ticker == nil {
-			return // Nothing to do if the clock has been stopped.
-		}
-		for now := range e.clock.ticker.C {
-			if game.rootpv.size == 0 {
-				continue // Haven't found the move yet.
-			}
-			elapsed := e.elapsed(now)
-			if (game.deepening && game.improving && elapsed > e.remaining() * 4 / 5) || elapsed > e.clock.hardStop {
-				//\\ e.debug("# Halt: Flags %v Elapsed %s Remaining %s Hard stop %s\n",
-				//\\	game.deepening && game.improving, ms(elapsed), ms(e.remaining() * 4 / 5), ms(e.clock.hardStop))
-				e.clock.halt = true
-				return
-			}
+// Discards "ponderhit" that arrived after the previous search was over.
+func (e *Engine) clearPonderHit() *Engine {
+	select {
+	case <-e.clock.ponderhit:
+	default:
+	}
+
+	return e
+}
+
+// Pondering or infinite search must not reply with the best move until the
+// opponent has made the expected move or the search gets stopped.
+func (e *Engine) waitToReply() *Engine {
+	for (e.options.ponder || e.options.infinite) && !e.clock.halt.Load() {
+		time.Sleep(time.Millisecond * Ping / 10)
+		e.checkPonderHit()
+	}
+
+	return e
+}
+
+// Stop the clock so that it's no longer checked.
+func (e *Engine) stopClock() *Engine {
+	e.clock.ticking = false
+	return e
+}
+
+// Checks the clock and halts the search when it's time to make a move. Called
+// by the main search thread only, so it's safe to look at the search state.
+func (e *Engine) checkClock() *Engine {
+	e.checkPonderHit()
+	if !e.clock.ticking || (e.game.rootpv.size == 0 && e.options.mate == 0) {
+		return e // Not on the clock or haven't found the move yet.
+	}
+
+	// Fixed time control (ex. 5s per move): stop when the elapsed time
+	// approaches time-per-move limit.
+	elapsed := e.elapsed(time.Now())
+	if e.fixedTime() {
+		if elapsed >= e.options.moveTime - Ping {
+			e.clock.halt.Store(true)
 		}
-	}()
+		return e
+	}
+
+	// Variable time control (ex. 40 moves in 5 minutes): termination depends on
+	// multiple factors with hard stop being the ultimate limit. How long a
+	// minute is depends on which side of the bathroom door you're on.
+	if (e.game.deepening && e.game.improving && elapsed > e.remaining() * 4 / 5) || elapsed > e.clock.hardStop {
+		//\\ e.debug("# Halt: Flags %v Elapsed %s Remaining %s Hard stop %s\n",
+		//\\	game.deepening && game.improving, ms(elapsed), ms(e.remaining() * 4 / 5), ms(e.clock.hardStop))
+		e.clock.halt.Store(true)
+	}
 
 	return e
 }
@@ -225,13 +280,14 @@ func (e *Engine) varyingTimeTicker() *Engine {
 // Sets fixed search limits such as maximum depth or time to make a move.
 func (e *Engine) fixedLimit(options Options) *Engine {
 	e.options = options
-	return e
+	return e.clearPonderHit()
 }
 
 // Sets variable time control options and calculates soft and hard stop estimates.
 func (e *Engine) varyingLimits(options Options) *Engine {
 
 	// Note if it's a new time control before saving the options.
+	e.clearPonderHit()
 	e.options = options
 	e.options.ponder = false
 	e.options.infinite = false
@@ -5,8 +5,10 @@
 package donna
 
 import(
+	`bufio`
 	`fmt`
 	`io/ioutil`
+	`os`
 	`regexp`
 	`runtime`
 	`strconv`
@@ -21,9 +23,26 @@ var (
 	ansiNone  = "\033[0m"
 )
 
+// Returns the move in long algebraic notation using UTF-8 chess symbols if
+// the engine is set up to be fancy.
+func (e *Engine) replMove(move Move) string {
+	return move.format(e.fancy)
+}
+
+// Same as above for the list of moves, ex. principal variation, that gets
+// shown in square brackets.
+func (e *Engine) replMoves(moves []Move) string {
+	list := make([]string, len(moves))
+	for i, move := range moves {
+		list[i] = e.replMove(move)
+	}
+
+	return `[` + strings.Join(list, ` `) + `]`
+}
+
 func (e *Engine) replBestMove(move Move) *Engine {
-	fmt.Printf(ansiTeal + "Donna's move: %s", move)
-	if game.nodes == 0 {
+	fmt.Printf(ansiTeal + "Donna's move: %s", e.replMove(move))
+	if nodes, _ := e.game.nodes(); nodes == 0 {
 		fmt.Printf(" (book)")
 	}
 	fmt.Println(ansiNone + "\n")
@@ -32,7 +51,9 @@ func (e *Engine) replBestMove(move Move) *Engine {
 }
 
 func (e *Engine) replPrincipal(depth, score, status int, duration int64) {
-	fmt.Printf(`%2d %s %9d %9d %9d  `, depth, ms(duration), game.nodes, game.qnodes, nps(duration))
+	nodes, qnodes := e.game.nodes()
+	prefix := fmt.Sprintf(`%2d %s %9d %9d %9d  `, depth, ms(duration), nodes, qnodes, e.game.nps(duration))
+	fmt.Print(prefix)
 	switch status {
 	case WhiteWon:
 		fmt.Println(`1-0 White Checkmates`)
@@ -45,34 +66,41 @@ func (e *Engine) replPrincipal(depth, score, status int, duration int64) {
 	case FiftyMoves:
 		fmt.Println(`1/2 Fifty Moves`)
 	case WhiteWinning, BlackWinning: // Show moves till checkmate.
-		fmt.Printf("%6dX   %v Checkmate\n", (Checkmate - abs(score)) / 2 + 1, game.rootpv.moves[0:game.rootpv.size])
+		fmt.Printf("%6dX   %s Checkmate\n", (Checkmate - abs(score)) / 2 + 1, e.replMoves(e.game.rootpv.moves[0:e.game.rootpv.size]))
 	default:
-		fmt.Printf("%7.2f   %v\n", float32(score) / float32(onePawn), game.rootpv.moves[0:game.rootpv.size])
+		fmt.Printf("%7.2f   %s\n", float32(score) / float32(onePawn), e.replMoves(e.game.rootpv.moves[0:e.game.rootpv.size]))
+	}
+
+	// Show the rest of the lines in MultiPV mode right below the best one.
+	for i := 1; i < len(e.game.lines); i++ {
+		line := &e.game.lines[i]
+		score := let(e.game.position().color == White, line.score, -line.score)
+		fmt.Printf("%s%7.2f   %s\n", strings.Repeat(` `, len(prefix)), float32(score) / float32(onePawn), e.replMoves(line.moves[0:line.size]))
 	}
 }
 
 // There are two types of command interfaces in the world of computing: good
 // interfaces and user interfaces. -- Daniel J. Bernstein
 func (e *Engine) Repl() *Engine {
-	var game *Game
 	var position *Position
 
+	e.repl = true
+
 	// Suppress ANSI colors when running Windows.
 	if runtime.GOOS == `windows` {
 		ansiRed, ansiGreen, ansiTeal, ansiNone = ``, ``, ``, ``
 	}
 
 	setup := func() {
-		if game == nil || position == nil {
-			game = NewGame()
-			position = game.start()
+		if e.game == nil || position == nil {
+			position = e.NewGame().start()
 			fmt.Printf("%s\n", position)
 		}
 	}
 
 	think := func() {
-		if move := game.Think(); move != 0 {
-			position = position.makeMove(move)
+		if move := e.game.Think(); move != 0 {
+			position = position.playMove(move)
 			fmt.Printf("%s\n", position)
 		}
 	}
@@ -90,6 +118,7 @@ func (e *Engine) Repl() *Engine {
 		e.options.maxDepth, e.options.moveTime = 0, 10000
 		defer func() {
 			e.options.maxDepth, e.options.moveTime = maxDepth, moveTime
+			position = nil // Benchmark games take over the current one.
 			if err := recover(); err != nil {
 				fmt.Printf("Error loading %s\n", fileName)
 			}
@@ -104,7 +133,7 @@ func (e *Engine) Repl() *Engine {
 			for _, line := range strings.Split(string(content), "\n") {
 				if len(line) > 0 && line[0] != '#' {
 					total++
-					game := NewGame(line)
+					game := e.NewGame(line)
 					position := game.start()
 
 					best := strings.Split(line, ` # `)[1] // TODO: add support for "am" (avoid move).
@@ -142,59 +171,199 @@ func (e *Engine) Repl() *Engine {
 		}
 	}
 
+	// Searches current position without making the move. The search could
+	// be restricted to the given moves.
+	analyze := func(parameter string) {
+		moves := []Move{}
+		for _, str := range strings.Fields(parameter) {
+			move, validMoves := NewMoveFromString(position, str)
+			if move == 0 {
+				fmt.Printf("%s appears to be an invalid move; valid moves are %v\n", str, validMoves)
+				return
+			}
+			moves = append(moves, move)
+		}
+
+		searchMoves := e.options.searchMoves
+		e.options.searchMoves = moves
+		e.game.Think()
+		e.options.searchMoves = searchMoves
+	}
+
+	// Saves or loads the cache, or clears it. The file name defaults to the
+	// last one used.
+	hash := func(parameter string) {
+		fields := strings.Fields(parameter)
+		if len(fields) > 1 {
+			e.cacheFile = strings.Join(fields[1:], ` `)
+		}
+
+		var err error
+		switch {
+		case len(fields) > 0 && fields[0] == `clear`:
+			e.game.cache.clear()
+			fmt.Println(`Cleared the cache`)
+		case len(fields) > 0 && fields[0] == `save` && e.cacheFile != ``:
+			if err = e.game.SaveCache(e.cacheFile); err == nil {
+				fmt.Printf("Saved the cache to %s\n", e.cacheFile)
+			}
+		case len(fields) > 0 && fields[0] == `load` && e.cacheFile != ``:
+			if err = e.game.LoadCache(e.cacheFile); err == nil {
+				fmt.Printf("Loaded the cache from %s\n", e.cacheFile)
+			}
+		default:
+			fmt.Printf("Cache usage %d%%, use hash save|load <file> or hash clear\n", e.game.hashfull() / 10)
+		}
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	// Loads or saves evaluation parameters.
+	profile := func(parameter string) {
+		fields := strings.Fields(parameter)
+		if len(fields) < 2 || (fields[0] != `load` && fields[0] != `save`) {
+			fmt.Println(`Use params load|save <file>`)
+			return
+		}
+
+		fileName := strings.Join(fields[1:], ` `)
+		if fields[0] == `load` {
+			if err := e.loadProfile(fileName); err != nil {
+				fmt.Println(err)
+			} else {
+				fmt.Printf("Loaded evaluation parameters from %s\n", fileName)
+			}
+		} else if err := e.saveProfile(fileName); err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Printf("Saved evaluation parameters to %s\n", fileName)
+		}
+	}
+
+	// Checks evaluation symmetries of the positions in the file.
+	evalcheck := func(fileName string) {
+		file, err := os.Open(fileName)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		defer file.Close()
+
+		count, err := EvalCheck(file)
+		if err != nil {
+			fmt.Printf(ansiRed + "%s: %v\n" + ansiNone, fileName, err)
+		}
+		fmt.Printf("Checked %d position(s) from %s\n", count, fileName)
+	}
+
 	fmt.Printf("Donna v%s Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.\nType ? for help.\n\n", Version)
+	input := bufio.NewScanner(os.Stdin)
 	for command, parameter := ``, ``; ; command, parameter = ``, `` {
 		fmt.Print(`donna> `)
-		fmt.Scanln(&command, &parameter)
+		if !input.Scan() {
+			return e
+		}
+		if fields := strings.Fields(input.Text()); len(fields) > 0 {
+			command, parameter = fields[0], strings.Join(fields[1:], ` `)
+		}
 
 		switch command {
 		case ``:
+		case `analyze`:
+			setup()
+			analyze(parameter)
 		case `bench`:
 			benchmark(parameter)
 		case `book`:
 			book(parameter)
+		case `evalcheck`:
+			evalcheck(parameter)
 		case `exit`, `quit`:
 			return e
 		case `go`:
 			setup()
 			think()
+		case `level`:
+			if parameter == `off` || parameter == `max` {
+				e.limited = false
+			} else if n, err := strconv.Atoi(parameter); err == nil {
+				e.setElo(n, true)
+			}
+			if e.limited {
+				fmt.Printf("Playing at %d Elo level\n", e.elo)
+			} else {
+				fmt.Println(`Playing at full strength`)
+			}
+		case `mate`:
+			setup()
+			options := e.options
+			e.options = Options{ mate: 3, moveTime: options.moveTime }
+			if n, err := strconv.Atoi(parameter); err == nil && n > 0 {
+				e.options.mate = n
+			}
+			e.game.Think()
+			e.options = options
+		case `multipv`:
+			if n, err := strconv.Atoi(parameter); err == nil && n >= 1 && n <= MaxMultiPV {
+				e.multiPV = n
+			}
+			fmt.Printf("Showing %d best line(s)\n", max(1, e.multiPV))
+		case `hash`:
+			setup()
+			hash(parameter)
 		case `help`, `?`:
-			fmt.Println("The commands are:\n\n" +
+			fmt.Print("The commands are:\n\n" +
+				"  analyze [mv]   Analyze position or given moves\n" +
 				"  bench <file>   Run benchmarks\n" +
 				"  book <file>    Use opening book\n" +
+				"  evalcheck <f>  Check evaluation symmetry\n" +
 				"  exit           Exit the program\n" +
 				"  go             Take side and make a move\n" +
+				"  hash <op> [f]  Save or load cache file, or clear cache\n" +
 				"  help           Display this help\n" +
+				"  level [elo]    Limit playing strength (or off)\n" +
+				"  mate [n]       Find mate in n moves\n" +
+				"  multipv [n]    Show n best lines\n" +
 				"  new            Start new game\n" +
+				"  params <op> f  Load or save evaluation parameters\n" +
 				"  perft [depth]  Run perft test\n" +
 				"  score          Show evaluation summary\n" +
+				"  score json [s] Show evaluation trace as JSON, with squares\n" +
 				"  undo           Undo last move\n\n" +
-				"To make a move use algebraic notation, for example e2e4, Ng1f3, or e7e8Q\n")
+				"To make a move use algebraic notation, for example e2e4, Ng1f3, or e7e8Q\n\n")
 		case `new`:
-			game, position = nil, nil
+			position = nil
 			setup()
+		case `params`:
+			profile(parameter)
 		case `perft`:
 			perft(parameter)
 		case `score`:
 			setup()
-			_, metrics := position.EvaluateWithTrace()
-			Summary(metrics)
+			if fields := strings.Fields(parameter); len(fields) > 0 && fields[0] == `json` {
+				if data, err := position.Trace(len(fields) > 1 && fields[1] == `squares`).JSON(); err == nil {
+					fmt.Printf("%s\n", data)
+				} else {
+					fmt.Println(err)
+				}
+			} else {
+				_, metrics := position.EvaluateWithTrace()
+				Summary(metrics)
+			}
 		case `undo`:
 			if position != nil {
-				position = position.undoLastMove()
+				position = position.takeBack()
 				fmt.Printf("%s\n", position)
 			}
 		default:
 			setup()
 			if move, validMoves := NewMoveFromString(position, command); move != 0 {
-				position = position.makeMove(move)
+				position = position.playMove(move)
 				think()
 			} else { // Invalid move or non-evasion on check.
-				fancy := e.fancy; e.fancy = false
 				fmt.Printf("%s appears to be an invalid move; valid moves are %v\n", command, validMoves)
-				e.fancy = fancy
 			}
 		}
 	}
-	return e
 }
@@ -0,0 +1,114 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (`math`; `time`)
+
+const (
+	MinElo = 1000 // Weakest UCI_Elo setting.
+	MaxElo = 2800 // Strongest UCI_Elo setting.
+)
+
+// Playing strength handicap for the given Elo rating. Weaker levels search
+// shallower with smaller node budget, add noise to root move scores, and
+// occasionally search a root move too shallow to see the tactics.
+type Strength struct {
+	depth   int  // Maximum search depth.
+	nodes   int  // Node budget per move.
+	noise   int  // Root move score noise amplitude.
+	blunder int  // Chance to miss the tactics, in percent.
+}
+
+// Measured ratings of the handicap levels anchored at the strongest one. Each
+// level played 100 games (200 for 600-1000) against the next one at up to
+// 256000 nodes per move with Nebula openings and scripts/level.sh rules.
+var strengthLevels = [...]struct{ level, elo int }{
+	{ -1000, 893 }, { -600, 1012 }, { -200, 1068 }, { 200, 1187 }, { 600, 1247 },
+	{ 1000, 1366 }, { 1200, 1481 }, { 1400, 1562 }, { 1600, 1701 }, { 1800, 1861 },
+	{ 2000, 2057 }, { 2200, 2221 }, { 2400, 2441 }, { 2600, 2623 }, { 2800, MaxElo },
+}
+
+// Maps Elo rating to the strength handicap. The rating first gets converted
+// to the handicap level that measured as strong. All the parameters change
+// monotonically with the level: every 100 points add about 2/3 ply of depth
+// and 40% more nodes (but no fewer than 100) while the noise and the chance
+// to blunder go down linearly.
+func NewStrength(elo int) Strength {
+	elo = max(MinElo, min(MaxElo, elo))
+	level := handicapLevel(elo) - MinElo
+
+	return Strength{
+		depth:   max(1, 2 + level / 150),
+		nodes:   max(100, int(500.0 * math.Pow(2.0, float64(level) / 200.0))),
+		noise:   onePawn * (MaxElo - MinElo - level) / 1200,
+		blunder: (MaxElo - MinElo - level) / 90,
+	}
+}
+
+// Returns the handicap level that plays at the given Elo rating.
+func handicapLevel(elo int) int {
+	i := 1
+	for i < len(strengthLevels) - 1 && strengthLevels[i].elo < elo {
+		i++
+	}
+	lo, hi := strengthLevels[i - 1], strengthLevels[i]
+
+	return lo.level + (hi.level - lo.level) * (elo - lo.elo) / (hi.elo - lo.elo)
+}
+
+// Sets UCI_Elo playing strength, and optionally turns the handicap on.
+func (e *Engine) setElo(elo int, limit bool) *Engine {
+	e.elo, e.limited = max(MinElo, min(MaxElo, elo)), limit
+	return e
+}
+
+// Returns current strength handicap, if any.
+func (e *Engine) strength() (Strength, bool) {
+	if !e.limited {
+		return Strength{}, false
+	}
+
+	return NewStrength(e.elo), true
+}
+
+// Returns node budget for the search: either "go nodes" limit or the node
+// budget of the strength handicap, whichever is smaller.
+func (e *Engine) nodeBudget() int {
+	nodes := e.options.maxNodes
+	if strength, ok := e.strength(); ok && (nodes == 0 || strength.nodes < nodes) {
+		nodes = strength.nodes
+	}
+
+	return nodes
+}
+
+// Picks new random seed for the strength handicap so that each search makes
+// different mistakes.
+func (game *Game) shuffle() *Game {
+	game.seed = uint64(time.Now().UnixNano())
+	return game
+}
+
+// Returns noise to add to the root move score, and whether the move should
+// be searched too shallow to see the tactics. Both stay the same for the
+// given move throughout iterative deepening.
+func (game *Game) handicap(move Move) (noise int, blunder bool) {
+	strength, ok := game.engine.strength()
+	if !ok {
+		return 0, false
+	}
+
+	// SplitMix64 finalizer makes good enough random bits out of the seed
+	// and the move.
+	x := game.seed ^ (uint64(move) * 0x9E3779B97F4A7C15)
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	x ^= x >> 31
+
+	noise = int(x % uint64(2 * strength.noise + 1)) - strength.noise
+	blunder = int((x >> 32) % 100) < strength.blunder
+
+	return
+}
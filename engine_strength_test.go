@@ -0,0 +1,75 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `context`; `testing`)
+
+// Higher Elo never plays weaker.
+func TestStrength000(t *testing.T) {
+	weaker := NewStrength(MinElo)
+	for elo := MinElo + 100; elo <= MaxElo; elo += 100 {
+		stronger := NewStrength(elo)
+		expect.True(t, stronger.depth >= weaker.depth)
+		expect.True(t, stronger.nodes >= weaker.nodes)
+		expect.True(t, stronger.noise <= weaker.noise)
+		expect.True(t, stronger.blunder <= weaker.blunder)
+		weaker = stronger
+	}
+	expect.True(t, NewStrength(MaxElo).nodes > NewStrength(MinElo).nodes)
+	expect.Eq(t, NewStrength(MaxElo).noise, 0)
+	expect.Eq(t, NewStrength(MaxElo).blunder, 0)
+	expect.Eq(t, NewStrength(MinElo - 500), NewStrength(MinElo))
+
+	// Measured ratings map back to their handicap levels.
+	for _, measured := range strengthLevels {
+		expect.Eq(t, handicapLevel(measured.elo), measured.level)
+	}
+}
+
+// Root move handicap stays the same during the search and is off at full strength.
+func TestStrength010(t *testing.T) {
+	game := NewEngine(`elo`, MinElo).NewGame().shuffle()
+	p := game.start()
+	move := NewMove(p, G1, F3)
+	noise, blunder := game.handicap(move)
+	for i := 0; i < 10; i++ {
+		n, b := game.handicap(move)
+		expect.Eq(t, n, noise)
+		expect.Eq(t, b, blunder)
+	}
+	expect.True(t, abs(noise) <= NewStrength(MinElo).noise)
+
+	game.engine.limited = false
+	noise, blunder = game.handicap(move)
+	expect.Eq(t, noise, 0)
+	expect.False(t, blunder)
+}
+
+// Weakest level stays within its node budget and depth limit.
+func TestStrength020(t *testing.T) {
+	engine := NewEngine(`elo`, MinElo, `movetime`, 10000)
+	game := engine.NewGame()
+	game.start()
+	result := game.think(context.Background())
+	nodes, qnodes := game.nodes()
+
+	expect.Ne(t, result.Move, Move(0))
+	expect.True(t, result.Depth <= NewStrength(MinElo).depth)
+	expect.True(t, nodes + qnodes < NewStrength(MinElo).nodes * 2)
+}
+
+// UCI_Elo is clamped to the supported range.
+func TestStrength030(t *testing.T) {
+	engine := NewEngine()
+	expect.False(t, engine.limited)
+	expect.Eq(t, engine.nodeBudget(), 0)
+
+	engine.setElo(500, true)
+	expect.Eq(t, engine.elo, MinElo)
+	expect.Eq(t, engine.nodeBudget(), NewStrength(MinElo).nodes)
+
+	engine.options.maxNodes = 10
+	expect.Eq(t, engine.nodeBudget(), 10)
+}
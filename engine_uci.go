@@ -6,11 +6,13 @@ package donna
 
 import (
 	`bufio`
+	`context`
 	`fmt`
 	`io`
 	`os`
 	`strconv`
 	`strings`
+	`sync`
 )
 
 func (e *Engine) uciScore(depth, score, alpha, beta int) *Engine {
@@ -25,25 +27,70 @@ func (e *Engine) uciScore(depth, score, alpha, beta int) *Engine {
 		}
 		str += fmt.Sprintf(" mate %d", mate/2)
 	}
+	str += e.uciWdl(score)
 	if score <= alpha {
 		str += " upperbound"
 	} else if score >= beta {
 		str += " lowerbound"
 	}
 
-	return engine.reply(str + "\n")
+	return e.reply(str + "\n")
+}
+
+// Returns expected win/draw/loss in per mille if UCI_ShowWDL option is on.
+func (e *Engine) uciWdl(score int) string {
+	if !e.showWdl {
+		return ``
+	}
+
+	win, draw, loss := e.game.position().wdl(score)
+	return fmt.Sprintf(" wdl %d %d %d", win, draw, loss)
 }
 
 func (e *Engine) uciMove(move Move, moveno, depth int) *Engine {
-	return engine.reply("info depth %d currmove %s currmovenumber %d\n", depth, move.notation(), moveno)
+	return e.reply("info depth %d currmove %s currmovenumber %d\n", depth, e.uciNotation(move), moveno)
+}
+
+// Returns move notation as expected by GUI. In Chess960 mode castles are
+// sent as the king capturing its own rook, ex. `g1h1`.
+func (e *Engine) uciNotation(move Move) string {
+	if e.chess960 && move.isCastle() {
+		return move.notation()[:2] + Move(e.game.castling.rook(move.color(), move.to()) << 8).notation()[2:4]
+	}
+
+	return move.notation()
 }
 
+// Replies with the best move followed by expected opponent's reply, if any,
+// so that GUI could let the engine ponder on it.
 func (e *Engine) uciBestMove(move Move, duration int64) *Engine {
-	return engine.reply("info nodes %d time %d\nbestmove %s\n", game.nodes + game.qnodes, duration, move.notation())
+	nodes, qnodes := e.game.nodes()
+	str := fmt.Sprintf("info nodes %d time %d\nbestmove %s", nodes + qnodes, duration, e.uciNotation(move))
+	if pv := &e.game.rootpv; pv.size > 1 && pv.moves[0] == move {
+		str += " ponder " + e.uciNotation(pv.moves[1])
+	}
+
+	return e.reply(str + "\n")
 }
 
+// Prints principal variation, or all the lines found so far in MultiPV mode.
 func (e *Engine) uciPrincipal(depth, score int, duration int64) *Engine {
-	str := fmt.Sprintf("info depth %d score", depth)
+	if len(e.game.lines) > 1 {
+		for i := range e.game.lines {
+			e.uciLine(depth, i + 1, e.game.lines[i].score, &e.game.lines[i], duration)
+		}
+		return e
+	}
+
+	return e.uciLine(depth, 0, score, &e.game.rootpv, duration)
+}
+
+func (e *Engine) uciLine(depth, multipv, score int, pv *RootPv, duration int64) *Engine {
+	str := fmt.Sprintf("info depth %d", depth)
+	if multipv > 0 {
+		str += fmt.Sprintf(" multipv %d", multipv)
+	}
+	str += " score"
 
 	if !isMate(score) {
 		str += fmt.Sprintf(" cp %d", score * 100 / onePawn)
@@ -54,20 +101,34 @@ func (e *Engine) uciPrincipal(depth, score int, duration int64) *Engine {
 		}
 		str += fmt.Sprintf(" mate %d", mate / 2)
 	}
-	str += fmt.Sprintf(" nodes %d nps %d time %d pv", game.nodes + game.qnodes, nps(duration), duration)
+	str += e.uciWdl(score)
+	nodes, qnodes := e.game.nodes()
+	str += fmt.Sprintf(" nodes %d nps %d hashfull %d time %d pv", nodes + qnodes, e.game.nps(duration), e.game.hashfull(), duration)
 
-	for i := 0; i < game.rootpv.size; i++ {
-		str += " " + game.rootpv.moves[i].notation()
+	for i := 0; i < pv.size; i++ {
+		str += " " + e.uciNotation(pv.moves[i])
 	}
 
-	return engine.reply(str + "\n")
+	return e.reply(str + "\n")
 }
 
 // Brain-damaged universal chess interface (UCI) protocol as described at
 // http://wbec-ridderkerk.nl/html/UCIProtocol.html
 func (e *Engine) Uci() *Engine {
-	var game *Game
+	return e.uciLoop(os.Stdin)
+}
+
+// Reads and handles UCI commands until "quit" or end of input. The search runs
+// in background so that we could answer "isready" or handle "stop" any time.
+func (e *Engine) uciLoop(input io.Reader) *Engine {
 	var position *Position
+	var thinking sync.WaitGroup
+
+	// Halts the search, if any, and waits for it to reply with the best move.
+	halt := func() {
+		e.clock.halt.Store(true)
+		thinking.Wait()
+	}
 
 	e.uci = true
 
@@ -77,17 +138,33 @@ func (e *Engine) Uci() *Engine {
 		e.reply("id name Donna %s\n", Version)
 		e.reply("id author Michael Dvorkin\n")
 		e.reply("option name Hash type spin default 256 min 32 max 1024\n")
-		// e.reply("option name Mobility type spin default %d min 0 max 100\n", weightMobility.midgame)
-		// e.reply("option name PawnStructure type spin default %d min 0 max 100\n", weightPawnStructure.midgame)
-		// e.reply("option name PassedPawns type spin default %d min 0 max 100\n", weightPassedPawns.midgame)
-		// e.reply("option name KingSafety type spin default %d min 0 max 100\n", weightOurKingSafety.midgame)
-		// e.reply("option name OppositeKingSafety type spin default %d min 0 max 100\n", weightTheirKingSafety.midgame)
+		e.reply("option name Clear Hash type button\n")
+		e.reply("option name HashFile type string default <empty>\n")
+		e.reply("option name Save Hash type button\n")
+		e.reply("option name Load Hash type button\n")
+		e.reply("option name Threads type spin default 1 min 1 max %d\n", MaxThreads)
+		e.reply("option name MultiPV type spin default 1 min 1 max %d\n", MaxMultiPV)
+		e.reply("option name Ponder type check default false\n")
+		e.reply("option name UCI_Chess960 type check default false\n")
+		e.reply("option name UCI_LimitStrength type check default false\n")
+		e.reply("option name UCI_Elo type spin default %d min %d max %d\n", MaxElo, MinElo, MaxElo)
+		e.reply("option name Contempt type spin default 0 min -100 max 100\n")
+		e.reply("option name DynamicContempt type check default false\n")
+		e.reply("option name UCI_ShowWDL type check default false\n")
+		e.reply("option name SyzygyPath type string default <empty>\n")
+		e.reply("option name SyzygyProbeLimit type spin default 6 min 0 max %d\n", tbPieces)
+		e.reply("option name EvalProfile type string default <empty>\n")
+		e.reply("option name EvalFile type string default <empty>\n")
+		for _, w := range e.profile.weights {
+			e.reply("option name %s type spin default 100 min 0 max 200\n", w.option)
+		}
 		e.reply("uciok\n")
 	}
 
 	// "ucinewgame" command handler.
 	doUciNewGame := func(args []string) {
-		game, position = nil, nil
+		halt()
+		position = nil
 	}
 
 	// "isready" command handler.
@@ -98,14 +175,16 @@ func (e *Engine) Uci() *Engine {
 	// "position [startpos | fen ] [ moves ... ]" command handler.
 	doPosition := func(args []string) {
 		// Make sure we've started the game since "ucinewgame" is optional.
-		if game == nil || position == nil {
-			game = NewGame()
+		halt()
+		if e.game == nil || position == nil {
+			e.NewGame()
 		}
 
 		switch args[0] {
 		case `startpos`:
 			args = args[1:]
-			position = game.start()
+			e.game.initial = `rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1`
+			position = e.game.start()
 		case `fen`:
 			fen := []string{}
 			for _, token := range args[1:] {
@@ -115,8 +194,8 @@ func (e *Engine) Uci() *Engine {
 				}
 				fen = append(fen, token)
 			}
-			game.initial = strings.Join(fen, ` `)
-			position = game.start()
+			e.game.initial = strings.Join(fen, ` `)
+			position = e.game.start()
 		default:
 			return
 		}
@@ -124,37 +203,53 @@ func (e *Engine) Uci() *Engine {
 		if position != nil && len(args) > 0 && args[0] == `moves` {
 			for _, move := range args[1:] {
 				args = args[1:] // Shift the move.
-				position = position.makeMove(NewMoveFromNotation(position, move))
+				position = position.playMove(NewMoveFromNotation(position, move))
 			}
 		}
 	}
 
-	// "go [[wtime winc | btime binc ] movestogo] | depth | nodes | movetime"
+	// "go [[wtime winc | btime binc ] movestogo] | depth | nodes | movetime | mate"
+	// followed by optional "searchmoves <move1> ... <moveN>".
 	doGo := func(args []string) {
-		think := true
-		options := e.options
+		halt()
+		think, ponder := true, false
+		options := Options{} // <-- Nothing carries over from the previous "go".
+		searchMoves := []Move{}
 
 		for i, token := range args {
 			// Boolen "infinite" and "ponder" commands have no arguments.
 			if token == `infinite` {
-				options = Options{infinite: true}
+				options.infinite = true
 			} else if token == `ponder` {
-				options = Options{ponder: true}
+				ponder = true
 			} else if token == `test` { // <-- Custom token for use in tests.
 				think = false
+			} else if token == `searchmoves` {
+				for _, notation := range args[i+1:] {
+					if !reNotation.MatchString(notation) {
+						break
+					}
+					if move := NewMoveFromNotation(position, notation); NewGen(position, MaxPly).generateAllMoves().validOnly().amongValid(move) {
+						searchMoves = append(searchMoves, move)
+					}
+				}
 			} else if len(args) > i+1 {
 				switch token {
 				case `depth`:
 					if n, err := strconv.Atoi(args[i+1]); err == nil {
-						options = Options{ maxDepth: n }
+						options.maxDepth = n
 					}
 				case `nodes`:
 					if n, err := strconv.Atoi(args[i+1]); err == nil {
-						options = Options{ maxNodes: n }
+						options.maxNodes = n
+					}
+				case `mate`:
+					if n, err := strconv.Atoi(args[i+1]); err == nil && n > 0 {
+						options.mate = n
 					}
 				case `movetime`:
 					if n, err := strconv.Atoi(args[i+1]); err == nil {
-						options = Options{ moveTime: int64(n) }
+						options.moveTime = int64(n)
 					}
 				case `wtime`:
 					if position.color == White {
@@ -187,30 +282,132 @@ func (e *Engine) Uci() *Engine {
 				}
 			}
 		}
+		options.searchMoves = searchMoves
 		if options.timeLeft != 0 || options.timeInc != 0 || options.movesToGo != 0 {
 			e.varyingLimits(options)
 		} else {
 			e.fixedLimit(options)
 		}
+		e.options.ponder = ponder
 
-		// Start "thinking" and come up with best move unless when running
-		// tests where we verify argument parsing only.
+		// Start "thinking" in background and come up with best move unless
+		// when running tests where we verify argument parsing only.
 		if think {
-			game.Think()
+			e.clock.halt.Store(false)
+			thinking.Add(1)
+			go func() {
+				defer thinking.Done()
+				e.game.think(context.Background())
+			}()
 		}
 	}
 
-	// Stop calculating as soon as possible.
+	// The opponent has played expected move: keep searching on our clock.
+	doPonderHit := func(args []string) {
+		e.ponderHit()
+	}
+
+	// Stop calculating as soon as possible and reply with the best move found
+	// so far. GUI ignores the best move of stopped ponder search.
 	doStop := func(args []string) {
-		e.clock.halt = true
+		halt()
 	}
 
-	// Set UCI option. So far we only support "setoption name Hash value 32..1024".
+	// Set UCI option. So far we only support "setoption name Hash value 32..1024",
+	// "setoption name Threads value 1..MaxThreads", "setoption name MultiPV
+	// value 1..MaxMultiPV", "setoption name UCI_Chess960 value true|false",
+	// "setoption name UCI_LimitStrength value true|false", "setoption name
+	// UCI_Elo value MinElo..MaxElo", "setoption name Contempt value -100..100",
+	// "setoption name DynamicContempt value true|false", "setoption name
+	// UCI_ShowWDL value true|false",
+	// "setoption name SyzygyPath value <path>", "setoption name
+	// SyzygyProbeLimit value 0..7", "setoption name HashFile value <path>",
+	// "setoption name EvalProfile value <path>", "setoption name EvalFile
+	// value <path>", "setoption name Mobility|
+	// PawnStructure|PassedPawns|KingSafety|Center|Threats value 0..200", and
+	// "setoption name Clear|Save|Load Hash". The paths might contain spaces.
 	doSetOption := func(args []string) {
-		if len(args) == 4 && args[0] == `name` && args[1] == `Hash` && args[2] == `value` {
-			if n, err := strconv.Atoi(args[3]); err == nil && n >= 32 && n <= 1024 {
-				e.cacheSize = float64(n)
-				game, position = nil, nil // Make sure the game gets restarted.
+		halt()
+		if len(args) == 3 && args[0] == `name` && args[2] == `Hash` {
+			// Make sure the game is there so that the next "position"
+			// command doesn't start new game wiping out loaded cache.
+			if e.game == nil || position == nil {
+				e.NewGame()
+				position = e.game.start()
+			}
+			switch args[1] {
+			case `Clear`:
+				e.game.cache.clear()
+				e.cacheLoaded = false
+			case `Save`:
+				if err := e.game.SaveCache(e.cacheFile); err != nil {
+					e.reply("info string %s\n", err)
+				}
+			case `Load`:
+				if err := e.game.LoadCache(e.cacheFile); err != nil {
+					e.reply("info string %s\n", err)
+				}
+			}
+		} else if len(args) >= 4 && args[0] == `name` && args[2] == `value` {
+			switch args[1] {
+			case `Hash`:
+				if n, err := strconv.Atoi(args[3]); err == nil && n >= 32 && n <= 1024 {
+					e.cacheSize, e.cacheLoaded = float64(n), false
+					position = nil // Make sure the game gets restarted.
+				}
+			case `Threads`:
+				if n, err := strconv.Atoi(args[3]); err == nil && n >= 1 && n <= MaxThreads {
+					e.threads = n
+					position = nil // Make sure the game gets restarted.
+				}
+			case `Ponder`:
+				// Nothing to do: GUI tells when to ponder with "go ponder".
+			case `UCI_Chess960`:
+				e.chess960 = (args[3] == `true`)
+			case `UCI_LimitStrength`:
+				e.limited = (args[3] == `true`)
+			case `UCI_Elo`:
+				if n, err := strconv.Atoi(args[3]); err == nil {
+					e.setElo(n, e.limited)
+				}
+			case `Contempt`:
+				if n, err := strconv.Atoi(args[3]); err == nil && n >= -100 && n <= 100 {
+					e.contempt = n
+				}
+			case `DynamicContempt`:
+				e.dynamic = (args[3] == `true`)
+			case `UCI_ShowWDL`:
+				e.showWdl = (args[3] == `true`)
+			case `MultiPV`:
+				if n, err := strconv.Atoi(args[3]); err == nil && n >= 1 && n <= MaxMultiPV {
+					e.multiPV = n
+				}
+			case `HashFile`:
+				if e.cacheFile = strings.Join(args[3:], ` `); e.cacheFile == `<empty>` {
+					e.cacheFile = ``
+				}
+			case `SyzygyPath`:
+				e.setTablebasePath(strings.Join(args[3:], ` `))
+			case `SyzygyProbeLimit`:
+				if n, err := strconv.Atoi(args[3]); err == nil && n >= 0 && n <= tbPieces {
+					e.syzygyLimit = n
+				}
+			case `EvalProfile`:
+				if fileName := strings.Join(args[3:], ` `); fileName != `<empty>` {
+					if err := e.loadProfile(fileName); err != nil {
+						e.reply("info string %s\n", err)
+					}
+					position = nil // Make sure the game gets restarted.
+				}
+			case `EvalFile`:
+				if err := e.loadNetwork(strings.Join(args[3:], ` `)); err != nil {
+					e.reply("info string %s\n", err)
+				}
+				position = nil // Make sure the game gets restarted.
+			default: // Evaluation weights.
+				if n, err := strconv.Atoi(args[3]); err == nil && n >= 0 && n <= 200 && e.profile.setWeight(args[1], n) {
+					position = nil // Make sure the game gets restarted.
+				}
 			}
 		}
 	}
@@ -222,6 +419,7 @@ func (e *Engine) Uci() *Engine {
 		`position`:   doPosition,
 		`go`:         doGo,
 		`stop`:       doStop,
+		`ponderhit`:  doPonderHit,
 		`setoption`:  doSetOption,
 	}
 
@@ -230,10 +428,10 @@ func (e *Engine) Uci() *Engine {
 	// a bit or byte to read or write,
 	// I/O, I/O, I/O, I/O
 	//                -- Dave Peacock
-	bio := bufio.NewReader(os.Stdin)
+	bio := bufio.NewReader(input)
 	for {
 		command, err := bio.ReadString('\n')
-		if err != io.EOF && len(command) > 0 {
+		if len(command) > 0 {
 			//\\ e.debug("> " + command)
 			args := strings.Split(strings.Trim(command, " \t\r\n"), ` `)
 			if args[0] == `quit` {
@@ -243,6 +441,11 @@ func (e *Engine) Uci() *Engine {
 				handler(args[1:])
 			}
 		}
+		if err != nil { // No more commands, same as "quit".
+			break
+		}
 	}
+	halt()
+
 	return e
 }
@@ -0,0 +1,288 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `bufio`; `fmt`; `io`; `io/ioutil`; `os`; `path/filepath`; `strings`; `sync`; `testing`)
+
+// Standard output is captured by one UCI session at a time.
+var uciStdout sync.Mutex
+
+// Runs UCI loop capturing its output; returns a function to send commands and
+// the channel to read replies from. Sending "quit" waits till the loop is over
+// and standard output is restored.
+func uciSession() (func(string), chan string) {
+	return uciEngineSession(NewEngine())
+}
+
+// Same as above for the given engine.
+func uciEngineSession(engine *Engine) (func(string), chan string) {
+	uciStdout.Lock()
+	stdout := os.Stdout
+	reader, writer, _ := os.Pipe()
+	os.Stdout = writer
+
+	input, commands := io.Pipe()
+	done := make(chan bool)
+	go func() {
+		engine.uciLoop(input)
+		os.Stdout = stdout
+		writer.Close()
+		close(done)
+		uciStdout.Unlock()
+	}()
+
+	replies := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			if line := scanner.Text(); !strings.HasPrefix(line, `info`) || strings.HasPrefix(line, `info string`) {
+				replies <- line
+			}
+		}
+		close(replies)
+	}()
+
+	return func(command string) {
+		fmt.Fprintln(commands, command)
+		if command == `quit` {
+			<-done
+		}
+	}, replies
+}
+
+// The engine stays responsive while thinking: "isready" is answered during
+// infinite search, and "stop" makes it reply with the best move.
+func TestUci000(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`position startpos`)
+	send(`go infinite`)
+	send(`isready`)
+	expect.Eq(t, <-replies, `readyok`)
+
+	send(`stop`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`quit`)
+	_, open := <-replies
+	expect.False(t, open)
+}
+
+// Quitting in the middle of the search still gets the best move out.
+func TestUci010(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`position startpos moves e2e4`)
+	send(`go movetime 60000`)
+	send(`quit`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	_, open := <-replies
+	expect.False(t, open)
+}
+
+// Chess960 castles are sent as the king capturing its own rook.
+func TestUci020(t *testing.T) {
+	engine := NewEngine()
+	p := engine.NewGame(`bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9`).start()
+	move := NewCastle(p, G1, G1)
+	expect.Eq(t, engine.uciNotation(move), `g1g1`)
+
+	engine.chess960 = true
+	expect.Eq(t, engine.uciNotation(move), `g1h1`)
+}
+
+// Mate search replies with the mating move. If there is no mate it says so
+// and still comes up with the move.
+func TestUci030(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`position fen 5Kbk/6pp/6P1/8/8/8/8/7R w - - 0 1`)
+	send(`go mate 2`)
+	expect.Eq(t, <-replies, `bestmove h1h6 ponder g7h6`)
+
+	send(`go mate 1`)
+	expect.Eq(t, <-replies, `info string no mate in 1`)
+	expect.Eq(t, <-replies, `bestmove h1h6 ponder g7h6`)
+
+	send(`quit`)
+}
+
+// Search could be restricted to given root moves.
+func TestUci040(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`position startpos moves e2e4 e7e5`)
+	send(`go depth 4 searchmoves a2a3 h2h4`)
+	reply := <-replies
+	expect.True(t, strings.HasPrefix(reply, `bestmove a2a3`) || strings.HasPrefix(reply, `bestmove h2h4`))
+
+	send(`go depth 4 searchmoves g1h3`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove g1h3`))
+
+	send(`quit`)
+}
+
+// UCI_ShowWDL adds expected win/draw/loss to the score.
+func TestUci050(t *testing.T) {
+	engine := NewEngine()
+	engine.NewGame().start()
+	expect.Eq(t, engine.uciWdl(100), ``)
+
+	engine.showWdl = true
+	win, draw, loss := engine.game.position().wdl(100)
+	expect.Eq(t, engine.uciWdl(100), fmt.Sprintf(" wdl %d %d %d", win, draw, loss))
+	expect.Eq(t, engine.uciWdl(Checkmate - 1), ` wdl 1000 0 0`)
+}
+
+// Very long games don't run out of game history space.
+func TestUci060(t *testing.T) {
+	send, replies := uciSession()
+
+	moves := strings.Repeat(` g1f3 g8f6 f3g1 f6g8`, 300)
+	send(`position startpos moves` + moves + ` e2e4`)
+	send(`go depth 3`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`quit`)
+}
+
+// Hash could be cleared with the button option.
+func TestUci070(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`uci`)
+	options := []string{}
+	for reply := <-replies; reply != `uciok`; reply = <-replies {
+		options = append(options, reply)
+	}
+	expect.Contain(t, options, `option name Clear Hash type button`)
+
+	send(`position startpos`)
+	send(`go depth 4`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`setoption name Clear Hash`)
+	send(`isready`)
+	expect.Eq(t, <-replies, `readyok`)
+
+	send(`quit`)
+}
+
+// Hash could be saved to the file and loaded back.
+func TestUci080(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), `donna.hash`)
+	send, replies := uciSession()
+
+	send(`position startpos`)
+	send(`go depth 4`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`setoption name HashFile value ` + fileName)
+	send(`setoption name Save Hash`)
+	send(`ucinewgame`)
+	send(`setoption name Load Hash`)
+	send(`isready`)
+	expect.Eq(t, <-replies, `readyok`)
+
+	_, err := os.Stat(fileName)
+	expect.Eq(t, err, error(nil))
+
+	send(`quit`)
+}
+
+// Evaluation profile and weights could be set with UCI options.
+func TestUci090(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), `profile.json`)
+	ioutil.WriteFile(fileName, []byte(`{ "rookOnOpen": [30, 15] }`), 0644)
+	engine := NewEngine()
+	send, replies := uciEngineSession(engine)
+
+	send(`uci`)
+	options := []string{}
+	for reply := <-replies; reply != `uciok`; reply = <-replies {
+		options = append(options, reply)
+	}
+	expect.Contain(t, options, `option name EvalProfile type string default <empty>`)
+	expect.Contain(t, options, `option name KingSafety type spin default 100 min 0 max 200`)
+
+	send(`setoption name EvalProfile value ` + fileName)
+	send(`setoption name Threats value 0`)
+	send(`isready`)
+	expect.Eq(t, <-replies, `readyok`)
+	send(`quit`)
+
+	expect.Eq(t, engine.profile.rookOnOpen, Score{ 30, 15 })
+	expect.Eq(t, engine.profile.weightThreats, Score{ 0, 0 })
+	expect.Eq(t, NewEngine().profile.rookOnOpen, Score{ 22, 10 }) // Other engines are not affected.
+}
+
+// Neural network evaluator could be loaded with EvalFile option.
+func TestUci100(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`uci`)
+	options := []string{}
+	for reply := <-replies; reply != `uciok`; reply = <-replies {
+		options = append(options, reply)
+	}
+	expect.Contain(t, options, `option name EvalFile type string default <empty>`)
+
+	send(`setoption name EvalFile value testdata/nnue/tiny.nnue`)
+	send(`position fen 6k1/5ppp/8/8/8/8/5PPP/R5K1 w - - 0 1`)
+	send(`go depth 4`)
+	expect.Eq(t, <-replies, `bestmove a1a8`)
+
+	send(`setoption name EvalFile value <empty>`)
+	send(`position startpos moves e2e4 e7e5`)
+	send(`go depth 4`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`quit`)
+}
+
+// Mate search limit doesn't carry over to the searches that follow.
+func TestUci110(t *testing.T) {
+	send, replies := uciSession()
+
+	send(`position fen 5Kbk/6pp/6P1/8/8/8/8/7R w - - 0 1`)
+	send(`go mate 1`)
+	expect.Eq(t, <-replies, `info string no mate in 1`)
+	expect.Eq(t, <-replies, `bestmove h1h6 ponder g7h6`)
+
+	send(`position startpos`)
+	send(`go depth 3`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`go wtime 1000 btime 1000`)
+	expect.True(t, strings.HasPrefix(<-replies, `bestmove `))
+
+	send(`quit`)
+}
+
+// "position startpos" starts off the initial position even if the game was
+// started with FEN.
+func TestUci120(t *testing.T) {
+	engine := NewEngine()
+	engine.uciLoop(strings.NewReader("position fen 5Kbk/6pp/6P1/8/8/8/8/7R w - - 0 1\nposition startpos moves e2e4\n"))
+	expect.Eq(t, engine.game.position().fen(), `rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1`)
+}
+
+// Hash loaded along with the options survives "ucinewgame" and "position"
+// that GUI sends next; the following new game clears it.
+func TestUci130(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), `donna.hash`)
+	p := NewEngine(`cache`, 32).NewGame().start()
+	e2e4 := NewMove(p, E2, E4)
+	p.cache(e2e4, 42, 8, 0, cacheExact)
+	expect.Eq(t, p.game().SaveCache(fileName), error(nil))
+
+	engine := NewEngine()
+	engine.uciLoop(strings.NewReader("setoption name Hash value 32\nsetoption name HashFile value " + fileName + "\nsetoption name Load Hash\nucinewgame\nisready\nposition startpos\n"))
+	expect.Eq(t, engine.game.position().cachedMove(), e2e4)
+
+	engine.uciLoop(strings.NewReader("ucinewgame\nposition startpos\n"))
+	expect.True(t, engine.game.position().cachedMove().nil())
+}
@@ -48,36 +48,49 @@ type Evaluation struct {
 	attacks   [14]Bitmask 	 // Attack bitmasks for all the pieces on the board.
 	pins      [2]Bitmask     // Bitmask of pinned pieces for both sides.
 	pawns     *PawnEntry 	 // Pointer to the pawn cache entry.
-	material  *MaterialEntry // Pointer to the matrial base entry.
+	material  *MaterialEntry // Pointer to the material base entry.
 	position  *Position 	 // Pointer to the position we're evaluating.
+	profile   *Profile 	 // Evaluation parameters of the engine.
 	metrics   Metrics 	 // Evaluation metrics when tracking is on.
+	squares   *squareTrace	 // Per-square contributions when tracking squares.
 }
 
-// Use single statically allocated variable to avoid garbage collection overhead.
-var eval Evaluation
-
 // The following statement is true. The previous statement is false. Main position
-// evaluation method that returns single blended score.
+// evaluation method that returns single blended score. Evaluation uses scratch
+// space statically allocated by the worker to avoid garbage collection overhead.
+// With the neural network loaded it takes over all but known endgames.
 func (p *Position) Evaluate() int {
-	return eval.init(p).run()
+	if p.worker.network != nil && p.material().flags & knownEndgame == 0 {
+		return p.evaluateNetwork()
+	}
+	return p.worker.eval.init(p).run()
 }
 
 // Auxiliary evaluation method that captures individual evaluation metrics. This
 // is useful when we want to see evaluation summary.
 func (p *Position) EvaluateWithTrace() (int, Metrics) {
-	eval.init(p)
-	eval.metrics = make(Metrics)
+	return p.evaluateWithTrace(nil)
+}
+
+func (p *Position) evaluateWithTrace(squares *squareTrace) (int, Metrics) {
+	eval := p.worker.eval.init(p)
+	eval.metrics, eval.squares = make(Metrics), squares
 
-	engine.trace = true
 	defer func() {
 		var tempo Total
 		var final Score
 
 		if p.color == White {
-			tempo.white.add(rightToMove)
+			tempo.white.add(eval.profile.rightToMove)
+		} else {
+			tempo.black.add(eval.profile.rightToMove)
+		}
+
+		// Known endgames return early, before the score gets flipped for
+		// the side to move.
+		if p.color == White || eval.material.flags & knownEndgame != 0 {
 			final.add(eval.score)
 		} else {
-			tempo.black.add(rightToMove)
 			final.sub(eval.score)
 		}
 
@@ -86,22 +99,21 @@ func (p *Position) EvaluateWithTrace() (int, Metrics) {
 		eval.checkpoint(`PST`, p.tally)
 		eval.checkpoint(`Tempo`, tempo)
 		eval.checkpoint(`Final`, final)
-		engine.trace = false
 	}()
 
 	return eval.run(), eval.metrics
 }
 
 func (e *Evaluation) init(p *Position) *Evaluation {
-	eval = Evaluation{}
-	e.position = p
+	*e = Evaluation{}
+	e.position, e.profile = p, p.worker.profile
 
 	// Initialize the score with incremental PST value and right to move.
 	e.score = p.tally
 	if p.color == White {
-		e.score.add(rightToMove)
+		e.score.add(e.profile.rightToMove)
 	} else {
-		e.score.sub(rightToMove)
+		e.score.sub(e.profile.rightToMove)
 	}
 
 	// Set up king and pawn attacks for both sides.
@@ -122,7 +134,7 @@ func (e *Evaluation) init(p *Position) *Evaluation {
 }
 
 func (e *Evaluation) run() int {
-	e.material = &materialBase[e.position.balance]
+	e.material = e.position.material()
 
 	e.score.add(e.material.score)
 	if e.material.flags & knownEndgame != 0 {
@@ -154,6 +166,11 @@ func (e *Evaluation) wrapUp() {
 	}
 }
 
+// Returns true if evaluation captures individual metrics.
+func (e *Evaluation) tracing() bool {
+	return e.metrics != nil
+}
+
 func (e *Evaluation) checkpoint(tag string, metric interface{}) {
 	e.metrics[tag] = metric
 }
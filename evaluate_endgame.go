@@ -51,40 +51,65 @@ func (e *Evaluation) strongerSide() uint8 {
 }
 
 // Known endgames where we calculate the exact score.
-func (e *Evaluation) winAgainstBareKing() int { 	// STUB.
-	return e.score.blended(e.material.phase)
+func (e *Evaluation) winAgainstBareKing() int {
+	color := e.strongerSide()
+	outposts := &e.position.outposts
+
+	// Check KRK and KQK bitbases for stalemates and hanging pieces.
+	for _, piece := range []Piece{ Rook, Queen } {
+		if outposts[color].count() == 2 && outposts[piece | Piece(color)].any() && !e.bitbaseWin(piece) {
+			return DrawScore
+		}
+	}
+
+	return e.driveBareKing(color, &bonusEdge, 0)
 }
 
-func (e *Evaluation) knightAndBishopVsBareKing() int {	// STUB.
-	return e.score.blended(e.material.phase)
+// Bishop and knight mate in the corner matching the color of the bishop.
+func (e *Evaluation) knightAndBishopVsBareKing() int {
+	color := e.strongerSide()
+	corner := let((e.position.outposts[bishop(color)] & maskDark).any(), 0, 56)
+
+	return e.driveBareKing(color, &bonusCorner, corner)
 }
 
-func (e *Evaluation) twoBishopsVsBareKing() int { 	// STUB.
-	return e.score.blended(e.material.phase)
+// Two bishops win in any corner unless they are on the same colored squares.
+func (e *Evaluation) twoBishopsVsBareKing() int {
+	color := e.strongerSide()
+	bishops := e.position.outposts[bishop(color)]
+	if (bishops & maskDark).empty() || (bishops & ^maskDark).empty() {
+		return DrawScore
+	}
+
+	return e.driveBareKing(color, &bonusEdge, 0)
 }
 
-func (e *Evaluation) kingAndPawnVsBareKing() int {
-	var color, wKing, bKing, wPawn int
-
-	stronger := e.strongerSide()
-	if stronger == White {
-		color = int(e.position.color)
-		wKing = int(e.position.king[White])
-		bKing = int(e.position.king[Black])
-		wPawn = e.position.outposts[Pawn].last()
-	} else {
-		color = int(e.position.color)^1
-		wKing = 64 + ^int(e.position.king[Black])
-		bKing = 64 + ^int(e.position.king[White])
-		wPawn = 64 + ^e.position.outposts[BlackPawn].last()
+// Adjusts the score of the side with material advantage so that it gets higher
+// as the bare king is pushed towards the edge or the mating corner of the board,
+// and as the winning king gets closer to it.
+func (e *Evaluation) driveBareKing(color uint8, drive *[64]int, corner int) int {
+	p := e.position
+	square := int(p.king[color^1])
+
+	score := e.score.blended(e.material.phase)
+	if color == Black {
+		score = -score
 	}
+	score = max(score, 0) + drive[square ^ corner] + bonusCloser[distance[square][p.king[color]]]
 
-	index := color + (wKing << 1) + (bKing << 7) + ((wPawn - 8) << 13)
-	if bitbase[index / 64] & (1 << uint(index & 0x3F)) == 0 {
+	if color == Black {
+		return -score
+	}
+
+	return score
+}
+
+func (e *Evaluation) kingAndPawnVsBareKing() int {
+	if !e.bitbaseWin(Pawn) {
 		return DrawScore
 	}
 
-	if stronger == Black {
+	if e.strongerSide() == Black {
 		return BlackWinning
 	}
 
@@ -201,15 +226,89 @@ func (e *Evaluation) kingAndPawnVsKingAndPawn() int {
 	return ExistingScore
 }
 
-func (e *Evaluation) bishopAndPawnVsBareKing() int {	// STUB.
+// Rook pawn with the bishop that doesn't control the promotion square is a draw
+// when the bare king gets to the corner.
+func (e *Evaluation) bishopAndPawnVsBareKing() int {
+	color := e.strongerSide()
+	p := e.position
+
+	pawns := p.outposts[pawn(color)]
+	if (pawns & (maskFile[0] | maskFile[7])).any() {
+		promo := flip(color^1, A8 + col(pawns.first()))
+		if (p.outposts[bishop(color)] & same(promo)).empty() && distance[promo][p.king[color^1]] <= 1 {
+			return DrawScore
+		}
+	}
+
+	return ExistingScore
+}
+
+// Checks for the Philidor defense (draw) or the Lucena position (win).
+func (e *Evaluation) rookAndPawnVsRook() int {
+	p := e.position
+	color := uint8(let(p.outposts[Pawn].any(), White, Black))
+
+	// Relative squares as seen by the side that has the pawn.
+	pawnSquare := flip(color^1, p.outposts[pawn(color)].first())
+	ourKing := flip(color^1, int(p.king[color]))
+	ourRook := flip(color^1, p.outposts[rook(color)].first())
+	theirKing := flip(color^1, int(p.king[color^1]))
+	theirRook := flip(color^1, p.outposts[rook(color^1)].first())
+
+	pawnRow, pawnCol := coordinate(pawnSquare)
+	promo := A8 + pawnCol
+	tempo := let(p.color == color, 1, 0)
+
+	// Philidor: the defending king controls the promotion square and the rook
+	// holds the third rank until the pawn advances, then checks from behind.
+	if pawnRow <= A5H5 && distance[theirKing][promo] <= 1 && ourKing <= H5 &&
+	   (row(theirRook) == A6H6 || (pawnRow <= A3H3 && row(ourRook) != A6H6)) {
+		return DrawScore
+	}
+	if pawnRow == A6H6 && distance[theirKing][promo] <= 1 && row(ourKing) + tempo <= A6H6 &&
+	   (row(theirRook) == A1H1 || (tempo == 0 && abs(col(theirRook) - pawnCol) >= 3)) {
+		return DrawScore
+	}
+	if pawnRow >= A6H6 && theirKing == promo && row(theirRook) == A1H1 &&
+	   (tempo == 0 || distance[ourKing][pawnSquare] >= 2) {
+		return DrawScore
+	}
+
+	// Lucena: the king stands on the promotion square in front of the pawn on
+	// the 7th rank, the defending king is cut off by at least a file, and the
+	// rook is not hanging.
+	if pawnRow == A7H7 && pawnCol != A1A8 && pawnCol != H1H8 && ourKing == promo &&
+	   abs(col(theirKing) - pawnCol) >= 2 && distance[ourRook][theirKing] > 1 {
+		e.score.endgame = let(color == White, WhiteWinning, BlackWinning)
+	}
+
 	return ExistingScore
 }
 
-func (e *Evaluation) rookAndPawnVsRook() int {		// STUB.
+// Rook defended by a pawn next to the king on its 2nd rank is a fortress the
+// queen can't break through.
+func (e *Evaluation) queenVsRookAndPawns() int {
+	p := e.position
+	color := uint8(let(p.outposts[Queen].any(), White, Black))
+
+	theirKing := int(p.king[color^1])
+	theirRook := p.outposts[rook(color^1)].first()
+
+	if rank(color^1, theirKing) <= A2H2 && rank(color^1, int(p.king[color])) >= A4H4 && rank(color^1, theirRook) == A3H3 &&
+	   (p.outposts[pawn(color^1)] & kingMoves[theirKing] & pawnAttacks[color][theirRook]).any() {
+		return DrawScore
+	}
+
 	return ExistingScore
 }
 
-func (e *Evaluation) queenVsRookAndPawns() int { 	// STUB.
+// Queen vs. rook is won unless the bitbase says otherwise, in which case the
+// rook draws or is about to win the queen, and the search is left to tell.
+func (e *Evaluation) queenVsRook() int {
+	if !e.bitbaseWin(Queen) {
+		return DrawScore
+	}
+
 	return ExistingScore
 }
 
@@ -229,3 +229,116 @@ func TestEndgame380(t *testing.T) {
 	score := NewGame(`Kf1,h3`, `M,Kh1,h4`).start().Evaluate()
 	expect.Eq(t, score, 0)
 }
+
+// King with winning material vs. bare king.
+func TestEndgame400(t *testing.T) {
+	score := NewGame(`Ke1,Ra1`, `M,Ke8`).start().Evaluate()
+	expect.True(t, score < -onePawn * 5)
+}
+
+func TestEndgame410(t *testing.T) {
+	edge := NewGame(`Kc6,Qd1`, `M,Ka8`).start().Evaluate()
+	center := NewGame(`Kc6,Qd1`, `M,Ke4`).start().Evaluate()
+	expect.True(t, edge < center)
+}
+
+func TestEndgame420(t *testing.T) {
+	close := NewGame(`Kc6,Rd1`, `Ka8`).start().Evaluate()
+	far := NewGame(`Kh1,Rd1`, `Ka8`).start().Evaluate()
+	expect.True(t, close > far)
+	expect.True(t, far > onePawn * 5)
+}
+
+// Knight and bishop vs. bare king.
+func TestEndgame430(t *testing.T) {
+	right := NewGame(`Kf6,Bc1,Nb1`, `Kh8`).start().Evaluate()
+	wrong := NewGame(`Kc6,Bc1,Nb1`, `Ka8`).start().Evaluate()
+	expect.True(t, right > wrong)
+	expect.True(t, wrong > onePawn * 5)
+}
+
+func TestEndgame440(t *testing.T) {
+	right := NewGame(`Kf3,Bf1,Nb1`, `M,Kh1`).start().Evaluate()
+	wrong := NewGame(`Kc3,Bf1,Nb1`, `M,Ka1`).start().Evaluate()
+	expect.True(t, right < wrong)
+	expect.True(t, wrong < -onePawn * 5)
+}
+
+// Two bishops vs. bare king.
+func TestEndgame450(t *testing.T) {
+	score := NewGame(`Ke1,Bc1,Bf1`, `Ke8`).start().Evaluate()
+	expect.True(t, score > onePawn * 5)
+}
+
+func TestEndgame460(t *testing.T) {
+	score := NewGame(`Ke1,Bc1,Be3`, `Ke8`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+// Bishop and rook pawn vs. bare king.
+func TestEndgame500(t *testing.T) {
+	score := NewGame(`Kc4,Bc1,a5`, `Kb7`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestEndgame510(t *testing.T) {
+	score := NewGame(`Kc4,Bf1,a5`, `Kb7`).start().Evaluate()
+	expect.True(t, score > 0)
+}
+
+func TestEndgame520(t *testing.T) {
+	score := NewGame(`Kg2`, `M,Kc5,Bf8,h4`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestEndgame530(t *testing.T) {
+	score := NewGame(`Kc4,Bc1,a5`, `Ke7`).start().Evaluate()
+	expect.True(t, score > 0)
+}
+
+// Rook and pawn vs. rook: Philidor and Lucena positions.
+func TestEndgame600(t *testing.T) {
+	score := NewGame(`Kd5,Rb7,e5`, `Ke8,Ra6`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestEndgame610(t *testing.T) {
+	score := NewGame(`Kd5,Rb7,e6`, `Ke8,Ra1`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestEndgame620(t *testing.T) {
+	score := NewGame(`Ke1,Ra3`, `M,Kd4,Rb2,e4`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestEndgame630(t *testing.T) {
+	score := NewGame(`Kd5,Rb7,e5`, `Ke8,Ra1`).start().Evaluate()
+	expect.True(t, score > 0)
+}
+
+func TestEndgame640(t *testing.T) {
+	score := NewGame(`Kd8,Rf1,d7`, `Kg7,Ra2`).start().Evaluate()
+	expect.True(t, score > onePawn * 10)
+}
+
+func TestEndgame650(t *testing.T) {
+	score := NewGame(`Kd4,Rh8`, `M,Kb1,Rc7,b2`).start().Evaluate()
+	expect.True(t, score > onePawn * 10)
+}
+
+func TestEndgame660(t *testing.T) {
+	score := NewGame(`Kd8,Rf1,d7`, `Ke6,Ra2`).start().Evaluate()
+	expect.True(t, score > 0 && score < onePawn * 10)
+}
+
+// Queen vs. rook and pawns.
+func TestEndgame700(t *testing.T) {
+	score := NewGame(`Kd4,Qa7`, `Kg8,Rf6,g7`).start().Evaluate()
+	expect.Eq(t, score, 0)
+}
+
+func TestEndgame710(t *testing.T) {
+	score := NewGame(`Kd4,Qa7`, `Kg8,Rf5,g7`).start().Evaluate()
+	expect.True(t, score > 0)
+}
@@ -18,20 +18,21 @@ func (e *Evaluation) analyzePawns() {
 	key := e.position.pawnId
 
 	// Since pawn hash is fairly small we can use much faster 32-bit index.
-	index := uint32(key) % uint32(len(game.pawnCache))
-	e.pawns = &game.pawnCache[index]
+	cache := &e.position.worker.pawnCache
+	index := uint32(key) % uint32(len(cache))
+	e.pawns = &cache[index]
 
 	// Bypass pawns cache if evaluation tracing is enabled.
-	if e.pawns.id != key || engine.trace {
+	if e.pawns.id != key || e.tracing() {
 		white, black := e.pawnStructure(White), e.pawnStructure(Black)
-		e.pawns.score.clear().add(white).sub(black).apply(weightPawnStructure)
+		e.pawns.score.clear().add(white).sub(black).apply(e.profile.weightPawnStructure)
 		e.pawns.id = key
 
 		// Force full king shelter evaluation since any legit king square
 		// will be viewed as if the king has moved.
 		e.pawns.king[White], e.pawns.king[Black] = 0xFF, 0xFF
 
-		if engine.trace {
+		if e.tracing() {
 			e.checkpoint(`Pawns`, Total{white, black})
 		}
 	}
@@ -42,14 +43,14 @@ func (e *Evaluation) analyzePawns() {
 func (e *Evaluation) analyzePassers() {
 	var white, black, score Score
 
-	if engine.trace {
+	if e.tracing() {
 		defer func() {
 			e.checkpoint(`Passers`, Total{white, black})
 		}()
 	}
 
 	white, black = e.pawnPassers(White), e.pawnPassers(Black)
-	score.add(white).sub(black).apply(weightPassedPawns)
+	score.add(white).sub(black).apply(e.profile.weightPassedPawns)
 	e.score.add(score)
 }
 
@@ -85,18 +86,18 @@ func (e *Evaluation) pawnStructure(our uint8) (score Score) {
 		// exposed on semi-open file.
 		if isolated {
 			if !exposed {
-				score.sub(penaltyIsolatedPawn[col])
+				score.sub(e.profile.penaltyIsolatedPawn[col])
 			} else {
-				score.sub(penaltyWeakIsolatedPawn[col])
+				score.sub(e.profile.penaltyWeakIsolatedPawn[col])
 			}
 		} else if !supported {
-			score.sub(pawnAlone) // Small penalty if the pawn is not supported by a fiendly pawn.
+			score.sub(e.profile.pawnAlone) // Small penalty if the pawn is not supported by a fiendly pawn.
 		}
 
 		// Penalty if the pawn is doubled, i.e. there is another friendly
 		// pawn in front of us.
 		if doubled {
-			score.sub(penaltyDoubledPawn[col])
+			score.sub(e.profile.penaltyDoubledPawn[col])
 		}
 
 		// Penalty if the pawn is backward.
@@ -115,9 +116,9 @@ func (e *Evaluation) pawnStructure(our uint8) (score Score) {
 					if ((enemy | enemy.up(our)) & theirPawns).any() {
 						backward = true
 						if !exposed {
-							score.sub(penaltyBackwardPawn[col])
+							score.sub(e.profile.penaltyBackwardPawn[col])
 						} else {
-							score.sub(penaltyWeakBackwardPawn[col])
+							score.sub(e.profile.penaltyWeakBackwardPawn[col])
 						}
 					}
 				}
@@ -129,7 +130,7 @@ func (e *Evaluation) pawnStructure(our uint8) (score Score) {
 			his := maskPassed[their][square + up[our]] & maskIsolated[col] & ourPawns
 			her := maskPassed[our][square] & maskIsolated[col] & theirPawns
 			if his.count() >= her.count() {
-				score.add(bonusSemiPassedPawn[rank(our, square)])
+				score.add(e.profile.bonusSemiPassedPawn[rank(our, square)])
 			}
 		}
 	}
@@ -147,10 +148,10 @@ func (e *Evaluation) pawnPassers(our uint8) (score Score) {
 	for pawns.any() {
 		square := pawns.pop()
 		rank := rank(our, square)
-		bonus := bonusPassedPawn[rank]
+		bonus := e.profile.bonusPassedPawn[rank]
 
 		if rank > A2H2 {
-			extra := extraPassedPawn[rank]
+			extra := e.profile.extraPassedPawn[rank]
 			nextSquare := square + up[our]
 
 			// Adjust endgame bonus based on how close the kings are from the
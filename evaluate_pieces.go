@@ -9,7 +9,7 @@ func (e *Evaluation) analyzePieces() {
 	var bonus, score Score
 	var knight, bishop, rook, queen, mobility Total
 
-	if engine.trace {
+	if e.tracing() {
 		defer func() {
 			var our, their Score
 			e.checkpoint(`Mobility`, mobility)
@@ -100,7 +100,7 @@ func (e *Evaluation) analyzePieces() {
 	e.attacks[Black] |= e.attacks[BlackKnight] | e.attacks[BlackBishop] | e.attacks[BlackRook] | e.attacks[BlackQueen]
 
 	// Calculate total mobility score applying mobility weight.
-	score.add(mobility.white).sub(mobility.black).apply(weightMobility)
+	score.add(mobility.white).sub(mobility.black).apply(e.profile.weightMobility)
 	e.score.add(score)
 }
 
@@ -115,22 +115,22 @@ func (e *Evaluation) knights(our uint8, maskSafe Bitmask, unsafeKing bool) (scor
 		// Bonus for knight's mobility -- unless the knight is pinned.
 		if e.pins[our].off(square) {
 			attacks = p.attacks(square)
-			mobility.add(mobilityKnight[(attacks & maskSafe).count()])
+			mobility.add(e.mobility(square, e.profile.mobilityKnight[(attacks & maskSafe).count()]))
 		}
 
 		// Penalty if knight is attacked by enemy's pawn.
 		if (maskPawn[their][square] & p.outposts[pawn(their)]).any() {
-			score.sub(penaltyPawnThreat[Knight/2])
+			score.sub(e.profile.penaltyPawnThreat[Knight/2])
 		}
 
 		// Bonus if knight is behind friendly pawn.
 		if rank(our, square) < 4 && p.outposts[pawn(our)].on(square + up[our]) {
-			score.add(behindPawn)
+			score.add(e.profile.behindPawn)
 		}
 
 		// Track if knight attacks squares around enemy's king.
 		if unsafeKing {
-			e.kingThreats(knight(our), attacks)
+			e.kingThreats(knight(our), square, attacks)
 		}
 
 		// Update attack bitmask for the knight.
@@ -152,22 +152,22 @@ func (e *Evaluation) bishops(our uint8, maskSafe Bitmask, unsafeKing bool) (scor
 		if e.pins[our].on(square) {
 			attacks &= maskLine[p.king[our]][square]
 		}
-		mobility.add(mobilityBishop[(attacks & maskSafe).count()])
+		mobility.add(e.mobility(square, e.profile.mobilityBishop[(attacks & maskSafe).count()]))
 
 
 		// Penalty for light/dark-colored pawns restricting a bishop.
 		if count := (same(square) & p.outposts[pawn(our)]).count(); count > 0 {
-			score.sub(bishopPawn.times(count))
+			score.sub(e.profile.bishopPawn.times(count))
 		}
 
 		// Penalty if bishop is attacked by enemy's pawn.
 		if (maskPawn[their][square] & p.outposts[pawn(their)]).any() {
-			score.sub(penaltyPawnThreat[Bishop/2])
+			score.sub(e.profile.penaltyPawnThreat[Bishop/2])
 		}
 
 		// Bonus if bishop is behind friendly pawn.
 		if rank(our, square) < 4 && p.outposts[pawn(our)].on(square + up[our]) {
-			score.add(behindPawn)
+			score.add(e.profile.behindPawn)
 		}
 
 		// Middle game penalty for boxed bishop.
@@ -175,26 +175,26 @@ func (e *Evaluation) bishops(our uint8, maskSafe Bitmask, unsafeKing bool) (scor
 			if our == White {
 				if (square == C1 && p.pieces[D2].isPawn() && p.pieces[D3] != 0) ||
 				   (square == F1 && p.pieces[E2].isPawn() && p.pieces[E3] != 0) {
-					score.midgame -= bishopBoxed.midgame
+					score.midgame -= e.profile.bishopBoxed.midgame
 				}
 			} else {
 				if (square == C8 && p.pieces[D7].isPawn() && p.pieces[D6] != 0) ||
 				   (square == F8 && p.pieces[E7].isPawn() && p.pieces[E6] != 0) {
-					score.midgame -= bishopBoxed.midgame
+					score.midgame -= e.profile.bishopBoxed.midgame
 				}
 			}
 		}
 
 		// Extra bonus if bishop is on central ranks.
 		extra := Score{0, 0}
-		if extra.midgame = extraBishop[flip(our, square)]; extra.midgame > 0 {
+		if extra.midgame = e.profile.extraBishop[flip(our, square)]; extra.midgame > 0 {
 			extra.endgame = extra.midgame / 2
 			score.add(extra)
 		}
 
 		// Track if bishop attacks squares around enemy's king.
 		if unsafeKing {
-			e.kingThreats(bishop(our), attacks)
+			e.kingThreats(bishop(our), square, attacks)
 		}
 
 		// Update attack bitmask for the bishop.
@@ -213,7 +213,7 @@ func (e *Evaluation) rooks(our uint8, maskSafe Bitmask, unsafeKing bool) (score,
 
 	// Bonus if rook is on 7th rank and enemy's king trapped on 8th.
 	if count := (outposts & mask7th[our]).count(); count > 0 && p.outposts[king(their)] & mask8th[our] != 0 {
-		score.add(rookOn7th.times(count))
+		score.add(e.profile.rookOn7th.times(count))
 	}
 	for outposts.any() {
 		square := outposts.pop()
@@ -224,17 +224,17 @@ func (e *Evaluation) rooks(our uint8, maskSafe Bitmask, unsafeKing bool) (score,
 			attacks &= maskLine[p.king[our]][square]
 		}
 		safeSquares := (attacks & maskSafe).count()
-		mobility.add(mobilityRook[safeSquares])
+		mobility.add(e.mobility(square, e.profile.mobilityRook[safeSquares]))
 
 		// Penalty if rook is attacked by enemy's pawn.
 		if maskPawn[their][square] & theirPawns != 0 {
-			score.sub(penaltyPawnThreat[Rook/2])
+			score.sub(e.profile.penaltyPawnThreat[Rook/2])
 		}
 
 		// Bonus if rook is attacking enemy's pawns.
 		if rank(our, square) >= 4 {
 			if count := (attacks & theirPawns).count(); count > 0 {
-				score.add(rookOnPawn.times(count))
+				score.add(e.profile.rookOnPawn.times(count))
 			}
 		}
 
@@ -243,9 +243,9 @@ func (e *Evaluation) rooks(our uint8, maskSafe Bitmask, unsafeKing bool) (score,
 		isFileAjar := (ourPawns & maskFile[column] == 0)
 		if isFileAjar {
 			if theirPawns & maskFile[column] == 0 {
-				score.add(rookOnOpen)
+				score.add(e.profile.rookOnOpen)
 			} else {
-				score.add(rookOnSemiOpen)
+				score.add(e.profile.rookOnSemiOpen)
 			}
 		}
 
@@ -258,21 +258,21 @@ func (e *Evaluation) rooks(our uint8, maskSafe Bitmask, unsafeKing bool) (score,
 			// Queenside box: king on D/C/B vs. rook on A/B/C files. Increase the
 			// the penalty since no castle is possible.
 			if column < kingColumn && rookBoxA[our].on(square) && kingBoxA[our].on(kingSquare) {
-				score.midgame -= (rookBoxed.midgame - safeSquares * 10) * 2
+				score.midgame -= (e.profile.rookBoxed.midgame - safeSquares * 10) * 2
 			}
 
 			// Kingside box: king on E/F/G vs. rook on H/G/F files.
 			if column > kingColumn && rookBoxH[our].on(square) && kingBoxH[our].on(kingSquare) {
-				score.midgame -= (rookBoxed.midgame - safeSquares * 10)
+				score.midgame -= (e.profile.rookBoxed.midgame - safeSquares * 10)
 				if p.castles & castleKingside[our] == 0 {
-					score.midgame -= (rookBoxed.midgame - safeSquares * 10)
+					score.midgame -= (e.profile.rookBoxed.midgame - safeSquares * 10)
 				}
 			}
 		}
 
 		// Track if rook attacks squares around enemy's king.
 		if unsafeKing {
-			e.kingThreats(rook(our), attacks)
+			e.kingThreats(rook(our), square, attacks)
 		}
 
 		// Update attack bitmask for the rook.
@@ -294,16 +294,16 @@ func (e *Evaluation) queens(our uint8, maskSafe Bitmask, unsafeKing bool) (score
 		if e.pins[our].on(square) {
 			attacks &= maskLine[p.king[our]][square]
 		}
-		mobility.add(mobilityQueen[min(15, (attacks & maskSafe).count())])
+		mobility.add(e.mobility(square, e.profile.mobilityQueen[min(15, (attacks & maskSafe).count())]))
 
 		// Penalty if queen is attacked by enemy's pawn.
 		if (maskPawn[their][square] & p.outposts[pawn(their)]).any() {
-			score.sub(penaltyPawnThreat[Queen/2])
+			score.sub(e.profile.penaltyPawnThreat[Queen/2])
 		}
 
 		// Track if queen attacks squares around enemy's king.
 		if unsafeKing {
-			e.kingThreats(queen(our), attacks)
+			e.kingThreats(queen(our), square, attacks)
 		}
 
 		// Update attack bitmask for the queen.
@@ -314,12 +314,15 @@ func (e *Evaluation) queens(our uint8, maskSafe Bitmask, unsafeKing bool) (score
 }
 
 // Updates safety data used later on when evaluating king safety.
-func (e *Evaluation) kingThreats(piece Piece, attacks Bitmask) {
+func (e *Evaluation) kingThreats(piece Piece, square int, attacks Bitmask) {
 	their := piece.color()^1
 
-	if (attacks & e.safety[their].fort).any() {
+	if fort := attacks & e.safety[their].fort; fort.any() {
+		if e.squares != nil {
+			e.squares.fort[square] = fort.count()
+		}
 		e.safety[their].attackers++
-		e.safety[their].threats += kingThreat[piece.id()]
+		e.safety[their].threats += e.profile.kingThreat[piece.id()]
 		if bits := attacks & e.attacks[king(their)]; bits.any() {
 			e.safety[their].attacks += bits.count()
 		}
@@ -8,7 +8,7 @@ func (e *Evaluation) analyzeSafety() {
 	var score Score
 	var cover, safety Total
 
-	if engine.trace {
+	if e.tracing() {
 		defer func() {
 			var our, their Score
 			e.checkpoint(`+King`, Total{*our.add(cover.white).add(safety.white), *their.add(cover.black).add(safety.black)})
@@ -40,7 +40,7 @@ func (e *Evaluation) analyzeSafety() {
 	}
 
 	// Calculate total king safety and pawn cover score.
-	score.add(safety.white).sub(safety.black).apply(weightSafety)
+	score.add(safety.white).sub(safety.black).apply(e.profile.weightSafety)
 	score.add(cover.white).sub(cover.black)
 	e.score.add(score)
 }
@@ -104,12 +104,12 @@ func (e *Evaluation) kingSafety(our uint8) (score Score) {
 			rank(our, square) - e.pawns.cover[our].midgame / 16
 	safetyIndex = min(63, max(0, safetyIndex + threatIndex))
 
-	score.midgame -= kingSafety[safetyIndex]
+	score.midgame -= e.profile.kingSafety[safetyIndex]
 
 	if checkers > 0 {
-		score.add(rightToMove)
+		score.add(e.profile.rightToMove)
 		if checkers > 1 {
-			score.add(rightToMove)
+			score.add(e.profile.rightToMove)
 		}
 	}
 
@@ -156,17 +156,17 @@ func (e *Evaluation) kingCoverBonus(our uint8, square int) (bonus int) {
 		if pawns := (cover & maskFile[c]); pawns.any() {
 			closest = rank(our, pawns.closest(our))
 		}
-		bonus -= penaltyCover[closest]
+		bonus -= e.profile.penaltyCover[closest]
 
 		// Enemy pawns facing the king.
 		if pawns := (storm & maskFile[c]); pawns.any() {
 			farthest := rank(our, pawns.farthest(our^1))
 			if closest == 0 { // No opposing friendly pawn.
-				bonus -= penaltyStorm[farthest]
+				bonus -= e.profile.penaltyStorm[farthest]
 			} else if farthest == closest + 1 {
-				bonus -= penaltyStormBlocked[farthest]
+				bonus -= e.profile.penaltyStormBlocked[farthest]
 			} else {
-				bonus -= penaltyStormUnblocked[farthest]
+				bonus -= e.profile.penaltyStormUnblocked[farthest]
 			}
 		}
 	}
@@ -185,7 +185,7 @@ func (e *Evaluation) kingPawnProximity(our uint8, square int) (penalty int) {
 			proximity = min(proximity, distance[square][pawns.pop()])
 		}
 
-		penalty = -kingByPawn.endgame * (proximity - 1)
+		penalty = -e.profile.kingByPawn.endgame * (proximity - 1)
 	}
 
 	return penalty
@@ -0,0 +1,238 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`bufio`
+	`fmt`
+	`io`
+	`sort`
+	`strings`
+)
+
+// Evaluation symmetries: the evaluation of the reflected position must match
+// the original one.
+const (
+	symmetryColors = iota	// Board flipped upside down with the colors swapped.
+	symmetryMirror		// Board mirrored left to right; only without castle rights.
+	symmetrySide		// Side to move flipped; only when the other king is not in check.
+)
+
+var symmetryNames = [3]string{ `color flip`, `mirror`, `side to move flip` }
+
+// Checks evaluation symmetries of the positions read one per line in FEN, EPD
+// or DCF format; the lines starting with # are skipped. Returns the number of
+// positions checked and the error describing the first offending evaluation
+// term or the position that could not be set up, if any.
+func EvalCheck(r io.Reader) (count int, err error) {
+	game := NewEngine().NewGame()
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if fen := symmetryFen(scanner.Text()); fen != `` {
+			if err := game.evalCheck(fen); err != nil {
+				return count, fmt.Errorf("line %d: %v", line, err)
+			}
+			count++
+		}
+	}
+
+	return count, scanner.Err()
+}
+
+// Returns FEN for the FEN, EPD, or DCF line, or empty string if there is no
+// position.
+func symmetryFen(line string) string {
+	line = strings.TrimSpace(strings.Split(line, `#`)[0])
+	if line == `` {
+		return ``
+	}
+	if strings.Contains(line, ` : `) { // DCF.
+		p, err := NewEngine().NewGame(strings.Split(line, ` : `)...).Start()
+		if err != nil {
+			return line // Let evalCheck() report the error.
+		}
+		return p.fen()
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) >= 6 && isNumber(fields[4]) && isNumber(fields[5]) {
+		return strings.Join(fields[:6], ` `)
+	}
+
+	return strings.Join(fields[:min(4, len(fields))], ` `)
+}
+
+// Checks all evaluation symmetries of the position. Side to move doesn't get
+// flipped when in check since the other side can't be in check.
+func (game *Game) evalCheck(fen string) error {
+	score, metrics, err := game.trace(fen)
+	if err != nil {
+		return err
+	}
+	p := game.position()
+	inCheck := p.isInCheck(p.color)
+
+	for kind := symmetryColors; kind <= symmetrySide; kind++ {
+		reflection, ok := reflectFen(fen, kind)
+		if !ok || (kind == symmetrySide && inCheck) {
+			continue
+		}
+		reflected, reflectedMetrics, err := game.trace(reflection)
+		if err != nil {
+			return fmt.Errorf("%s %q vs %q: %v", symmetryNames[kind], fen, reflection, err)
+		}
+		if err = game.symmetryCheck(kind, score, metrics, reflected, reflectedMetrics); err != nil {
+			return fmt.Errorf("%s %q vs %q: %v", symmetryNames[kind], fen, reflection, err)
+		}
+	}
+
+	return nil
+}
+
+// Evaluates the position from White's point of view capturing the metrics.
+func (game *Game) trace(fen string) (int, Metrics, error) {
+	game.initial = fen
+	p, err := game.Start()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	score, metrics := p.EvaluateWithTrace()
+	score = let(p.color == White, score, -score)
+	if flags := p.material().flags; flags & (knownEndgame | lesserKnownEndgame) != 0 {
+		metrics[`Endgame`] = true // Known endgames might depend on side to move.
+	}
+
+	return score, metrics, nil
+}
+
+// Returns FEN of the reflected position, or false if the reflection does not
+// apply to the position.
+func reflectFen(fen string, kind int) (string, bool) {
+	fields := strings.Fields(fen)
+	if len(fields) < 4 {
+		return ``, false
+	}
+	ranks := strings.Split(fields[0], `/`)
+	color, castles, enpassant := fields[1], fields[2], fields[3]
+
+	switch kind {
+	case symmetryColors:
+		for i, j := 0, len(ranks) - 1; i < j; i, j = i + 1, j - 1 {
+			ranks[i], ranks[j] = ranks[j], ranks[i]
+		}
+		for i := range ranks {
+			ranks[i] = swapCase(ranks[i])
+		}
+		color, castles = otherColor(color), swapCase(castles)
+		if enpassant != `-` {
+			enpassant = enpassant[:1] + string('9' - enpassant[1] + '0')
+		}
+	case symmetryMirror:
+		if castles != `-` {
+			return ``, false
+		}
+		for i := range ranks {
+			ranks[i] = reverse(ranks[i])
+		}
+		if enpassant != `-` {
+			enpassant = string('h' - enpassant[0] + 'a') + enpassant[1:]
+		}
+	case symmetrySide:
+		color, enpassant = otherColor(color), `-`
+	}
+
+	fields[0], fields[1], fields[2], fields[3] = strings.Join(ranks, `/`), color, castles, enpassant
+
+	return strings.Join(fields, ` `), true
+}
+
+// Compares the evaluation score and metrics of the original and reflected
+// positions; the scores are from White's point of view.
+func (game *Game) symmetryCheck(kind, score int, metrics Metrics, reflectedScore int, reflected Metrics) error {
+	expected := score
+	switch kind {
+	case symmetryColors:
+		expected = -score
+	case symmetrySide:
+		if metrics[`Endgame`] != nil {
+			return nil
+		}
+		// Flipping side to move only affects tempo and unstoppable passers;
+		// passers are traced before they get weighted.
+		final := metrics[`Final`].(Score)
+		before, after := metrics[`Passers`].(Total).net(), reflected[`Passers`].(Total).net()
+		weight := game.engine.profile.weightPassedPawns
+		final.sub(*before.apply(weight)).add(*after.apply(weight))
+		final.sub(metrics[`Tempo`].(Total).net()).add(reflected[`Tempo`].(Total).net())
+		expected = final.blended(metrics[`Phase`].(int))
+	}
+
+	tags := []string{}
+	for tag := range metrics {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		if kind == symmetrySide && (tag == `Tempo` || tag == `Passers` || tag == `Final`) {
+			continue
+		}
+		metric := metrics[tag]
+		if kind == symmetryColors {
+			switch value := metric.(type) {
+			case Total:
+				metric = Total{ value.black, value.white }
+			case Score:
+				metric = value.times(-1)
+			}
+		}
+		if reflected[tag] != metric {
+			return fmt.Errorf("%s is %v, expected %v", strings.Trim(tag, `+-`), reflected[tag], metric)
+		}
+	}
+
+	// All the terms match; check the score they add up to.
+	if reflectedScore != expected {
+		return fmt.Errorf("Evaluate is %d, expected %d", reflectedScore, expected)
+	}
+
+	return nil
+}
+
+// Returns White's score minus Black's one.
+func (t Total) net() Score {
+	return t.white.minus(t.black)
+}
+
+// Returns FEN side to move flipped.
+func otherColor(color string) string {
+	if color == `w` {
+		return `b`
+	}
+	return `w`
+}
+
+// Returns the string with lower and upper case letters swapped.
+func swapCase(str string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		} else if r >= 'A' && r <= 'Z' {
+			return r - 'A' + 'a'
+		}
+		return r
+	}, str)
+}
+
+// Returns the string reversed.
+func reverse(str string) string {
+	bytes := []byte(str)
+	for i, j := 0, len(bytes) - 1; i < j; i, j = i + 1, j - 1 {
+		bytes[i], bytes[j] = bytes[j], bytes[i]
+	}
+
+	return string(bytes)
+}
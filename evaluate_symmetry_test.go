@@ -0,0 +1,73 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `os`; `path/filepath`; `strings`; `testing`)
+
+// Reflected positions.
+func TestSymmetry000(t *testing.T) {
+	fen := `r3k2r/pp3ppp/2n5/3pP3/8/8/PPP2PPP/R3K2R w Kq d6 0 12`
+	reflection, ok := reflectFen(fen, symmetryColors)
+	expect.True(t, ok)
+	expect.Eq(t, reflection, `r3k2r/ppp2ppp/8/8/3Pp3/2N5/PP3PPP/R3K2R b kQ d3 0 12`)
+	reflection, ok = reflectFen(fen, symmetrySide)
+	expect.True(t, ok)
+	expect.Eq(t, reflection, `r3k2r/pp3ppp/2n5/3pP3/8/8/PPP2PPP/R3K2R b Kq - 0 12`)
+	_, ok = reflectFen(fen, symmetryMirror)
+	expect.False(t, ok)
+
+	reflection, ok = reflectFen(`8/1k6/8/3pP3/8/8/5K2/8 w - d6`, symmetryMirror)
+	expect.True(t, ok)
+	expect.Eq(t, reflection, `8/6k1/8/3Pp3/8/8/2K5/8 w - e6`)
+}
+
+// Corpus positions are evaluated symmetrically.
+func TestSymmetry010(t *testing.T) {
+	dcf, _ := filepath.Glob(`benchmarks/*.dcf`)
+	epd, _ := filepath.Glob(`scripts/*.epd`)
+	expect.True(t, len(dcf) > 0 && len(epd) > 0)
+
+	for _, fileName := range append(dcf, epd...) {
+		file, err := os.Open(fileName)
+		expect.Eq(t, err, error(nil))
+		count, err := EvalCheck(file)
+		file.Close()
+		expect.Eq(t, err, error(nil))
+		expect.True(t, count > 0)
+	}
+}
+
+// Asymmetric metric gets reported by name.
+func TestSymmetry020(t *testing.T) {
+	game := NewGame()
+	fen := `r1bq1rk1/pp2bppp/2n1pn2/3p4/2PP4/2N1PN2/PP3PPP/R2QKB1R w KQ - 3 9`
+	score, metrics, _ := game.trace(fen)
+	reflection, _ := reflectFen(fen, symmetryColors)
+	reflected, reflectedMetrics, _ := game.trace(reflection)
+	expect.Eq(t, game.symmetryCheck(symmetryColors, score, metrics, reflected, reflectedMetrics), error(nil))
+
+	expect.Contain(t, game.symmetryCheck(symmetryColors, score, metrics, reflected + 1, reflectedMetrics).Error(), `Evaluate is`)
+	pawns := reflectedMetrics[`Pawns`].(Total)
+	reflectedMetrics[`Pawns`] = Total{ pawns.white.plus(Score{ 1, 0 }), pawns.black }
+	expect.Contain(t, game.symmetryCheck(symmetryColors, score, metrics, reflected, reflectedMetrics).Error(), `Pawns is`)
+
+	// Side to move flip keeps everything but tempo and unstoppable passers.
+	reflection, _ = reflectFen(fen, symmetrySide)
+	reflected, reflectedMetrics, _ = game.trace(reflection)
+	expect.Eq(t, game.symmetryCheck(symmetrySide, score, metrics, reflected, reflectedMetrics), error(nil))
+	expect.Ne(t, reflected, score)
+}
+
+// Corpus errors are reported along with the line number; positions outside
+// of the material table get checked too.
+func TestSymmetry030(t *testing.T) {
+	count, err := EvalCheck(strings.NewReader("# Comment\n\nKe1,Qd1,Qd2,Nc3 : Ke8,Rf8\n4k3/8/8/8/8/8/8/4K2R w K - 0 1\n"))
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, count, 2)
+
+	count, err = EvalCheck(strings.NewReader("4k3/8/8/8/8/8/8/4K2R w K -\n4k3/8/8/8/8/8/8/4K3 w\n"))
+	expect.Eq(t, count, 1)
+	expect.Contain(t, err.Error(), `line 2: `)
+}
@@ -8,7 +8,7 @@ func (e *Evaluation) analyzeThreats() {
 	var score Score
 	var threats, center Total
 
-	if engine.trace {
+	if e.tracing() {
 		defer func() {
 			e.checkpoint(`Threats`, threats)
 			if e.material.turf != 0 && e.material.flags & (whiteKingSafety | blackKingSafety) != 0 {
@@ -19,13 +19,13 @@ func (e *Evaluation) analyzeThreats() {
 
 	threats.white = e.threats(White)
 	threats.black = e.threats(Black)
-	score.add(threats.white).sub(threats.black).apply(weightThreats)
+	score.add(threats.white).sub(threats.black).apply(e.profile.weightThreats)
 	e.score.add(score)
 
 	if e.material.turf != 0 && e.material.flags & (whiteKingSafety | blackKingSafety) != 0 {
 		center.white = e.center(White)
 		center.black = e.center(Black)
-		score.clear().add(center.white).sub(center.black).apply(weightCenter)
+		score.clear().add(center.white).sub(center.black).apply(e.profile.weightCenter)
 		e.score.add(score)
 	}
 }
@@ -44,7 +44,7 @@ func (e *Evaluation) threats(our uint8) (score Score) {
 	// Bonus for each enemy piece attacked by our pawn.
 	for targets.any() {
 		piece := p.pieces[targets.pop()]
-		score.add(bonusPawnThreat[piece.id()])
+		score.add(e.profile.bonusPawnThreat[piece.id()])
 	}
 
 	// Find enemy pieces that might be our likely targets: major pieces
@@ -57,22 +57,22 @@ func (e *Evaluation) threats(our uint8) (score Score) {
 		targets = likely & (e.attacks[knight(our)] | e.attacks[bishop(our)])
 		for targets.any() {
 			piece := p.pieces[targets.pop()]
-			score.add(bonusMinorThreat[piece.id()])
+			score.add(e.profile.bonusMinorThreat[piece.id()])
 		}
 
 		// Bonus for enemy pieces attacked by rooks.
 		targets = (undefended | p.outposts[queen(their)]) & e.attacks[rook(our)]
 		for targets.any() {
 			piece := p.pieces[targets.pop()]
-			score.add(bonusRookThreat[piece.id()])
+			score.add(e.profile.bonusRookThreat[piece.id()])
 		}
 
 		// Bonus for enemy pieces attacked by the king.
 		if targets = undefended & e.attacks[king(our)]; targets.any() {
 			if count := targets.count(); count > 0 {
-				score.add(kingAttack)
+				score.add(e.profile.kingAttack)
 				if count > 1 {
-					score.add(kingAttack)
+					score.add(e.profile.kingAttack)
 				}
 			}
 		}
@@ -80,7 +80,7 @@ func (e *Evaluation) threats(our uint8) (score Score) {
 		// Extra bonus when attacking enemy pieces that are hanging.
 		if targets = undefended & ^e.attacks[their]; targets.any() {
 			if count := targets.count(); count > 0 {
-				score.add(hangingAttack.times(count))
+				score.add(e.profile.hangingAttack.times(count))
 			}
 		}
 	}
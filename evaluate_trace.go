@@ -0,0 +1,138 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`encoding/json`
+	`fmt`
+)
+
+// Evaluation terms in the order they are shown by Summary().
+var traceTerms = []string{ `PST`, `Imbalance`, `Tempo`, `Center`, `Threats`, `Pawns`, `Passers`, `Mobility`,
+	`+Pieces`, `-Knights`, `-Bishops`, `-Rooks`, `-Queens`, `+King`, `-Cover`, `-Safety` }
+
+// Machine readable evaluation trace. All scores but the evaluation itself are
+// from White's point of view, and per-side terms are traced before they get
+// weighted, just like in Summary().
+type Trace struct {
+	Fen      string          `json:"fen"`
+	Phase    int             `json:"phase"`              // 256 for the opening down to 0 for pawn endgame.
+	Terms    []TraceTerm     `json:"terms"`
+	Final    Score           `json:"final"`              // Final midgame and endgame scores.
+	Blended  int             `json:"blended"`            // Final score blended by game phase.
+	Score    int             `json:"score"`              // Evaluation from side to move's point of view.
+	Squares  []TraceSquare   `json:"squares,omitempty"`  // Contributions of each piece, if requested.
+}
+
+type TraceTerm struct {
+	Name     string          `json:"name"`
+	White    *Score          `json:"white,omitempty"`    // Nil unless the term is traced per side.
+	Black    *Score          `json:"black,omitempty"`
+	Total    Score           `json:"total"`              // White minus Black.
+	Blended  int             `json:"blended"`
+}
+
+type TraceSquare struct {
+	Square   string          `json:"square"`
+	Piece    string          `json:"piece"`              // Upper case for White, lower case for Black.
+	PST      Score           `json:"pst"`                // Piece value plus square bonus.
+	Mobility *Score          `json:"mobility,omitempty"` // Not weighted; minor and major pieces only.
+	Fort     int             `json:"fort,omitempty"`     // Number of squares attacked in enemy king's fort.
+}
+
+// Per-square contributions captured by the evaluation when tracing squares.
+type squareTrace struct {
+	mobility [64]Score
+	mobile   Bitmask 	// Squares of the pieces with mobility bonus.
+	fort     [64]int
+}
+
+// Returns evaluation trace of the position; with squares set to true the
+// trace includes contributions of each piece on the board.
+func (p *Position) Trace(squares bool) *Trace {
+	var traced *squareTrace
+	if squares {
+		traced = &squareTrace{}
+	}
+
+	score, metrics := p.evaluateWithTrace(traced)
+	phase, final := metrics[`Phase`].(int), metrics[`Final`].(Score)
+	trace := &Trace{ Fen: p.fen(), Phase: phase, Final: final, Blended: final.blended(phase), Score: score }
+
+	for _, tag := range traceTerms {
+		term := TraceTerm{ Name: tag }
+		if tag[0] == '+' || tag[0] == '-' {
+			term.Name = tag[1:]
+		}
+		switch metric := metrics[tag].(type) {
+		case Score:
+			term.Total = metric
+		case Total:
+			white, black := metric.white, metric.black
+			term.White, term.Black, term.Total = &white, &black, white.minus(black)
+		default: // Not traced, ex. in known endgames.
+			continue
+		}
+		term.Blended = term.Total.blended(phase)
+		trace.Terms = append(trace.Terms, term)
+	}
+
+	if traced != nil {
+		for board := p.board; board.any(); {
+			square := board.pop()
+			piece := p.pieces[square]
+			row, col := coordinate(square)
+			var mobility *Score
+			if traced.mobile.on(square) {
+				mobility = &traced.mobility[square]
+			}
+			trace.Squares = append(trace.Squares, TraceSquare{
+				Square:   fmt.Sprintf(`%c%d`, col + 'a', row + 1),
+				Piece:    piece.String(),
+				PST:      p.worker.profile.pst[piece][square],
+				Mobility: mobility,
+				Fort:     traced.fort[square],
+			})
+		}
+	}
+
+	return trace
+}
+
+// Returns the trace encoded as indented JSON with the scores kept on one line.
+func (t *Trace) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(t, ``, `  `)
+	if err != nil {
+		return nil, err
+	}
+
+	return rePair.ReplaceAll(data, []byte(`[$1, $2]`)), nil
+}
+
+// Scores are encoded as [midgame, endgame] pairs just like in evaluation
+// profiles.
+func (s Score) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{ s.midgame, s.endgame })
+}
+
+func (s *Score) UnmarshalJSON(data []byte) error {
+	pair := [2]int{}
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	s.midgame, s.endgame = pair[0], pair[1]
+
+	return nil
+}
+
+// Returns piece's mobility bonus capturing it when tracing squares.
+func (e *Evaluation) mobility(square int, bonus Score) Score {
+	if e.squares != nil {
+		e.squares.mobility[square] = bonus
+		e.squares.mobile.set(square)
+	}
+
+	return bonus
+}
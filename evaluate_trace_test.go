@@ -0,0 +1,90 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `encoding/json`; `testing`)
+
+// Trace terms add up the same way as in evaluation summary and survive JSON
+// round trip.
+func TestTrace000(t *testing.T) {
+	p := NewGame(`r1bq1rk1/pp2bppp/2n1pn2/3p4/2PP4/2N1PN2/PP3PPP/R2QKB1R w KQ - 3 9`).start()
+	trace := p.Trace(false)
+	expect.Eq(t, trace.Fen, p.fen())
+	expect.Eq(t, trace.Score, p.Evaluate())
+	expect.Eq(t, trace.Blended, trace.Score)
+	expect.Eq(t, len(trace.Squares), 0)
+
+	names := []string{}
+	for _, term := range trace.Terms {
+		names = append(names, term.Name)
+		if term.White != nil {
+			expect.Eq(t, term.Total, term.White.minus(*term.Black))
+		}
+		expect.Eq(t, term.Blended, term.Total.blended(trace.Phase))
+	}
+	expect.Eq(t, names, []string{ `PST`, `Imbalance`, `Tempo`, `Center`, `Threats`, `Pawns`, `Passers`, `Mobility`,
+		`Pieces`, `Knights`, `Bishops`, `Rooks`, `Queens`, `King`, `Cover`, `Safety` })
+	expect.Eq(t, trace.Terms[0].Total, p.tally)
+	expect.True(t, trace.Terms[0].White == nil)
+
+	data, err := trace.JSON()
+	expect.Eq(t, err, error(nil))
+	expect.Contain(t, string(data), `"name": "Mobility",`)
+	expect.Contain(t, string(data), `"phase": `)
+
+	var decoded Trace
+	expect.Eq(t, json.Unmarshal(data, &decoded), error(nil))
+	expect.Eq(t, *decoded.Terms[7].Black, *trace.Terms[7].Black)
+	encoded, _ := decoded.JSON()
+	expect.Eq(t, string(encoded), string(data))
+}
+
+// Per-square contributions add up to PST and mobility terms.
+func TestTrace010(t *testing.T) {
+	p := NewGame(`r1bq1rk1/pppp1ppp/2n2n2/4p2Q/2B1P3/5N2/PPPP1PPP/RNB1K2R w KQ - 0 5`).start()
+	trace := p.Trace(true)
+	expect.Eq(t, len(trace.Squares), p.board.count())
+
+	var tally Score
+	var mobility Total
+	fort := 0
+	for _, square := range trace.Squares {
+		tally.add(square.PST)
+		if square.Mobility != nil {
+			if square.Piece[0] >= 'a' {
+				mobility.black.add(*square.Mobility)
+			} else {
+				mobility.white.add(*square.Mobility)
+			}
+		}
+		if square.Piece == `Q` || square.Piece == `B` {
+			fort += square.Fort
+		}
+	}
+	expect.Eq(t, tally, p.tally)
+	expect.Eq(t, mobility.white, *trace.Terms[7].White)
+	expect.Eq(t, mobility.black, *trace.Terms[7].Black)
+	expect.True(t, fort > 0)
+
+	data, _ := trace.JSON()
+	expect.Contain(t, string(data), `"square": "h5",`)
+	expect.Contain(t, string(data), `"piece": "Q",`)
+}
+
+// Known endgames are traced without the terms they skip.
+func TestTrace020(t *testing.T) {
+	p := NewGame(`Ke1,Rh1`, `M,Ke8`).start()
+	trace := p.Trace(true)
+	expect.Eq(t, trace.Score, p.Evaluate())
+	expect.Eq(t, len(trace.Terms), 3)
+	expect.Eq(t, trace.Terms[2].Name, `Tempo`)
+	expect.Eq(t, len(trace.Squares), 3)
+}
+
+// Square tracing hooks cost nothing when the squares are not traced.
+func TestTrace030(t *testing.T) {
+	p := NewGame(`r1bq1rk1/pp2bppp/2n1pn2/3p4/2PP4/2N1PN2/PP3PPP/R2QKB1R w KQ - 3 9`).start()
+	expect.Eq(t, testing.AllocsPerRun(100, func() { p.Evaluate() }), 0.0)
+}
@@ -5,12 +5,15 @@
 package donna
 
 import (
+	`context`
 	`fmt`
 	`strings`
+	`sync`
 	`time`
 )
 
 type RootPv struct {
+	score int
 	size  int
 	moves [MaxPly]Move
 }
@@ -19,32 +22,48 @@ type History [14][64]int
 type Killers [MaxPly][2]Move
 
 type Game struct {
-	nodes       int 	// Number of regular nodes searched.
-	qnodes      int 	// Number of quiescence nodes searched.
 	token       uint8 	// Cache's expiration token.
 	deepening   bool 	// True when searching first root move.
 	improving   bool 	// True when root search score is not falling.
 	volatility  float32 	// Root search stability count.
+	seed        uint64 	// Random seed for strength handicap.
+	draw        int 	// Draw score for the side to move at the root.
 	initial     string   	// Initial position (FEN or algebraic).
-	history     History  	// Good moves history.
-	killers     Killers  	// Killer moves.
 	rootpv      RootPv 	// Principal variation for root moves.
-	pv          Pv  	// Principal variations for each ply.
+	lines       []RootPv 	// Best root moves' variations in MultiPV mode.
 	cache       Cache 	// Transposition table.
-	pawnCache   PawnCache 	// Cache of pawn structures.
+	castling    *Castling 	// Castle setup, standard or Chess960.
+	workers     []*Worker 	// Search workers; the main one goes first.
+	engine      *Engine 	// The engine that plays the game.
 }
 
-// Use single statically allocated variable.
-var game Game
-
 // We have two ways to initialize the game: 1) pass FEN string, and 2) specify
 // white and black pieces using regular chess notation.
 //
 // In latter case we need to tell who gets to move first when starting the game.
 // The second option is a bit less pricise (ex. no en-passant square) but it is
 // much more useful when writing tests from memory.
-func NewGame(args ...string) *Game {
-	game = Game{ cache: NewCache(engine.cacheSize), pawnCache: PawnCache{} }
+//
+// The new game becomes engine's current game and takes over the cache of the
+// previous one, if any. The cache gets cleared unless it has just been loaded
+// from file.
+func (e *Engine) NewGame(args ...string) *Game {
+	var cache Cache
+	if e.game != nil {
+		cache = e.game.cache
+	}
+
+	game := &Game{ engine: e, castling: standardCastling }
+	if e.cacheLoaded {
+		game.cache, game.token = cache, e.game.token
+	} else {
+		game.cache = NewCache(e.cacheSize, cache)
+	}
+	e.cacheLoaded = false
+	game.workers = make([]*Worker, max(1, e.threads))
+	for i := range game.workers {
+		game.workers[i] = NewWorker(game, i)
+	}
 
 	switch len(args) {
 	case 0: // Initial position.
@@ -55,12 +74,19 @@ func NewGame(args ...string) *Game {
 		game.initial = args[0] + ` : ` + args[1]
 	}
 
-	return &game
+	e.game = game
+	return game
+}
+
+// Shortcut to start new game using the engine with default settings.
+func NewGame(args ...string) *Game {
+	return NewEngine().NewGame(args...)
 }
 
 func (game *Game) start() *Position {
-	engine.clock.halt = false
-	tree, node, rootNode = [1024]Position{}, 0, 0
+	game.engine.clock.halt.Store(false)
+	main := game.workers[0]
+	main.tree, main.past, main.node, main.rootNode = [len(main.tree)]Position{}, nil, 0, 0
 
 	// Was the game started with FEN or algebraic notation?
 	sides := strings.Split(game.initial, ` : `)
@@ -71,7 +97,17 @@ func (game *Game) start() *Position {
 }
 
 func (game *Game) position() *Position {
-	return &tree[node]
+	return game.workers[0].position()
+}
+
+// Returns the number of regular and quiescence nodes searched by all workers.
+func (game *Game) nodes() (nodes, qnodes int) {
+	for _, worker := range game.workers {
+		nodes += int(worker.nodes.Load())
+		qnodes += int(worker.qnodes.Load())
+	}
+
+	return nodes, qnodes
 }
 
 // Resets principal variation as well as killer moves and move history. Cache
@@ -79,54 +115,120 @@ func (game *Game) position() *Position {
 // current tree node to match the position.
 func (game *Game) getReady() *Game {
 	game.rootpv = RootPv{}
-	game.pv = Pv{}
-	game.killers = Killers{}
-	game.history = History{}
+	game.lines = nil
 	game.deepening = false
 	game.improving = true
 	game.volatility = 0.0
 	game.token++ // <-- Wraps around: ...254, 255, 0, 1...
+	game.draw = game.contempt()
 
-	rootNode = node
+	game.workers[0].getReady()
 	return game
 }
 
-// Copies the very latest top principal variation line.
-func updateRootPv() {
-	if game.pv[0].size > 0 {
-		copy(game.rootpv.moves[0:], game.pv[0].moves[0:])
-		game.rootpv.size = game.pv[0].size
+// Returns the draw score from the point of view of the side the engine is
+// playing. Positive contempt makes the engine avoid draws, negative one makes
+// it seek them. Dynamic contempt fades away as the material comes off the
+// board.
+func (game *Game) contempt() int {
+	engine := game.engine
+	contempt := engine.contempt * onePawn / 100
+	if engine.dynamic {
+		contempt = contempt * min(game.position().material().phase, 256) / 256
+	}
+
+	return -contempt
+}
+
+// Copies the very latest top principal variation line found by the main worker.
+func (game *Game) updateRootPv() {
+	if pv := &game.workers[0].pv[0]; pv.size > 0 {
+		copy(game.rootpv.moves[0:], pv.moves[0:])
+		game.rootpv.size = pv.size
 	}
 }
 
+// Starts helper workers that search the same root position along with the
+// main worker until the main worker is done.
+func (game *Game) startHelpers() *sync.WaitGroup {
+	done := &sync.WaitGroup{}
+
+	// Helpers would only make handicapped engine stronger.
+	if game.engine.limited {
+		return done
+	}
+
+	for _, worker := range game.workers[1:] {
+		done.Add(1)
+		go worker.follow(game.workers[0]).think(done)
+	}
+
+	return done
+}
+
+// Tells the helpers to quit and waits until all of them are done.
+func (game *Game) stopHelpers(done *sync.WaitGroup) *Game {
+	if len(game.workers) > 1 && !game.engine.limited {
+		game.engine.clock.halt.Store(true)
+		done.Wait()
+	}
+
+	return game
+}
+
 // "The question of whether machines can think is about as relevant as the
 // question of whether submarines can swim." -- Edsger W. Dijkstra
 func (game *Game) Think() Move {
+	game.engine.clock.halt.Store(false)
+	return game.think(context.Background()).Move
+}
+
+// Runs iterative deepening search within current engine limits until it's time
+// to make a move or the context gets cancelled. The caller is expected to reset
+// the halt flag so that the search could be stopped before it gets going.
+func (game *Game) think(ctx context.Context) (result Result) {
+	engine := game.engine
 	start := time.Now()
 	position := game.position()
-	game.nodes, game.qnodes = 0, 0
+	game.workers[0].nodes.Store(0); game.workers[0].qnodes.Store(0)
 
-	if len(engine.bookFile) != 0 {
+	if len(engine.bookFile) != 0 && engine.options.mate == 0 && len(engine.options.searchMoves) == 0 {
 		if book, err := NewBook(engine.bookFile); err == nil {
 			if move := book.pickMove(position); move != 0 {
+				engine.waitToReply()
 				game.printBestMove(move, since(start))
-				return move
+				return game.result(move, 0, 0, since(start))
 			}
-		} else if !engine.uci {
+		} else if engine.repl {
 			fmt.Printf("Book error: %v\n", err)
 		}
 	}
 
-	game.getReady()
+	game.getReady().shuffle()
 	score, move, status, alpha, beta := 0, Move(0), InProgress, -Checkmate, Checkmate
 
-	if !engine.uci {
+	if engine.repl && engine.options.mate == 0 {
 		fmt.Println(`Depth   Time     Nodes    QNodes   Nodes/s    Score   Best`)
 	}
 
 	if !engine.fixedDepth() {
 		engine.startClock(); defer engine.stopClock();
 	}
+	if ctx.Done() != nil {
+		stop := make(chan bool)
+		defer close(stop)
+		go func() {
+			select {
+			case <-ctx.Done():
+				engine.clock.halt.Store(true)
+			case <-stop:
+			}
+		}()
+	}
+	if engine.options.mate > 0 {
+		return game.mate(ctx, engine.options.mate, start)
+	}
+	helpers := game.startHelpers()
 
 	for depth := 1; game.keepThinking(depth, status, move); depth++ {
 		// Save previous best score in case search gets interrupted.
@@ -141,7 +243,7 @@ func (game *Game) Think() Move {
 			score = position.search(alpha, beta, depth)
 			if score > alpha || depth == 1 {
 				bestScore = score
-				updateRootPv()
+				game.updateRootPv()
 			}
 		} else {
 			aspiration := onePawn / 3
@@ -155,10 +257,10 @@ func (game *Game) Think() Move {
 				score = position.search(alpha, beta, depth)
 				if score > alpha {
 					bestScore = score
-					updateRootPv()
+					game.updateRootPv()
 				}
 
-				if !engine.fixedDepth() && engine.clock.halt {
+				if engine.clock.halt.Load() {
 					break
 				}
 
@@ -175,35 +277,86 @@ func (game *Game) Think() Move {
 			}
 			// TBD: position.cache(game.rootpv[0], score, 0, 0)
 		}
-		if engine.clock.halt {
+		if engine.clock.halt.Load() {
 			score = bestScore
 		}
 
+		if engine.multiPV > 1 {
+			score = game.searchLines(depth, score)
+		}
+
 		move = game.rootpv.moves[0]
 		status = position.status(move, score)
 		game.printPrincipal(depth, score, status, since(start))
+		result.Depth = depth
 	}
 
-	game.printBestMove(move, since(start))
+	engine.waitToReply()
+	game.stopHelpers(helpers).printBestMove(move, since(start))
 
-	return move
+	return game.result(move, score, result.Depth, since(start))
+}
+
+// Searches the rest of MultiPV lines at given depth once the best line has been
+// found. Each line skips root moves of the lines found before it. The lines
+// are then sorted by score and the best one becomes root principal variation.
+func (game *Game) searchLines(depth, score int) int {
+	engine, main, position := game.engine, game.workers[0], game.position()
+	count := max(1, min(engine.multiPV, main.moveList[0].tail))
+	if len(game.lines) != count {
+		game.lines = make([]RootPv, count)
+	}
+	game.lines[0] = game.rootpv
+	game.lines[0].score = score
+
+	for main.multipv = 1; main.multipv < count && !engine.clock.halt.Load(); main.multipv++ {
+		score := position.search(-Checkmate, Checkmate, depth)
+		if engine.clock.halt.Load() {
+			break
+		}
+		line, pv := &game.lines[main.multipv], &main.pv[0]
+		line.score, line.size = score, pv.size
+		copy(line.moves[0:], pv.moves[0:pv.size])
+	}
+
+	// Drop the lines that have not been completed when the search got halted.
+	game.lines, main.multipv = game.lines[:main.multipv], 0
+
+	// Sort the lines by score along with matching root moves so that next
+	// iteration starts off the best line.
+	gen := &main.moveList[0]
+	for i := 1; i < len(game.lines); i++ {
+		for j := i; j > 0 && game.lines[j].score > game.lines[j - 1].score; j-- {
+			game.lines[j], game.lines[j - 1] = game.lines[j - 1], game.lines[j]
+			gen.list[j], gen.list[j - 1] = gen.list[j - 1], gen.list[j]
+		}
+	}
+	game.rootpv = game.lines[0]
+
+	return game.rootpv.score
 }
 
 // When in doubt, do what the President does ―- guess.
 func (game *Game) keepThinking(depth, status int, move Move) bool {
+	engine := game.engine
 	if depth == 1 || depth > MaxDepth || status != InProgress {
 		return depth == 1
 	}
 
-	if engine.fixedDepth() {
-		return depth <= engine.options.maxDepth
-	} else if engine.clock.halt {
+	if engine.clock.halt.Load() {
+		return false
+	} else if strength, ok := engine.strength(); ok && depth > strength.depth {
 		return false
+	} else if engine.fixedDepth() {
+		return depth <= engine.options.maxDepth
+	} else if engine.options.infinite || engine.options.ponder || engine.options.maxNodes > 0 {
+		return true
 	}
 
-	// Stop deepening if it's the only move.
-	gen := NewRootGen(nil, depth)
-	if gen.onlyMove() {
+	// Stop deepening if it's the only move unless we've been asked to
+	// analyze it.
+	gen := NewRootGen(game.position(), depth)
+	if gen.onlyMove() && len(engine.options.searchMoves) == 0 {
 		//\\ engine.debug("# Depth %02d Only move %s\n", depth, move)
 		return false
 	}
@@ -224,9 +377,10 @@ func (game *Game) keepThinking(depth, status int, move Move) bool {
 }
 
 func (game *Game) printBestMove(move Move, duration int64) {
+	engine := game.engine
 	if engine.uci {
 		engine.uciBestMove(move, duration)
-	} else {
+	} else if engine.repl {
 		engine.replBestMove(move)
 	}
 }
@@ -235,9 +389,10 @@ func (game *Game) printBestMove(move Move, duration int64) {
 // and advantage black is -score whereas in UCI +score is advantage current side
 // and -score is advantage opponent.
 func (game *Game) printPrincipal(depth, score, status int, duration int64) {
+	engine := game.engine
 	if engine.uci {
 		engine.uciPrincipal(depth, score, duration)
-	} else {
+	} else if engine.repl {
 		if game.position().color == Black {
 			score = -score
 		}
@@ -245,50 +400,6 @@ func (game *Game) printPrincipal(depth, score, status int, duration int64) {
 	}
 }
 
-func (game *Game) saveBest(ply int, move Move) *Game {
-	game.pv[ply].moves[ply] = move
-	game.pv[ply].size = ply + 1
-
-	next := game.pv[ply].size
-	if size := game.pv[next].size; next < MaxPly && size > next {
-		copy(game.pv[ply].moves[next:], game.pv[next].moves[next:size])
-		game.pv[ply].size += size - next
-	}
-
-	return game
-}
-
-func (game *Game) saveGood(depth int, move Move) *Game {
-	if move.isQuiet() {
-		if ply := ply(); move != game.killers[ply][0] {
-			game.killers[ply][1] = game.killers[ply][0]
-			game.killers[ply][0] = move
-		}
-		game.history[move.piece()][move.to()] += depth * depth
-	}
-
-	return game
-}
-
-
-func (game *Game) updatePoor(depth int, bestMove Move, mgen *MoveGen) *Game {
-	value := depth * depth
-
-	for move := mgen.NextMove(); move != 0; move = mgen.NextMove() {
-		if move.isQuiet() {
-			game.history[move.piece()][move.to()] = let(move == bestMove, value, -value)
-		}
-	}
-
-	return game
-}
-
-// Checks whether the move is among good moves captured so far and returns its
-// history value.
-func (game *Game) good(move Move) int {
-	return game.history[move.piece()][move.to()]
-}
-
 func (game *Game) String() string {
 	return game.position().String()
 }
@@ -16,30 +16,32 @@ type MoveGen struct {
 	head	int
 	tail	int
 	pins	Bitmask
+	stage	int	// Next stage of staged move generation.
+	picks	[4]Move	// Cached move, killers, and counter move, if any.
+	bad	[128]Move	// Postponed bad captures and underpromotions.
+	bads	int	// Number of postponed moves.
 }
 
-// Pre-allocate move generator array (one entry per ply) to avoid garbage
-// collection overhead. Last entry serves for utility move generation, ex. when
-// converting string notations or determining a stalemate.
-var moveList [MaxPly+1]MoveGen
-
-// Returns "new" move generator for the given ply. Since move generator array
-// has been pre-allocated already we simply return a pointer to the existing
-// array element re-initializing all its data.
+// Returns "new" move generator for the given ply. Move generator array is
+// pre-allocated by the position's worker (one entry per ply) to avoid garbage
+// collection overhead, so we simply return a pointer to the existing array
+// element re-initializing all its data. Last entry serves for utility move
+// generation, ex. when converting string notations or determining a stalemate.
 func NewGen(p *Position, ply int) (gen *MoveGen) {
-	gen = &moveList[ply]
+	gen = &p.worker.moveList[ply]
 	gen.p = p
 	gen.list = [128]MoveWithScore{}
 	gen.ply = ply
 	gen.head, gen.tail = 0, 0
 	gen.pins = p.pins(p.king[p.color])
+	gen.stage = stageDone
 
 	return gen
 }
 
 // Convenience method to return move generator for the current ply.
 func NewMoveGen(p *Position) *MoveGen {
-	return NewGen(p, ply())
+	return NewGen(p, p.ply())
 }
 
 // Returns new move generator for the initial step of iterative deepening
@@ -49,7 +51,7 @@ func NewRootGen(p *Position, depth int) *MoveGen {
 		return NewGen(p, 0) // Zero ply.
 	}
 
-	return &moveList[0]
+	return &p.worker.moveList[0]
 }
 
 func (gen *MoveGen) reset() *MoveGen {
@@ -67,6 +69,9 @@ func (gen *MoveGen) onlyMove() bool {
 }
 
 func (gen *MoveGen) NextMove() (move Move) {
+	if gen.stage != stageDone {
+		return gen.nextStagedMove()
+	}
 	if gen.head < gen.tail {
 		move = gen.list[gen.head].move
 		gen.head++
@@ -137,15 +142,16 @@ func (gen *MoveGen) sort() *MoveGen {
 	count := total
 	pocket := MoveWithScore{}
 	ever := true
+	list := gen.list[gen.head:gen.tail]
 
 	for (ever) {
 		count = (count + 1) / 2
 		ever = count > 1
 		for i := 0; i < total - count; i++ {
-			if this := gen.list[i + count]; this.score > gen.list[i].score {
+			if this := list[i + count]; this.score > list[i].score {
 				pocket = this
-				gen.list[i + count] = gen.list[i]
-				gen.list[i] = pocket
+				list[i + count] = list[i]
+				list[i] = pocket
 				ever = true
 			}
 		}
@@ -164,13 +170,13 @@ func (gen *MoveGen) rank(bestMove Move) *MoveGen {
 		if move == bestMove {
 			gen.list[i].score = 0xFFFF
 		} else if !move.isQuiet() || move.isEnpassant() {
-			gen.list[i].score = 8192 + move.value()
-		} else if move == game.killers[gen.ply][0] {
+			gen.list[i].score = 8192 + move.value(gen.p.worker.profile)
+		} else if move == gen.p.worker.killers[gen.ply][0] {
 			gen.list[i].score = 4096
-		} else if move == game.killers[gen.ply][1] {
+		} else if move == gen.p.worker.killers[gen.ply][1] {
 			gen.list[i].score = 2048
 		} else {
-			gen.list[i].score = game.good(move)
+			gen.list[i].score = gen.p.worker.good(move)
 		}
 	}
 
@@ -184,9 +190,9 @@ func (gen *MoveGen) quickRank() *MoveGen {
 
 	for i := gen.head; i < gen.tail; i++ {
 		if move := gen.list[i].move; !move.isQuiet() || move.isEnpassant() {
-			gen.list[i].score = 8192 + move.value()
+			gen.list[i].score = 8192 + move.value(gen.p.worker.profile)
 		} else {
-			gen.list[i].score = game.good(move)
+			gen.list[i].score = gen.p.worker.good(move)
 		}
 	}
 
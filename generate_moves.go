@@ -4,11 +4,37 @@
 
 package donna
 
+// Generates valid root moves. The moves requested by "go searchmoves" get
+// picked out of valid ones so that an illegal request never gets played.
 func (gen *MoveGen) generateRootMoves() *MoveGen {
-	gen.generateAllMoves()
+	gen.generateAllMoves().validOnly().restrict(gen.p.engine().options.searchMoves)
 
 	if !gen.onlyMove() {
-		gen.validOnly().rank(Move(0))
+		gen.rank(Move(0))
+	}
+
+	return gen
+}
+
+// Leaves the moves requested by "go searchmoves" only. The restriction is
+// ignored if none of the requested moves could be found.
+func (gen *MoveGen) restrict(moves []Move) *MoveGen {
+	if len(moves) == 0 {
+		return gen
+	}
+
+	tail := 0
+	for i := 0; i < gen.tail; i++ {
+		for _, move := range moves {
+			if gen.list[i].move == move {
+				gen.list[tail] = gen.list[i]
+				tail++
+				break
+			}
+		}
+	}
+	if tail > 0 {
+		gen.tail = tail
 	}
 
 	return gen
@@ -17,10 +43,16 @@ func (gen *MoveGen) generateRootMoves() *MoveGen {
 // Copies last move returned by NextMove() to the top of the list shifting
 // remaining moves down. Head/tail pointers remain unchanged.
 func (gen *MoveGen) rearrangeRootMoves() *MoveGen {
-	if gen.head > 0 {
+	return gen.rearrangeRootMovesFrom(0)
+}
+
+// Same as above but leaves first N moves in place, i.e. the moves that start
+// MultiPV lines found so far.
+func (gen *MoveGen) rearrangeRootMovesFrom(first int) *MoveGen {
+	if gen.head > first {
 		best := gen.list[gen.head - 1]
-		copy(gen.list[1:], gen.list[0:gen.head - 1])
-		gen.list[0] = best
+		copy(gen.list[first + 1:], gen.list[first:gen.head - 1])
+		gen.list[first] = best
 	}
 
 	return gen
@@ -70,10 +102,10 @@ func (gen *MoveGen) kingMoves(color uint8) *MoveGen {
 
 		kingside, queenside := gen.p.canCastle(color)
 		if kingside {
-			gen.moveKing(square, bit[G1 + 56 * color])
+			gen.add(NewCastle(gen.p, square, G1 + 56 * int(color)))
 		}
 		if queenside {
-			gen.moveKing(square, bit[C1 + 56 * color])
+			gen.add(NewCastle(gen.p, square, C1 + 56 * int(color)))
 		}
 	}
 
@@ -96,12 +128,7 @@ func (gen *MoveGen) movePawn(square int, targets Bitmask) *MoveGen {
 
 func (gen *MoveGen) moveKing(square int, targets Bitmask) *MoveGen {
 	for targets.any() {
-		target := targets.pop()
-		if abs(square - target) == 2 {
-			gen.add(NewCastle(gen.p, square, target))
-		} else {
-			gen.add(NewMove(gen.p, square, target))
-		}
+		gen.add(NewMove(gen.p, square, targets.pop()))
 	}
 
 	return gen
@@ -4,7 +4,7 @@
 
 package donna
 
-import(`github.com/michaeldv/donna/expect`; `testing`)
+import(`github.com/michaeldv/donna/expect`; `context`; `testing`)
 
 func TestGenerateMoves000(t *testing.T) {
 	gen := NewMoveGen(NewGame().start()).generateMoves()
@@ -152,3 +152,18 @@ func TestGenerateMoves300(t *testing.T) {
 	gen.rearrangeRootMoves().reset()
 	expect.Eq(t, gen.allMoves(), `[Ng1-h3 e2-e4 a2-a3 a2-a4 b2-b3 b2-b4 c2-c3 c2-c4 d2-d3 d2-d4 e2-e3 f2-f3 f2-f4 g2-g3 g2-g4 h2-h3 h2-h4 Nb1-a3 Nb1-c3 Ng1-f3]`)
 }
+
+// Illegal root move restriction gets ignored: the pinned bishop can't move.
+func TestGenerateMoves310(t *testing.T) {
+	game := NewGame(`Ke1,Bd2,a2`, `Kh8,Ba5,h7`)
+	p := game.start()
+	be3 := NewMoveFromNotation(p, `d2e3`)
+	game.engine.options.searchMoves = []Move{ be3 }
+
+	gen := NewRootGen(p, 1).generateRootMoves()
+	expect.Eq(t, gen.allMoves(), `[Bd2xa5 a2-a3 Bd2-c3 Bd2-b4 a2-a4 Ke1-d1 Ke1-f1 Ke1-e2 Ke1-f2]`)
+
+	game.Start()
+	result, _ := game.Search(context.Background(), Limits{ Depth: 3, SearchMoves: []Move{ be3 } })
+	expect.Ne(t, result.Move, be3)
+}
@@ -0,0 +1,250 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+// Staged move generation for the main search. The moves are generated one
+// stage at a time and only when the previous stage has run out of moves, so
+// that the cutoff caused by the cached move or a good capture saves the work
+// of generating and sorting the quiet moves.
+const (
+	stageCached = iota	// Move from the transposition table.
+	stageCaptures		// Captures and queen promotions that don't lose material.
+	stageKillers		// Killer moves.
+	stageCounter		// Counter move to the opponent's last move.
+	stageQuiets		// Remaining quiet moves ordered by history.
+	stageBadCaptures	// Captures losing material and underpromotions.
+	stageDone		// No more moves; non-staged generators start here.
+)
+
+// Sets up staged move generation starting with the cached move. The king
+// must not be in check: check evasions are generated all at once.
+func (gen *MoveGen) generateStaged(cachedMove Move) *MoveGen {
+	gen.stage, gen.bads = stageCached, 0
+	gen.picks = [4]Move{}
+
+	if gen.p.isPossible(cachedMove) {
+		gen.pick(cachedMove)
+	}
+
+	return gen
+}
+
+// Returns next move of the current stage moving on to the next stage when
+// the current one is over. Captures that lose material by static exchange
+// evaluation get postponed till the very end.
+func (gen *MoveGen) nextStagedMove() Move {
+	for {
+		if gen.head < gen.tail {
+			move := gen.list[gen.head].move
+			gen.head++
+			if gen.stage == stageCaptures && move.isCapture() && gen.p.exchange(move) < 0 {
+				gen.postpone(move).remove()
+				continue
+			}
+			return move
+		}
+
+		if gen.stage == stageDone || gen.stage == stageBadCaptures {
+			gen.stage = stageDone
+			return Move(0)
+		}
+		gen.stage++
+		gen.generateStage()
+	}
+}
+
+// Generates the moves of the current stage.
+func (gen *MoveGen) generateStage() *MoveGen {
+	p, worker := gen.p, gen.p.worker
+
+	switch gen.stage {
+	case stageCaptures:
+		gen.stagedCaptures(p.color)
+		for i := gen.head; i < gen.tail; i++ {
+			gen.list[i].score = gen.list[i].move.value(gen.p.worker.profile)
+		}
+		gen.sort()
+	case stageKillers:
+		for _, move := range worker.killers[gen.ply] {
+			if move.isQuiet() && !gen.picked(move) && p.isPossible(move) {
+				gen.pick(move)
+			}
+		}
+	case stageCounter:
+		if last := p.last; !last.nil() {
+			if move := worker.counters[last.piece()][last.to()]; move.isQuiet() && !gen.picked(move) && p.isPossible(move) {
+				gen.pick(move)
+			}
+		}
+	case stageQuiets:
+		gen.stagedQuiets(p.color)
+		for i := gen.head; i < gen.tail; i++ {
+			gen.list[i].score = worker.good(gen.list[i].move)
+		}
+		gen.sort()
+	case stageBadCaptures:
+		for i := 0; i < gen.bads; i++ {
+			gen.add(gen.bad[i])
+		}
+	}
+
+	return gen
+}
+
+// Adds the move of the cached, killer, or counter move stage remembering it
+// so that it doesn't get generated again.
+func (gen *MoveGen) pick(move Move) *MoveGen {
+	for i := range gen.picks {
+		if gen.picks[i].nil() {
+			gen.picks[i] = move
+			break
+		}
+	}
+
+	return gen.add(move)
+}
+
+// Returns true if the move has already been picked up by the cached, killer,
+// or counter move stage.
+func (gen *MoveGen) picked(move Move) bool {
+	return move == gen.picks[0] || move == gen.picks[1] || move == gen.picks[2] || move == gen.picks[3]
+}
+
+// Saves the move for the bad captures stage.
+func (gen *MoveGen) postpone(move Move) *MoveGen {
+	gen.bad[gen.bads] = move
+	gen.bads++
+
+	return gen
+}
+
+// Adds the move to the list unless it's been picked up already.
+func (gen *MoveGen) addNew(move Move) *MoveGen {
+	if !gen.picked(move) {
+		gen.add(move)
+	}
+
+	return gen
+}
+
+// Generates captures, including en-passant, and promotions. Underpromotions
+// are postponed till the bad captures stage.
+func (gen *MoveGen) stagedCaptures(color uint8) *MoveGen {
+	p := gen.p
+	enemy := p.outposts[color^1]
+
+	pawns := p.outposts[pawn(color)]
+	for pawns.any() {
+		square := pawns.pop()
+		targets := p.targets(square)
+		if rank(color, square) != A7H7 {
+			targets &= enemy
+			if p.enpassant != 0 {
+				targets |= p.targets(square) & bit[p.enpassant]
+			}
+			for targets.any() {
+				gen.addNew(NewMove(p, square, targets.pop()))
+			}
+		} else {
+			for targets.any() {
+				mQ, mR, mB, mN := NewPromotion(p, square, targets.pop())
+				gen.addNew(mQ)
+				for _, move := range []Move{ mR, mB, mN } {
+					if !gen.picked(move) {
+						gen.postpone(move)
+					}
+				}
+			}
+		}
+	}
+
+	outposts := p.outposts[color] ^ p.outposts[pawn(color)]
+	for outposts.any() {
+		square := outposts.pop()
+		targets := p.targets(square) & enemy
+		for targets.any() {
+			gen.addNew(NewMove(p, square, targets.pop()))
+		}
+	}
+
+	return gen
+}
+
+// Generates quiet moves, i.e. the moves that are neither captures nor
+// promotions, skipping the ones picked up by the previous stages.
+func (gen *MoveGen) stagedQuiets(color uint8) *MoveGen {
+	p := gen.p
+	empty := ^p.board
+
+	pawns := p.outposts[pawn(color)]
+	for pawns.any() {
+		if square := pawns.pop(); rank(color, square) != A7H7 {
+			targets := p.targets(square) & empty
+			if p.enpassant != 0 {
+				targets &= ^bit[p.enpassant]
+			}
+			for targets.any() {
+				gen.addNew(NewPawnMove(p, square, targets.pop()))
+			}
+		}
+	}
+
+	outposts := p.outposts[color] ^ p.outposts[pawn(color)] ^ p.outposts[king(color)]
+	for outposts.any() {
+		square := outposts.pop()
+		targets := p.targets(square) & empty
+		for targets.any() {
+			gen.addNew(NewMove(p, square, targets.pop()))
+		}
+	}
+
+	square := int(p.king[color])
+	targets := p.targets(square) & empty
+	for targets.any() {
+		gen.addNew(NewMove(p, square, targets.pop()))
+	}
+	kingside, queenside := p.canCastle(color)
+	if kingside {
+		gen.addNew(NewCastle(p, square, G1 + 56 * int(color)))
+	}
+	if queenside {
+		gen.addNew(NewCastle(p, square, C1 + 56 * int(color)))
+	}
+
+	return gen
+}
+
+// Returns true if the move could have been generated in the position, ex.
+// when the move comes from the transposition table or killer moves. Just
+// like generated moves it still has to pass isValid() check.
+func (p *Position) isPossible(move Move) bool {
+	from, to, piece, _ := move.split()
+	if move.nil() || piece.nil() || piece.color() != p.color || p.pieces[from] != piece {
+		return false
+	}
+
+	if move.isCastle() {
+		kingside, queenside := p.canCastle(p.color)
+		if (kingside && to == G1 + 56 * int(p.color)) || (queenside && to == C1 + 56 * int(p.color)) {
+			return move == NewCastle(p, from, to)
+		}
+		return false
+	}
+
+	if !p.targets(from).on(to) {
+		return false
+	}
+	if !piece.isPawn() {
+		return move == NewMove(p, from, to)
+	}
+	if rank(p.color, from) != A7H7 {
+		return move == NewPawnMove(p, from, to)
+	}
+	if promo := move.promo(); promo.nil() || promo.color() != p.color || promo.isPawn() || promo.isKing() {
+		return false
+	}
+
+	return move == NewMove(p, from, to).promote(move.promo().kind())
+}
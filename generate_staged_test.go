@@ -0,0 +1,71 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `sort`; `testing`)
+
+// Returns sorted list of moves as generated by staged and regular generators.
+func stagedAndRegular(p *Position, cachedMove Move) (staged, regular []string) {
+	for _, move := range NewMoveGen(p).generateStaged(cachedMove).allMoves() {
+		staged = append(staged, move.String())
+	}
+	for _, move := range NewMoveGen(p).generateMoves().allMoves() {
+		regular = append(regular, move.String())
+	}
+	sort.Strings(staged)
+	sort.Strings(regular)
+
+	return staged, regular
+}
+
+// Staged generation starts with the cached move followed by good captures,
+// killers, counter move, quiet moves, and bad captures.
+func TestGenerateStaged000(t *testing.T) {
+	p := NewGame(`Kg1,Qd1,Nc3,Bf1,b7,g2`, `Kg8,Rd5,Nd6,e6,f7,g7`).start()
+	p.worker.killers[0] = [2]Move{ NewMove(p, F1, C4), NewMove(p, G2, G3) }
+	p.last, p.worker.counters[BlackKing][H8] = NewMove(p, G8, H8), NewMove(p, G1, H1)
+	gen := NewMoveGen(p).generateStaged(NewMove(p, C3, E4))
+
+	expect.Eq(t, gen.allMoves(), `[Nc3-e4 b7-b8Q Nc3xd5 Bf1-c4 g2-g3 Kg1-h1 g2-g4 Qd1-a1 Qd1-b1 Qd1-c1 Qd1-e1 Qd1-c2 Qd1-d2 Qd1-e2 Qd1-b3 Qd1-d3 Qd1-f3 Qd1-a4 Qd1-d4 Qd1-g4 Qd1-h5 Bf1-e2 Bf1-d3 Bf1-b5 Bf1-a6 Nc3-b1 Nc3-a2 Nc3-e2 Nc3-a4 Nc3-b5 Kg1-f2 Kg1-h2 b7-b8R b7-b8B b7-b8N Qd1xd5]`)
+}
+
+// Staged generation produces the same moves as the regular one.
+func TestGenerateStaged010(t *testing.T) {
+	for _, fen := range []string{
+		`rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1`,
+		`r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1`,
+		`r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 b kq - 0 1`,
+		`rnbqkb1r/ppp1pppp/5n2/3pP3/8/8/PPPP1PPP/RNBQKBNR w KQkq d6 0 3`,
+		`8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1`,
+	} {
+		p := NewGame(fen).start()
+		staged, regular := stagedAndRegular(p, Move(0))
+		expect.Eq(t, staged, regular)
+
+		for _, move := range NewMoveGen(p).generateMoves().allMoves() {
+			p.worker.killers[0] = [2]Move{ move, move }
+			staged, regular = stagedAndRegular(p, move)
+			expect.Eq(t, staged, regular)
+		}
+	}
+}
+
+// Cached and killer moves must be possible in the position.
+func TestGenerateStaged020(t *testing.T) {
+	p := NewGame(`r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1`).start()
+	for _, move := range NewMoveGen(p).generateMoves().allMoves() {
+		expect.True(t, p.isPossible(move))
+	}
+
+	expect.False(t, p.isPossible(Move(0)))
+	expect.False(t, p.isPossible(NewMove(p, A7, A6)))			// Wrong color.
+	expect.False(t, p.isPossible(NewMove(p, A1, A3)))			// Blocked.
+	expect.False(t, p.isPossible(NewMove(p, B2, B4)))			// Jump over.
+	expect.False(t, p.isPossible(NewMove(p, A2, A4)))			// Missing en-passant flag.
+	expect.True(t, p.isPossible(NewPawnMove(p, A2, A4)))
+	expect.False(t, p.isPossible(NewMove(p, E5, D7) ^ Move(int(BlackPawn) << 20)))	// Wrong capture.
+	expect.False(t, p.isPossible(NewMove(p, D5, D6).promote(Queen)))	// Not a promotion.
+	expect.False(t, p.isPossible(NewCastle(p, E1, B1)))			// Wrong target.
+}
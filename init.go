@@ -64,7 +64,6 @@ var (
 func init() {
 	initMasks()
 	initArrays()
-	initPST()
 	initMaterial()
 }
 
@@ -172,6 +171,9 @@ func initArrays() {
 		}
 	}
 
+	// Standard castle setup.
+	standardCastling = newCastling().setup(White, E1, H1, A1).setup(Black, E8, H8, A8)
+
 	// Enpassant hash values.
 	for col := A1; col <= H1; col++ {
 		hashEnpassant[col] = polyglotRandomEnpassant[col]
@@ -189,27 +191,29 @@ func initArrays() {
 	}
 }
 
-func initPST() {
+// Builds piece/square table of the profile from piece values and bonuses.
+func (profile *Profile) initPST() {
+	pst := &profile.pst
 	for square := A1; square <= H8; square++ {
 
 		// White pieces: flip square index since bonus points have been
 		// set up from black's point of view.
 		flip := square ^ A8
-		pst[Pawn]  [square].add(Score{bonusPawn  [0][flip], bonusPawn  [1][flip]}).add(valuePawn)
-		pst[Knight][square].add(Score{bonusKnight[0][flip], bonusKnight[1][flip]}).add(valueKnight)
-		pst[Bishop][square].add(Score{bonusBishop[0][flip], bonusBishop[1][flip]}).add(valueBishop)
-		pst[Rook]  [square].add(Score{bonusRook  [0][flip], bonusRook  [1][flip]}).add(valueRook)
-		pst[Queen] [square].add(Score{bonusQueen [0][flip], bonusQueen [1][flip]}).add(valueQueen)
-		pst[King]  [square].add(Score{bonusKing  [0][flip], bonusKing  [1][flip]})
+		pst[Pawn]  [square].add(Score{profile.bonusPawn  [0][flip], profile.bonusPawn  [1][flip]}).add(profile.valuePawn)
+		pst[Knight][square].add(Score{profile.bonusKnight[0][flip], profile.bonusKnight[1][flip]}).add(profile.valueKnight)
+		pst[Bishop][square].add(Score{profile.bonusBishop[0][flip], profile.bonusBishop[1][flip]}).add(profile.valueBishop)
+		pst[Rook]  [square].add(Score{profile.bonusRook  [0][flip], profile.bonusRook  [1][flip]}).add(profile.valueRook)
+		pst[Queen] [square].add(Score{profile.bonusQueen [0][flip], profile.bonusQueen [1][flip]}).add(profile.valueQueen)
+		pst[King]  [square].add(Score{profile.bonusKing  [0][flip], profile.bonusKing  [1][flip]})
 
 		// Black pieces: use square index as is, and assign negative
 		// values so we could use white + black without extra condition.
-		pst[BlackPawn]  [square].sub(Score{bonusPawn  [0][square], bonusPawn  [1][square]}).sub(valuePawn)
-		pst[BlackKnight][square].sub(Score{bonusKnight[0][square], bonusKnight[1][square]}).sub(valueKnight)
-		pst[BlackBishop][square].sub(Score{bonusBishop[0][square], bonusBishop[1][square]}).sub(valueBishop)
-		pst[BlackRook]  [square].sub(Score{bonusRook  [0][square], bonusRook  [1][square]}).sub(valueRook)
-		pst[BlackQueen] [square].sub(Score{bonusQueen [0][square], bonusQueen [1][square]}).sub(valueQueen)
-		pst[BlackKing]  [square].sub(Score{bonusKing  [0][square], bonusKing  [1][square]})
+		pst[BlackPawn]  [square].sub(Score{profile.bonusPawn  [0][square], profile.bonusPawn  [1][square]}).sub(profile.valuePawn)
+		pst[BlackKnight][square].sub(Score{profile.bonusKnight[0][square], profile.bonusKnight[1][square]}).sub(profile.valueKnight)
+		pst[BlackBishop][square].sub(Score{profile.bonusBishop[0][square], profile.bonusBishop[1][square]}).sub(profile.valueBishop)
+		pst[BlackRook]  [square].sub(Score{profile.bonusRook  [0][square], profile.bonusRook  [1][square]}).sub(profile.valueRook)
+		pst[BlackQueen] [square].sub(Score{profile.bonusQueen [0][square], profile.bonusQueen [1][square]}).sub(profile.valueQueen)
+		pst[BlackKing]  [square].sub(Score{profile.bonusKing  [0][square], profile.bonusKing  [1][square]})
 	}
 }
 
@@ -237,23 +241,7 @@ func initMaterial() {
 			wP * materialBalance[Pawn]        +
 			bP * materialBalance[BlackPawn]
 
-		// Compute game phase and home turf values.
-		materialBase[index].phase = 12 * (wN + bN + wB + bB) + 18 * (wR + bR) + 44 * (wQ + bQ)
-		materialBase[index].turf = (wN + bN + wB + bB) * (wN + bN + wB + bB)
-
-		// Set up evaluation flags and endgame handlers.
-		materialBase[index].flags,
-		materialBase[index].endgame = endgames(wP, wN, wB, wR, wQ, bP, bN, bB, bR, bQ)
-
-		// Compute material imbalance scores.
-		if wQ != bQ || wR != bR || wB != bB || wN != bN || wP != bP {
-			white := imbalance(wB/2, wP, wN, wB, wR, wQ,  bB/2, bP, bN, bB, bR, bQ)
-			black := imbalance(bB/2, bP, bN, bB, bR, bQ,  wB/2, wP, wN, wB, wR, wQ)
-
-			adjustment := (white - black) / 32
-			materialBase[index].score.midgame += adjustment
-			materialBase[index].score.endgame += adjustment
-		}
+		materialBase[index] = newMaterial(wP, wN, wB, wR, wQ, bP, bN, bB, bR, bQ)
 										}
 									}
 								}
@@ -266,6 +254,29 @@ func initMaterial() {
 	}
 }
 
+// Creates material base entry for given number of pieces. Besides the table
+// itself it's used for the positions the table doesn't cover.
+func newMaterial(wP, wN, wB, wR, wQ, bP, bN, bB, bR, bQ int) (material MaterialEntry) {
+	// Compute game phase and home turf values.
+	material.phase = 12 * (wN + bN + wB + bB) + 18 * (wR + bR) + 44 * (wQ + bQ)
+	material.turf = (wN + bN + wB + bB) * (wN + bN + wB + bB)
+
+	// Set up evaluation flags and endgame handlers.
+	material.flags, material.endgame = endgames(wP, wN, wB, wR, wQ, bP, bN, bB, bR, bQ)
+
+	// Compute material imbalance scores.
+	if wQ != bQ || wR != bR || wB != bB || wN != bN || wP != bP {
+		white := imbalance(wB/2, wP, wN, wB, wR, wQ,  bB/2, bP, bN, bB, bR, bQ)
+		black := imbalance(bB/2, bP, bN, bB, bR, bQ,  wB/2, wP, wN, wB, wR, wQ)
+
+		adjustment := (white - black) / 32
+		material.score.midgame += adjustment
+		material.score.endgame += adjustment
+	}
+
+	return material
+}
+
 // Simplified second-degree polynomial material imbalance by Tord Romstad.
 func imbalance(w2, wP, wN, wB, wR, wQ, b2, bP, bN, bB, bR, bQ int) int {
 	polynom := func(x, a, b, c int) int {
@@ -315,12 +326,12 @@ func endgames(wP, wN, wB, wR, wQ, bP, bN, bB, bR, bQ int) (flags uint8, endgame
 		endgame = (*Evaluation).kingAndPawnVsBareKing
 
 	// Known endgame: king with a knight and a bishop vs. bare king.
-	} else if noPawns && allMajor == 0 && ((wN == 1 && wB == 1) || (bN == 1 && bB == 1)) {
+	} else if noPawns && bareKing && allMajor == 0 && ((wN == 1 && wB == 1) || (bN == 1 && bB == 1)) {
 		flags |= knownEndgame
 		endgame = (*Evaluation).knightAndBishopVsBareKing
 
 	// Known endgame: two bishops vs. bare king.
-	} else if noPawns && allMajor == 0 && ((wN == 0 && wB == 2) || (bN == 0 && bB == 2)) {
+	} else if noPawns && bareKing && allMajor == 0 && ((wN == 0 && wB == 2) || (bN == 0 && bB == 2)) {
 		flags |= knownEndgame
 		endgame = (*Evaluation).twoBishopsVsBareKing
 
@@ -340,6 +351,11 @@ func endgames(wP, wN, wB, wR, wQ, bP, bN, bB, bR, bQ int) (flags uint8, endgame
 		flags |= lesserKnownEndgame
 		endgame = (*Evaluation).queenVsRookAndPawns
 
+	// Lesser known endgame: queen vs. rook.
+	} else if noPawns && allMinor == 0 && ((wQ == 1 && wR + bQ == 0 && bR == 1) || (bQ == 1 && bR + wQ == 0 && wR == 1)) {
+		flags |= lesserKnownEndgame
+		endgame = (*Evaluation).queenVsRook
+
 	// Lesser known endgame: king and pawn vs. king and pawn.
 	} else if allMinor + allMajor == 0 && wP == 1 && bP == 1 {
 		flags |= lesserKnownEndgame
@@ -7,6 +7,7 @@ package donna
 import (
 	`bytes`
 	`regexp`
+	`strings`
 )
 
 const (
@@ -70,9 +71,15 @@ func NewMoveFromNotation(p *Position, e2e4 string) Move {
 	from := square(int(e2e4[1] - '1'), int(e2e4[0] - 'a'))
 	to := square(int(e2e4[3] - '1'), int(e2e4[2] - 'a'))
 
-	// Check if this is a castle.
-	if p.pieces[from].isKing() && abs(from - to) == 2 {
-		return NewCastle(p, from, to)
+	// Check if this is a castle. In Chess960 the castle is encoded as the
+	// king capturing its own rook.
+	if piece := p.pieces[from]; piece.isKing() {
+		if p.pieces[to] == rook(piece.color()) {
+			return NewCastle(p, from, let(to > from, G1, C1) + 56 * int(piece.color()))
+		}
+		if abs(from - to) == 2 && !p.engine().chess960 {
+			return NewCastle(p, from, to)
+		}
 	}
 
 	// Special handling for pawn pushes because they might cause en-passant
@@ -105,7 +112,7 @@ func NewMoveFromString(p *Position, e2e4 string) (move Move, validMoves []Move)
 
 	// Before returning the move make sure it is valid in current position.
 	defer func() {
-		gen := NewMoveGen(p).generateAllMoves().validOnly()
+		gen := NewGen(p, MaxPly).generateAllMoves().validOnly()
 		validMoves = gen.allMoves()
 		if move != Move(0) && !gen.amongValid(move) {
 			move = Move(0)
@@ -157,6 +164,48 @@ func NewMoveFromString(p *Position, e2e4 string) (move Move, validMoves []Move)
 	return
 }
 
+// Converts standard algebraic notation, ex. `Nbd7`, `exd5`, `e8=Q+`, or
+// `O-O`, to the move. Returns Move(0) if the move is illegal or ambiguous.
+func NewMoveFromSan(p *Position, san string) (move Move) {
+	san = strings.TrimRight(san, `+#!?`)
+	castle := strings.Replace(san, `0`, `O`, -1)
+	matches := reSan.FindStringSubmatch(san)
+	if castle != `O-O` && castle != `O-O-O` && matches == nil {
+		return Move(0)
+	}
+	letter := func(str string) byte { // Empty piece letter stands for a pawn.
+		if str == `` {
+			return 0
+		}
+		return str[0]
+	}
+
+	for _, valid := range NewGen(p, MaxPly).generateAllMoves().validOnly().allMoves() {
+		if valid.isCastle() {
+			if (castle == `O-O` && col(valid.to()) == 6) || (castle == `O-O-O` && col(valid.to()) == 2) {
+				return valid
+			}
+			continue
+		}
+		if matches == nil || valid.piece().char() != letter(matches[1]) || valid.promo().char() != letter(matches[5]) {
+			continue
+		}
+		from, to := valid.from(), valid.to()
+		if to != square(int(matches[4][1] - '1'), int(matches[4][0] - 'a')) {
+			continue
+		}
+		if (matches[2] != `` && col(from) != int(matches[2][0] - 'a')) || (matches[3] != `` && row(from) != int(matches[3][0] - '1')) {
+			continue
+		}
+		if !move.nil() {
+			return Move(0) // Ambiguous move.
+		}
+		move = valid
+	}
+
+	return move
+}
+
 func (m Move) nil() bool {
 	return m == Move(0)
 }
@@ -195,12 +244,12 @@ func (m Move) promote(kind int) Move {
 }
 
 // Capture value based on most valueable victim/least valueable attacker.
-func (m Move) value() (value int) {
-	value = pieceValue[m.capture().id()] - int(m.piece())
+func (m Move) value(profile *Profile) (value int) {
+	value = profile.pieceValue[m.capture().id()] - int(m.piece())
 	if m.isEnpassant() {
-		value += valuePawn.midgame
+		value += profile.valuePawn.midgame
 	} else if m.isPromo() {
-		value += pieceValue[m.promo().id()] - valuePawn.midgame
+		value += profile.pieceValue[m.promo().id()] - profile.valuePawn.midgame
 	}
 	return
 }
@@ -231,11 +280,6 @@ func (m Move) isPawnAdvance() bool {
 	return m.piece().isPawn() && rank(m.color(), m.to()) > A4H4
 }
 
-// Returns true is the move is one of the killer moves at given ply.
-func (m Move) isKiller(ply int) bool {
-	return m != Move(0) && (m == game.killers[ply][0] || m == game.killers[ply][1])
-}
-
 // Returns true if *non-evasion* move is valid, i.e. it is possible to make
 // the move in current position without violating chess rules.
 //
@@ -285,30 +329,35 @@ func (m Move) notation() string {
 // Returns string representation of the move in long algebraic notation using
 // ASCII characters only.
 func (m Move) str() (str string) {
-	if engine.fancy {
-		defer func() { engine.fancy = true }()
-		engine.fancy = false
-	}
-
-	return m.String()
+	return m.format(false)
 }
 
-// By default the move is represented in long algebraic notation utilizing fancy
-// UTF-8 engine setting. For example: `♘g1-f3` (fancy), `e4xd5` or `h7-h8Q`.
-// This notation is used in tests, REPL, and when showing principal variation.
+// By default the move is represented in long algebraic notation, for example:
+// `Ng1-f3`, `e4xd5` or `h7-h8Q`. This notation is used in tests and logs; REPL
+// shows the moves with replMove() that honors engine's fancy setting.
 func (m Move) String() (str string) {
+	return m.format(false)
+}
+
+// Returns the move in long algebraic notation; fancy one shows the pieces as
+// UTF-8 chess symbols, ex. `♘g1-f3` or `h7-h8♕`.
+func (m Move) format(fancy bool) string {
 	var buffer bytes.Buffer
 
 	from, to, piece, capture := m.split()
 	if m.isCastle() {
-		if to > from {
+		if col(to) == G1G8 {
 			return `0-0`
 		}
 		return `0-0-0`
 	}
 
 	if !piece.isPawn() {
-		buffer.WriteByte(piece.char())
+		if fancy {
+			buffer.WriteString(piece.fancy())
+		} else {
+			buffer.WriteByte(piece.char())
+		}
 	}
 	buffer.WriteByte(byte(col(from)) + 'a')
 	buffer.WriteByte(byte(row(from)) + '1')
@@ -320,7 +369,11 @@ func (m Move) String() (str string) {
 	buffer.WriteByte(byte(col(to)) + 'a')
 	buffer.WriteByte(byte(row(to)) + '1')
 	if promo := m.promo(); !promo.nil() {
-		buffer.WriteByte(promo.char())
+		if fancy {
+			buffer.WriteString(promo.fancy())
+		} else {
+			buffer.WriteByte(promo.char())
+		}
 	}
 
 	return buffer.String()
@@ -10,60 +10,60 @@ import(`github.com/michaeldv/donna/expect`; `testing`)
 func TestMove000(t *testing.T) {
 	game := NewGame(`Kd6,Qd1,Ra5,Nc3,Bc4,e4`, `Kh8,Qd5`)
 	p := game.start()
-	expect.Eq(t, NewMove(p, E4, D5).value(), 1258) // PxQ
-	expect.Eq(t, NewMove(p, C3, D5).value(), 1256) // NxQ
-	expect.Eq(t, NewMove(p, C4, D5).value(), 1254) // BxQ
-	expect.Eq(t, NewMove(p, A5, D5).value(), 1252) // RxQ
-	expect.Eq(t, NewMove(p, D1, D5).value(), 1250) // QxQ
-	expect.Eq(t, NewMove(p, D6, D5).value(), 1248) // KxQ
+	expect.Eq(t, NewMove(p, E4, D5).value(p.worker.profile), 1258) // PxQ
+	expect.Eq(t, NewMove(p, C3, D5).value(p.worker.profile), 1256) // NxQ
+	expect.Eq(t, NewMove(p, C4, D5).value(p.worker.profile), 1254) // BxQ
+	expect.Eq(t, NewMove(p, A5, D5).value(p.worker.profile), 1252) // RxQ
+	expect.Eq(t, NewMove(p, D1, D5).value(p.worker.profile), 1250) // QxQ
+	expect.Eq(t, NewMove(p, D6, D5).value(p.worker.profile), 1248) // KxQ
 }
 
 // PxR, NxR, BxR, RxR, QxR, KxR
 func TestMove010(t *testing.T) {
 	game := NewGame(`Kd6,Qd1,Ra5,Nc3,Bc4,e4`, `Kh8,Rd5`)
 	p := game.start()
-	expect.Eq(t, NewMove(p, E4, D5).value(), 633) // PxR
-	expect.Eq(t, NewMove(p, C3, D5).value(), 631) // NxR
-	expect.Eq(t, NewMove(p, C4, D5).value(), 629) // BxR
-	expect.Eq(t, NewMove(p, A5, D5).value(), 627) // RxR
-	expect.Eq(t, NewMove(p, D1, D5).value(), 625) // QxR
-	expect.Eq(t, NewMove(p, D6, D5).value(), 623) // KxR
+	expect.Eq(t, NewMove(p, E4, D5).value(p.worker.profile), 633) // PxR
+	expect.Eq(t, NewMove(p, C3, D5).value(p.worker.profile), 631) // NxR
+	expect.Eq(t, NewMove(p, C4, D5).value(p.worker.profile), 629) // BxR
+	expect.Eq(t, NewMove(p, A5, D5).value(p.worker.profile), 627) // RxR
+	expect.Eq(t, NewMove(p, D1, D5).value(p.worker.profile), 625) // QxR
+	expect.Eq(t, NewMove(p, D6, D5).value(p.worker.profile), 623) // KxR
 }
 
 // PxB, NxB, BxB, RxB, QxB, KxB
 func TestMove020(t *testing.T) {
 	game := NewGame(`Kd6,Qd1,Ra5,Nc3,Bc4,e4`, `Kh8,Bd5`)
 	p := game.start()
-	expect.Eq(t, NewMove(p, E4, D5).value(), 416) // PxB
-	expect.Eq(t, NewMove(p, C3, D5).value(), 414) // NxB
-	expect.Eq(t, NewMove(p, C4, D5).value(), 412) // BxB
-	expect.Eq(t, NewMove(p, A5, D5).value(), 410) // RxB
-	expect.Eq(t, NewMove(p, D1, D5).value(), 408) // QxB
-	expect.Eq(t, NewMove(p, D6, D5).value(), 406) // KxB
+	expect.Eq(t, NewMove(p, E4, D5).value(p.worker.profile), 416) // PxB
+	expect.Eq(t, NewMove(p, C3, D5).value(p.worker.profile), 414) // NxB
+	expect.Eq(t, NewMove(p, C4, D5).value(p.worker.profile), 412) // BxB
+	expect.Eq(t, NewMove(p, A5, D5).value(p.worker.profile), 410) // RxB
+	expect.Eq(t, NewMove(p, D1, D5).value(p.worker.profile), 408) // QxB
+	expect.Eq(t, NewMove(p, D6, D5).value(p.worker.profile), 406) // KxB
 }
 
 // PxN, NxN, BxN, RxN, QxN, KxN
 func TestMove030(t *testing.T) {
 	game := NewGame(`Kd6,Qd1,Ra5,Nc3,Bc4,e4`, `Kh8,Nd5`)
 	p := game.start()
-	expect.Eq(t, NewMove(p, E4, D5).value(), 406) // PxN
-	expect.Eq(t, NewMove(p, C3, D5).value(), 404) // NxN
-	expect.Eq(t, NewMove(p, C4, D5).value(), 402) // BxN
-	expect.Eq(t, NewMove(p, A5, D5).value(), 400) // RxN
-	expect.Eq(t, NewMove(p, D1, D5).value(), 398) // QxN
-	expect.Eq(t, NewMove(p, D6, D5).value(), 396) // KxN
+	expect.Eq(t, NewMove(p, E4, D5).value(p.worker.profile), 406) // PxN
+	expect.Eq(t, NewMove(p, C3, D5).value(p.worker.profile), 404) // NxN
+	expect.Eq(t, NewMove(p, C4, D5).value(p.worker.profile), 402) // BxN
+	expect.Eq(t, NewMove(p, A5, D5).value(p.worker.profile), 400) // RxN
+	expect.Eq(t, NewMove(p, D1, D5).value(p.worker.profile), 398) // QxN
+	expect.Eq(t, NewMove(p, D6, D5).value(p.worker.profile), 396) // KxN
 }
 
 // PxP, NxP, BxP, RxP, QxP, KxP
 func TestMove040(t *testing.T) {
 	game := NewGame(`Kd6,Qd1,Ra5,Nc3,Bc4,e4`, `Kh8,d5`)
 	p := game.start()
-	expect.Eq(t, NewMove(p, E4, D5).value(), 98) // PxP
-	expect.Eq(t, NewMove(p, C3, D5).value(), 96) // NxP
-	expect.Eq(t, NewMove(p, C4, D5).value(), 94) // BxP
-	expect.Eq(t, NewMove(p, A5, D5).value(), 92) // RxP
-	expect.Eq(t, NewMove(p, D1, D5).value(), 90) // QxP
-	expect.Eq(t, NewMove(p, D6, D5).value(), 88) // KxP
+	expect.Eq(t, NewMove(p, E4, D5).value(p.worker.profile), 98) // PxP
+	expect.Eq(t, NewMove(p, C3, D5).value(p.worker.profile), 96) // NxP
+	expect.Eq(t, NewMove(p, C4, D5).value(p.worker.profile), 94) // BxP
+	expect.Eq(t, NewMove(p, A5, D5).value(p.worker.profile), 92) // RxP
+	expect.Eq(t, NewMove(p, D1, D5).value(p.worker.profile), 90) // QxP
+	expect.Eq(t, NewMove(p, D6, D5).value(p.worker.profile), 88) // KxP
 }
 
 // NewMoveFromString: move from algebraic notation.
@@ -233,3 +233,53 @@ func TestMove340(t *testing.T) {
 	expect.Eq(t, bK & isCapture, Move(0))
 	expect.Ne(t, bP & isCapture, Move(0)) // Ne() for Pawn.
 }
+
+// Chess960 castle is encoded as the king capturing its own rook.
+func TestMove400(t *testing.T) {
+	p := NewGame(`bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9`).start()
+	p.game().engine.chess960 = true
+	move := NewMoveFromNotation(p, `g1h1`)
+	expect.Eq(t, move, NewCastle(p, G1, G1))
+	expect.Eq(t, move.String(), `0-0`)
+}
+
+func TestMove410(t *testing.T) {
+	p := NewGame(`6k1/8/8/8/8/8/8/RK6 w A - 0 1`).start()
+	p.game().engine.chess960 = true
+	move := NewMoveFromNotation(p, `b1a1`)
+	expect.Eq(t, move, NewCastle(p, B1, C1))
+	expect.Eq(t, move.String(), `0-0-0`)
+	expect.False(t, NewMoveFromNotation(p, `b1d1`).isCastle())
+}
+
+// Standard algebraic notation.
+func TestMove500(t *testing.T) {
+	p := NewGame(`Ke1,Ra1,Rh1,Nb1,Nf3,e5,g7`, `M,Ke8,Rd8,Nd7,d5`).start()
+	p = p.makeMove(NewMoveFromSan(p, `d5-d4`))
+	expect.Eq(t, NewMoveFromSan(p, `Nbd2`), NewMove(p, B1, D2))
+	expect.Eq(t, NewMoveFromSan(p, `Nfd2`), NewMove(p, F3, D2))
+	expect.Eq(t, NewMoveFromSan(p, `Nd2`), Move(0)) // Ambiguous.
+	expect.Eq(t, NewMoveFromSan(p, `Nxd4`), NewMove(p, F3, D4))
+	expect.Eq(t, NewMoveFromSan(p, `O-O`), NewCastle(p, E1, G1))
+	expect.Eq(t, NewMoveFromSan(p, `O-O-O+`), Move(0)) // Knight in the way.
+	expect.Eq(t, NewMoveFromSan(p, `Rhf1`), NewMove(p, H1, F1))
+	expect.Eq(t, NewMoveFromSan(p, `g8=N`).promo(), Piece(Knight))
+	expect.Eq(t, NewMoveFromSan(p, `gxh8=Q`), Move(0)) // Nothing to capture.
+	expect.Eq(t, NewMoveFromSan(p, `Ke3`), Move(0))    // Illegal.
+}
+
+// Fancy engine shows the moves with UTF-8 chess symbols; plain string stays
+// in ASCII.
+func TestMove510(t *testing.T) {
+	engine := NewEngine(`fancy`, true)
+	p := engine.NewGame(`Ke1,Ng1,h7`, `Kd8,Nb8`).start()
+	knight, promo := NewMove(p, G1, F3), NewMove(p, H7, H8).promote(Queen)
+
+	expect.Eq(t, engine.replMove(knight), "♘g1-f3")
+	expect.Eq(t, engine.replMoves([]Move{ knight, promo }), "[♘g1-f3 h7-h8♕]")
+	expect.Eq(t, knight.String(), `Ng1-f3`)
+	expect.Eq(t, promo.str(), `h7-h8Q`)
+
+	engine.fancy = false
+	expect.Eq(t, engine.replMoves([]Move{ knight }), `[Ng1-f3]`)
+}
@@ -0,0 +1,311 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`bytes`
+	`encoding/binary`
+	`errors`
+	`fmt`
+	`io`
+	`io/ioutil`
+	`math`
+	`os`
+)
+
+// Efficiently updatable neural network evaluator. The network has a single
+// hidden layer that is computed twice, from White's and from Black's point of
+// view, using the same weights. Its inputs are 768 piece-square features: six
+// own and six enemy piece kinds on 64 squares, with the board flipped upside
+// down for Black. Since a move toggles just a few features the hidden layer
+// sums (aka accumulators) get updated incrementally as the move is made. The
+// output is the dot product of clipped hidden values, side to move's half
+// first, and the output weights.
+//
+// Network file format, all numbers little endian:
+//
+//	Offset       Size     Contents
+//	0            4        Magic "DNUE"
+//	4            4        Format version, currently 1
+//	8            4        Hidden layer size N, 1..1024
+//	12           4        Output scale S, centipawns per unit of output
+//	16           768*N*2  Hidden layer weights, int16, N weights per feature
+//	16+1536*N    N*2      Hidden layer biases, int16
+//	16+1538*N    2*N*2    Output weights, int16, side to move's half first
+//	16+1542*N    4        Output bias, int32
+//
+// Feature index is (side * 6 + kind) * 64 + square, where side is 0 for own
+// pieces and 1 for the enemy ones, kind is 0 for pawns through 5 for kings,
+// and square is A1 = 0 through H8 = 63 flipped vertically for Black.
+//
+// The weights are quantized: hidden weights and biases are multiplied by QA,
+// output weights by QB, and output bias by QA * QB. Hidden values are clipped
+// to 0..QA, and the evaluation is (output * S) / (QA * QB).
+//
+// Quantized weights must fit int16, i.e. unquantized hidden weights and biases
+// stay within about ±128, and output weights within ±512. Hidden layer sums
+// are kept in int16 as well and wrap around on overflow: the bias plus the
+// weights of up to 32 pieces on the board must stay within -32768..32767 for
+// each hidden value, ex. hidden weights within ±3.9 when they all add up.
+const (
+	nnueMagic     = 0x45554E44 // "DNUE"
+	nnueVersion   = 1
+	nnueFeatures  = 768
+	nnueMaxHidden = 1024
+	nnueQA        = 255
+	nnueQB        = 64
+)
+
+type Network struct {
+	hidden   int                // Hidden layer size.
+	scale    int                // Output scale.
+	weights  []int16            // Hidden layer weights, feature by feature.
+	biases   []int16            // Hidden layer biases.
+	output   []int16            // Output weights.
+	bias     int                // Output bias.
+}
+
+// Hidden layer sums of the position from White's and Black's point of view.
+type Accumulator struct {
+	id       uint64             // Position the sums are up to date with.
+	network  *Network           // The network the sums belong to.
+	values   [2][]int16         // Hidden layer sums for each side.
+}
+
+// Reads the network file.
+func LoadNetwork(r io.Reader) (*Network, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 16 || binary.LittleEndian.Uint32(data) != nnueMagic {
+		return nil, errors.New(`donna: not a network file`)
+	}
+	if version := binary.LittleEndian.Uint32(data[4:]); version != nnueVersion {
+		return nil, fmt.Errorf("donna: unsupported network version %d", version)
+	}
+
+	n := &Network{ hidden: int(int32(binary.LittleEndian.Uint32(data[8:]))), scale: int(int32(binary.LittleEndian.Uint32(data[12:]))) }
+	if n.hidden < 1 || n.hidden > nnueMaxHidden || n.scale < 1 {
+		return nil, fmt.Errorf("donna: invalid network size %d or scale %d", n.hidden, n.scale)
+	}
+	if size := 16 + (nnueFeatures + 3) * n.hidden * 2 + 4; len(data) != size {
+		return nil, fmt.Errorf("donna: network file size %d, expected %d", len(data), size)
+	}
+
+	values := make([]int16, (nnueFeatures + 3) * n.hidden)
+	binary.Read(bytes.NewReader(data[16:]), binary.LittleEndian, values)
+	n.weights = values[: nnueFeatures * n.hidden]
+	n.biases = values[nnueFeatures * n.hidden : (nnueFeatures + 1) * n.hidden]
+	n.output = values[(nnueFeatures + 1) * n.hidden :]
+	n.bias = int(int32(binary.LittleEndian.Uint32(data[len(data) - 4:])))
+
+	return n, nil
+}
+
+// Creates the network from floating point weights by quantizing them: hidden
+// weights are given feature by feature, and output weights start with the
+// side to move's half.
+func NewNetwork(scale int, weights [][]float64, biases, output []float64, bias float64) (*Network, error) {
+	n := &Network{ hidden: len(biases), scale: scale }
+	if n.hidden < 1 || n.hidden > nnueMaxHidden || scale < 1 {
+		return nil, fmt.Errorf("donna: invalid network size %d or scale %d", n.hidden, scale)
+	}
+	if len(weights) != nnueFeatures || len(output) != 2 * n.hidden {
+		return nil, fmt.Errorf("donna: expected %d features and %d output weights, got %d and %d", nnueFeatures, 2 * n.hidden, len(weights), len(output))
+	}
+
+	// Quantized values that don't fit their type get reported rather than
+	// wrapped around.
+	var err error
+	quantize := func(value float64, factor int, limit float64) int {
+		quantized := math.Floor(value * float64(factor) + 0.5)
+		if err == nil && !(quantized >= -limit - 1 && quantized <= limit) { // <-- Catches NaN too.
+			err = fmt.Errorf("donna: weight %g is out of range when quantized by %d", value, factor)
+		}
+		return int(quantized)
+	}
+	for _, column := range weights {
+		if len(column) != n.hidden {
+			return nil, fmt.Errorf("donna: expected %d weights per feature, got %d", n.hidden, len(column))
+		}
+		for _, weight := range column {
+			n.weights = append(n.weights, int16(quantize(weight, nnueQA, math.MaxInt16)))
+		}
+	}
+	for _, weight := range biases {
+		n.biases = append(n.biases, int16(quantize(weight, nnueQA, math.MaxInt16)))
+	}
+	for _, weight := range output {
+		n.output = append(n.output, int16(quantize(weight, nnueQB, math.MaxInt16)))
+	}
+	n.bias = quantize(bias, nnueQA * nnueQB, math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Writes the network file.
+func (n *Network) Save(w io.Writer) error {
+	header := []uint32{ nnueMagic, nnueVersion, uint32(n.hidden), uint32(n.scale) }
+	for _, data := range []interface{}{ header, n.weights, n.biases, n.output, int32(n.bias) } {
+		if err := binary.Write(w, binary.LittleEndian, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns the hidden layer size.
+func (n *Network) Size() int {
+	return n.hidden
+}
+
+// Returns the index of the piece on the square as seen by the given side.
+func (n *Network) feature(color uint8, piece Piece, square int) int {
+	side := let(piece.color() == color, 0, 6)
+	return (side + piece.id() - 1) * 64 + (square ^ int(56 * color))
+}
+
+// Returns the network weights of the feature.
+func (n *Network) column(feature int) []int16 {
+	return n.weights[feature * n.hidden : (feature + 1) * n.hidden]
+}
+
+// Allocates the accumulators for each node of the worker's position tree.
+func (n *Network) accumulators(size int) []Accumulator {
+	accumulators := make([]Accumulator, size)
+	values := make([]int16, size * 2 * n.hidden)
+	for i := range accumulators {
+		accumulators[i].network = n
+		accumulators[i].values[White] = values[(2 * i) * n.hidden : (2 * i + 1) * n.hidden]
+		accumulators[i].values[Black] = values[(2 * i + 1) * n.hidden : (2 * i + 2) * n.hidden]
+	}
+
+	return accumulators
+}
+
+// Computes the sums from scratch.
+func (acc *Accumulator) refresh(p *Position) *Accumulator {
+	copy(acc.values[White], acc.network.biases)
+	copy(acc.values[Black], acc.network.biases)
+	for board := p.board; board.any(); {
+		square := board.pop()
+		acc.add(p.pieces[square], square)
+	}
+	acc.id = p.id
+
+	return acc
+}
+
+// Adds the piece on the square.
+func (acc *Accumulator) add(piece Piece, square int) *Accumulator {
+	for color := uint8(White); color <= Black; color++ {
+		values := acc.values[color]
+		for i, weight := range acc.network.column(acc.network.feature(color, piece, square)) {
+			values[i] += weight
+		}
+	}
+
+	return acc
+}
+
+// Removes the piece from the square.
+func (acc *Accumulator) sub(piece Piece, square int) *Accumulator {
+	for color := uint8(White); color <= Black; color++ {
+		values := acc.values[color]
+		for i, weight := range acc.network.column(acc.network.feature(color, piece, square)) {
+			values[i] -= weight
+		}
+	}
+
+	return acc
+}
+
+// Moves the piece from one square to another.
+func (acc *Accumulator) move(piece Piece, from, to int) *Accumulator {
+	n := acc.network
+	for color := uint8(White); color <= Black; color++ {
+		values, off, on := acc.values[color], n.column(n.feature(color, piece, from)), n.column(n.feature(color, piece, to))
+		for i := range values {
+			values[i] += on[i] - off[i]
+		}
+	}
+
+	return acc
+}
+
+// Returns the evaluation from the given side's point of view.
+func (acc *Accumulator) output(color uint8) int {
+	n, sum := acc.network, 0
+	for i, value := range acc.values[color] {
+		sum += min(max(int(value), 0), nnueQA) * int(n.output[i])
+	}
+	for i, value := range acc.values[color^1] {
+		sum += min(max(int(value), 0), nnueQA) * int(n.output[n.hidden + i])
+	}
+
+	return (sum + n.bias) * n.scale / (nnueQA * nnueQB)
+}
+
+// Returns the accumulator to update incrementally while the move is being
+// made, or nil if there is no network.
+func (p *Position) accumulator() *Accumulator {
+	if w := p.worker; w.network != nil {
+		return &w.accumulators[w.node]
+	}
+
+	return nil
+}
+
+// Copies the accumulator of the parent position over to the current node so
+// that it could be updated incrementally. Parent's accumulator gets refreshed
+// first if it's out of date, ex. at the root or after the game's move.
+func (w *Worker) inheritAccumulator(parent *Position) {
+	if w.network != nil {
+		previous, current := &w.accumulators[w.node - 1], &w.accumulators[w.node]
+		if previous.id != parent.id {
+			previous.refresh(parent)
+		}
+		copy(current.values[White], previous.values[White])
+		copy(current.values[Black], previous.values[Black])
+	}
+}
+
+// Returns the network evaluation from the side to move's point of view.
+func (p *Position) evaluateNetwork() int {
+	acc := &p.worker.accumulators[p.worker.node]
+	if acc.id != p.id {
+		acc.refresh(p)
+	}
+
+	return min(max(acc.output(p.color), BlackWinning), WhiteWinning)
+}
+
+// Loads the network file to evaluate positions with; empty file name turns
+// the network off. The network is used starting with the next new game.
+func (e *Engine) loadNetwork(fileName string) error {
+	if fileName == `` || fileName == `<empty>` {
+		e.network = nil
+		return nil
+	}
+
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	network, err := LoadNetwork(file)
+	if err == nil {
+		e.network = network
+	}
+
+	return err
+}
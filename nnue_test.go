@@ -0,0 +1,169 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `bytes`; `encoding/json`; `io/ioutil`; `math`; `os`; `testing`)
+
+type floatNetwork struct {
+	Scale   int         `json:"scale"`
+	Weights [][]float64 `json:"weights"`
+	Biases  []float64   `json:"biases"`
+	Output  []float64   `json:"output"`
+	Bias    float64     `json:"bias"`
+}
+
+func loadFloatNetwork(t *testing.T) *floatNetwork {
+	data, err := ioutil.ReadFile(`testdata/nnue/tiny.json`)
+	expect.Eq(t, err, error(nil))
+	var weights floatNetwork
+	expect.Eq(t, json.Unmarshal(data, &weights), error(nil))
+
+	return &weights
+}
+
+// Evaluates the position in floating point straight off the piece list.
+func (f *floatNetwork) evaluate(p *Position) float64 {
+	var hidden [2][]float64
+	for color := White; color <= Black; color++ {
+		hidden[color] = append([]float64{}, f.Biases...)
+		for square, piece := range p.pieces {
+			if piece.nil() {
+				continue
+			}
+			index := (int(piece) / 2 - 1) * 64 // Pawn = 2, Knight = 4...
+			if int(piece.color()) != color {
+				index += 6 * 64
+			}
+			if color == Black {
+				index += (7 - row(square)) * 8 + col(square)
+			} else {
+				index += square
+			}
+			for i, weight := range f.Weights[index] {
+				hidden[color][i] += weight
+			}
+		}
+	}
+
+	sum, size := f.Bias, len(f.Biases)
+	for i := 0; i < size; i++ {
+		sum += math.Min(math.Max(hidden[p.color][i], 0.0), 1.0) * f.Output[i]
+		sum += math.Min(math.Max(hidden[p.color^1][i], 0.0), 1.0) * f.Output[size + i]
+	}
+
+	return sum * float64(f.Scale)
+}
+
+func loadTinyNetwork(t *testing.T) *Engine {
+	engine := NewEngine()
+	expect.Eq(t, engine.loadNetwork(`testdata/nnue/tiny.nnue`), error(nil))
+
+	return engine
+}
+
+// Reference network file matches its float weights and saves back unchanged.
+func TestNnue000(t *testing.T) {
+	data, err := ioutil.ReadFile(`testdata/nnue/tiny.nnue`)
+	expect.Eq(t, err, error(nil))
+	network, err := LoadNetwork(bytes.NewReader(data))
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, network.Size(), 8)
+	expect.Eq(t, network.scale, 400)
+
+	var saved bytes.Buffer
+	expect.Eq(t, network.Save(&saved), error(nil))
+	expect.True(t, bytes.Equal(saved.Bytes(), data))
+
+	f := loadFloatNetwork(t)
+	quantized, err := NewNetwork(f.Scale, f.Weights, f.Biases, f.Output, f.Bias)
+	expect.Eq(t, err, error(nil))
+	saved.Reset()
+	expect.Eq(t, quantized.Save(&saved), error(nil))
+	expect.True(t, bytes.Equal(saved.Bytes(), data))
+}
+
+// Quantized inference matches the float reference.
+func TestNnue010(t *testing.T) {
+	engine, f := loadTinyNetwork(t), loadFloatNetwork(t)
+	for _, fen := range []string{
+		`rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1`,
+		`rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR b KQkq - 0 1`,
+		`r1bq1rk1/pp2bppp/2n1pn2/3p4/2PP4/2N1PN2/PP3PPP/R2QKB1R w KQ - 3 9`,
+		`8/5pk1/6p1/3R4/p7/2r3P1/5PK1/8 b - - 0 40`,
+		`r3k2r/8/8/8/8/8/4P3/R3K2R w KQkq - 0 1`,
+	} {
+		p := engine.NewGame(fen).start()
+		expected := f.evaluate(p)
+		actual := p.Evaluate()
+		expect.True(t, math.Abs(float64(actual) - expected) <= 1.0)
+		expect.Eq(t, actual, p.evaluateNetwork())
+	}
+}
+
+// Accumulators get updated incrementally as the moves are made.
+func TestNnue020(t *testing.T) {
+	engine := loadTinyNetwork(t)
+	p := engine.NewGame(`r3k3/1P3p2/8/3pP3/8/2n5/8/R3K2R w KQ d6 0 1`).start()
+	fresh := Accumulator{ network: engine.network, values: [2][]int16{ make([]int16, 8), make([]int16, 8) } }
+
+	for _, move := range []string{ `e5d6`, `c3d1`, `e1g1`, `d1e3`, `b7a8q`, `e8d7`, `a1a7` } {
+		m, _ := NewMoveFromString(p, move)
+		expect.Ne(t, m, Move(0))
+		p = p.makeMove(m)
+		acc := &p.worker.accumulators[p.worker.node]
+		expect.Eq(t, acc.id, p.id)
+		fresh.refresh(p)
+		expect.Eq(t, acc.values, fresh.values)
+		expect.Eq(t, p.Evaluate(), fresh.output(p.color))
+	}
+
+	// Null move keeps the sums and only flips the point of view.
+	score := p.Evaluate()
+	p = p.makeNullMove()
+	expect.Eq(t, p.worker.accumulators[p.worker.node].values, fresh.values)
+	expect.Eq(t, p.Evaluate(), fresh.output(p.color))
+	expect.Ne(t, p.Evaluate(), score)
+}
+
+// Broken network files get rejected.
+func TestNnue030(t *testing.T) {
+	data, _ := ioutil.ReadFile(`testdata/nnue/tiny.nnue`)
+
+	_, err := LoadNetwork(bytes.NewReader(data[:len(data) - 2]))
+	expect.Contain(t, err.Error(), `network file size`)
+	_, err = LoadNetwork(bytes.NewReader([]byte(`rnbqkbnr/pppppppp`)))
+	expect.Contain(t, err.Error(), `not a network file`)
+
+	broken := append([]byte{}, data...)
+	broken[4] = 2
+	_, err = LoadNetwork(bytes.NewReader(broken))
+	expect.Contain(t, err.Error(), `unsupported network version 2`)
+
+	_, err = NewNetwork(400, make([][]float64, 768), make([]float64, 4), make([]float64, 4), 0.0)
+	expect.Contain(t, err.Error(), `expected 768 features and 8 output weights`)
+
+	// Quantized weights that don't fit int16 are rejected.
+	weights := make([][]float64, 768)
+	for i := range weights {
+		weights[i] = make([]float64, 2)
+	}
+	_, err = NewNetwork(400, weights, []float64{ 0.0, 0.0 }, []float64{ 1.0, 2.0, 3.0, 512.0 }, 0.0)
+	expect.Contain(t, err.Error(), `weight 512 is out of range when quantized by 64`)
+	weights[767][1] = -128.6
+	_, err = NewNetwork(400, weights, []float64{ 0.0, 0.0 }, []float64{ 1.0, 2.0, 3.0, 4.0 }, 0.0)
+	expect.Contain(t, err.Error(), `weight -128.6 is out of range when quantized by 255`)
+	weights[767][1] = -128.5
+	_, err = NewNetwork(400, weights, []float64{ 0.0, 0.0 }, []float64{ 1.0, 2.0, 3.0, 4.0 }, math.NaN())
+	expect.Contain(t, err.Error(), `weight NaN is out of range`)
+	network, err := NewNetwork(400, weights, []float64{ 0.0, 0.0 }, []float64{ 1.0, 2.0, 3.0, 4.0 }, 0.0)
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, network.weights[767 * 2 + 1], int16(-32767))
+
+	engine := NewEngine()
+	_, err = os.Stat(`testdata/nnue/missing.nnue`)
+	expect.True(t, os.IsNotExist(err))
+	expect.True(t, engine.loadNetwork(`testdata/nnue/missing.nnue`) != nil)
+	expect.True(t, engine.network == nil)
+}
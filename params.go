@@ -0,0 +1,414 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`bytes`
+	`encoding/json`
+	`fmt`
+	`io`
+	`io/ioutil`
+	`os`
+	`strings`
+)
+
+// Evaluation profile: evaluation parameters along with the data derived from
+// them. Each engine owns its profile so that engines within the same process
+// could evaluate with different parameters. Use NewProfile() to create one.
+type Profile struct {
+	valuePawn, valueKnight, valueBishop, valueRook, valueQueen Score
+	rightToMove, bishopPawn, bishopBoxed, bishopDanger, rookOnPawn Score
+	rookOnOpen, rookOnSemiOpen, rookOn7th, rookBoxed, behindPawn Score
+	hangingAttack, kingAttack, kingByPawn, pawnAlone Score
+	weightMobility, weightPawnStructure, weightPassedPawns Score
+	weightSafety, weightCenter, weightThreats Score
+	bonusPawn, bonusKnight, bonusBishop, bonusRook, bonusQueen, bonusKing [2][64]int
+	bonusPassedPawn, bonusSemiPassedPawn [8]Score
+	extraPassedPawn [8]int
+	extraKnight, extraBishop [64]int
+	bonusPawnThreat, bonusMinorThreat, bonusRookThreat [6]Score
+	kingThreat [6]int
+	kingSafety [64]int
+	penaltyCover [7]int
+	penaltyStorm, penaltyStormBlocked, penaltyStormUnblocked [8]int
+	penaltyPawnThreat [6]Score
+	penaltyDoubledPawn, penaltyIsolatedPawn, penaltyWeakIsolatedPawn [8]Score
+	penaltyBackwardPawn, penaltyWeakBackwardPawn [8]Score
+	mobilityKnight [9]Score
+	mobilityBishop, mobilityRook, mobilityQueen [16]Score
+
+	pst            [14][64]Score // Piece values plus square bonuses, indexed by piece and square.
+	pieceValue     [7]int        // Piece values for most valuable victim/least valuable attacker.
+	exchangeScores [14]int       // Piece values for static exchange evaluation, indexed by piece.
+	weights        []*Weight     // Weights that could be changed with UCI options.
+	params         []*Param      // All evaluation parameters in the order they appear in data_evaluate.go.
+}
+
+// Evaluation parameter exposed by name so that it could be loaded from and
+// saved to the profile, or tuned. Only one of the fields that point to the
+// actual evaluation data is set.
+type Param struct {
+	name   string
+	score  *Score     // Single score, ex. rookOnOpen.
+	scores []Score    // Array of scores, ex. mobilityKnight.
+	ints   []int      // Array of plain values, ex. kingSafety.
+	table  *[2][64]int // Midgame and endgame piece/square bonuses.
+}
+
+// Evaluation weight exposed as UCI spin option. The weight used by evaluation
+// is the option's percentage of the base weight set by the profile.
+type Weight struct {
+	option  string
+	weight  *Score // Weight used by evaluation.
+	base    Score  // Weight set by the profile.
+	percent int    // UCI option value.
+}
+
+// Creates new profile with default evaluation parameters.
+func NewProfile() *Profile {
+	profile := new(Profile)
+	*profile = defaultProfile
+
+	profile.weights = []*Weight{
+		{ option: `Mobility`, weight: &profile.weightMobility },
+		{ option: `PawnStructure`, weight: &profile.weightPawnStructure },
+		{ option: `PassedPawns`, weight: &profile.weightPassedPawns },
+		{ option: `KingSafety`, weight: &profile.weightSafety },
+		{ option: `Center`, weight: &profile.weightCenter },
+		{ option: `Threats`, weight: &profile.weightThreats },
+	}
+	for _, w := range profile.weights {
+		w.base, w.percent = *w.weight, 100
+	}
+
+	// Profile weights are the base ones, not the ones scaled by UCI options.
+	profile.params = []*Param{
+		{ name: `valuePawn`, score: &profile.valuePawn },
+		{ name: `valueKnight`, score: &profile.valueKnight },
+		{ name: `valueBishop`, score: &profile.valueBishop },
+		{ name: `valueRook`, score: &profile.valueRook },
+		{ name: `valueQueen`, score: &profile.valueQueen },
+		{ name: `rightToMove`, score: &profile.rightToMove },
+		{ name: `bishopPawn`, score: &profile.bishopPawn },
+		{ name: `bishopBoxed`, score: &profile.bishopBoxed },
+		{ name: `bishopDanger`, score: &profile.bishopDanger },
+		{ name: `rookOnPawn`, score: &profile.rookOnPawn },
+		{ name: `rookOnOpen`, score: &profile.rookOnOpen },
+		{ name: `rookOnSemiOpen`, score: &profile.rookOnSemiOpen },
+		{ name: `rookOn7th`, score: &profile.rookOn7th },
+		{ name: `rookBoxed`, score: &profile.rookBoxed },
+		{ name: `behindPawn`, score: &profile.behindPawn },
+		{ name: `hangingAttack`, score: &profile.hangingAttack },
+		{ name: `kingAttack`, score: &profile.kingAttack },
+		{ name: `kingByPawn`, score: &profile.kingByPawn },
+		{ name: `pawnAlone`, score: &profile.pawnAlone },
+		{ name: `weightMobility`, score: &profile.weights[0].base },
+		{ name: `weightPawnStructure`, score: &profile.weights[1].base },
+		{ name: `weightPassedPawns`, score: &profile.weights[2].base },
+		{ name: `weightSafety`, score: &profile.weights[3].base },
+		{ name: `weightCenter`, score: &profile.weights[4].base },
+		{ name: `weightThreats`, score: &profile.weights[5].base },
+		{ name: `bonusPawn`, table: &profile.bonusPawn },
+		{ name: `bonusKnight`, table: &profile.bonusKnight },
+		{ name: `bonusBishop`, table: &profile.bonusBishop },
+		{ name: `bonusRook`, table: &profile.bonusRook },
+		{ name: `bonusQueen`, table: &profile.bonusQueen },
+		{ name: `bonusKing`, table: &profile.bonusKing },
+		{ name: `bonusPassedPawn`, scores: profile.bonusPassedPawn[:] },
+		{ name: `bonusSemiPassedPawn`, scores: profile.bonusSemiPassedPawn[:] },
+		{ name: `extraPassedPawn`, ints: profile.extraPassedPawn[:] },
+		{ name: `extraKnight`, ints: profile.extraKnight[:] },
+		{ name: `extraBishop`, ints: profile.extraBishop[:] },
+		{ name: `bonusPawnThreat`, scores: profile.bonusPawnThreat[:] },
+		{ name: `bonusMinorThreat`, scores: profile.bonusMinorThreat[:] },
+		{ name: `bonusRookThreat`, scores: profile.bonusRookThreat[:] },
+		{ name: `kingThreat`, ints: profile.kingThreat[:] },
+		{ name: `kingSafety`, ints: profile.kingSafety[:] },
+		{ name: `penaltyCover`, ints: profile.penaltyCover[:] },
+		{ name: `penaltyStorm`, ints: profile.penaltyStorm[:] },
+		{ name: `penaltyStormBlocked`, ints: profile.penaltyStormBlocked[:] },
+		{ name: `penaltyStormUnblocked`, ints: profile.penaltyStormUnblocked[:] },
+		{ name: `penaltyPawnThreat`, scores: profile.penaltyPawnThreat[:] },
+		{ name: `penaltyDoubledPawn`, scores: profile.penaltyDoubledPawn[:] },
+		{ name: `penaltyIsolatedPawn`, scores: profile.penaltyIsolatedPawn[:] },
+		{ name: `penaltyWeakIsolatedPawn`, scores: profile.penaltyWeakIsolatedPawn[:] },
+		{ name: `penaltyBackwardPawn`, scores: profile.penaltyBackwardPawn[:] },
+		{ name: `penaltyWeakBackwardPawn`, scores: profile.penaltyWeakBackwardPawn[:] },
+		{ name: `mobilityKnight`, scores: profile.mobilityKnight[:] },
+		{ name: `mobilityBishop`, scores: profile.mobilityBishop[:] },
+		{ name: `mobilityRook`, scores: profile.mobilityRook[:] },
+		{ name: `mobilityQueen`, scores: profile.mobilityQueen[:] },
+	}
+
+	return profile.reinit()
+}
+
+// Returns the parameter with the given name, or nil if there is none.
+func (profile *Profile) param(name string) *Param {
+	for _, p := range profile.params {
+		if p.name == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Returns pointers to all the values of the parameter, midgame and endgame
+// values of the scores go in pairs.
+func (p *Param) values() (values []*int) {
+	switch {
+	case p.score != nil:
+		values = append(values, &p.score.midgame, &p.score.endgame)
+	case p.scores != nil:
+		for i := range p.scores {
+			values = append(values, &p.scores[i].midgame, &p.scores[i].endgame)
+		}
+	case p.ints != nil:
+		for i := range p.ints {
+			values = append(values, &p.ints[i])
+		}
+	case p.table != nil:
+		for i := range p.table {
+			for j := range p.table[i] {
+				values = append(values, &p.table[i][j])
+			}
+		}
+	}
+
+	return values
+}
+
+// Returns the parameter as JSON value: scores are [midgame, endgame] pairs,
+// and piece/square bonuses are [[midgame...], [endgame...]] arrays.
+func (p *Param) MarshalJSON() ([]byte, error) {
+	switch {
+	case p.score != nil:
+		return json.Marshal([2]int{ p.score.midgame, p.score.endgame })
+	case p.scores != nil:
+		pairs := make([][2]int, len(p.scores))
+		for i, score := range p.scores {
+			pairs[i] = [2]int{ score.midgame, score.endgame }
+		}
+		return json.Marshal(pairs)
+	case p.ints != nil:
+		return json.Marshal(p.ints)
+	}
+
+	return json.Marshal(p.table)
+}
+
+// Sets the parameter from JSON value. The value must have exactly the same
+// shape as the parameter.
+func (p *Param) UnmarshalJSON(data []byte) (err error) {
+	switch {
+	case p.score != nil:
+		pair := [2]int{}
+		if err = unmarshalExact(data, &pair); err == nil {
+			p.score.midgame, p.score.endgame = pair[0], pair[1]
+		}
+	case p.scores != nil:
+		pairs := [][2]int{}
+		if err = unmarshalExact(data, &pairs); err == nil && len(pairs) == len(p.scores) {
+			for i, pair := range pairs {
+				p.scores[i] = Score{ pair[0], pair[1] }
+			}
+		} else if err == nil {
+			err = fmt.Errorf("expected %d scores, got %d", len(p.scores), len(pairs))
+		}
+	case p.ints != nil:
+		ints := []int{}
+		if err = unmarshalExact(data, &ints); err == nil && len(ints) == len(p.ints) {
+			copy(p.ints, ints)
+		} else if err == nil {
+			err = fmt.Errorf("expected %d values, got %d", len(p.ints), len(ints))
+		}
+	case p.table != nil:
+		table := [2][64]int{}
+		if err = unmarshalExact(data, &table); err == nil {
+			*p.table = table
+		}
+	}
+
+	return err
+}
+
+// Unmarshals JSON value rejecting fixed size arrays of wrong length that
+// json.Unmarshal() silently truncates or pads with zeros.
+func unmarshalExact(data []byte, value interface{}) error {
+	if err := json.Unmarshal(data, value); err != nil {
+		return err
+	}
+	if encoded, _ := json.Marshal(value); !bytes.Equal(compactJSON(data), encoded) {
+		return fmt.Errorf("unexpected shape of %s", data)
+	}
+
+	return nil
+}
+
+// Strips insignificant white space from JSON value.
+func compactJSON(data []byte) []byte {
+	var buffer bytes.Buffer
+	if json.Compact(&buffer, data) != nil {
+		return data
+	}
+
+	return buffer.Bytes()
+}
+
+// Saves all evaluation parameters as JSON profile, one parameter per line.
+func (profile *Profile) Save(w io.Writer) error {
+	lines := make([]string, len(profile.params))
+	for i, p := range profile.params {
+		value, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		lines[i] = fmt.Sprintf("  %q: %s", p.name, value)
+	}
+	_, err := fmt.Fprintf(w, "{\n%s\n}\n", strings.Join(lines, ",\n"))
+
+	return err
+}
+
+// Loads evaluation parameters from JSON profile. The parameters missing in
+// the profile keep their values; unknown parameters are rejected. Nothing
+// gets changed if the profile has errors. Weights that are set by the profile
+// become the base for their UCI options.
+func (profile *Profile) Load(r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]json.RawMessage{}
+	if err = json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("donna: invalid profile: %v", err)
+	}
+
+	// Validate all the parameters before changing any of them.
+	backup := profile.snapshot()
+	for name, value := range values {
+		p := profile.param(name)
+		if p == nil {
+			err = fmt.Errorf("donna: unknown parameter %q", name)
+		} else if e := json.Unmarshal(value, p); e != nil {
+			err = fmt.Errorf("donna: parameter %q: %v", name, e)
+		}
+		if err != nil {
+			profile.restore(backup)
+			return err
+		}
+	}
+	profile.reinit()
+
+	return nil
+}
+
+// Loads evaluation profile from the file and brings current game, if any, up
+// to date.
+func (e *Engine) loadProfile(fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = e.profile.Load(file); err == nil && e.game != nil {
+		e.game.retune()
+	}
+
+	return err
+}
+
+// Saves evaluation profile to the file.
+func (e *Engine) saveProfile(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err = e.profile.Save(file); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// Returns a copy of all parameter values.
+func (profile *Profile) snapshot() (snapshot []int) {
+	for _, p := range profile.params {
+		for _, value := range p.values() {
+			snapshot = append(snapshot, *value)
+		}
+	}
+
+	return snapshot
+}
+
+// Restores parameter values from the snapshot.
+func (profile *Profile) restore(snapshot []int) *Profile {
+	i := 0
+	for _, p := range profile.params {
+		for _, value := range p.values() {
+			*value, i = snapshot[i], i + 1
+		}
+	}
+
+	return profile.reinit()
+}
+
+// Sets the weight to given percentage of its base value. Returns false if
+// there is no such UCI option.
+func (profile *Profile) setWeight(option string, percent int) bool {
+	for _, w := range profile.weights {
+		if w.option == option {
+			w.percent = percent
+			profile.reinit()
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rebuilds the data derived from evaluation parameters: weights scaled by UCI
+// options, piece/square tables, and piece values used by move ordering and
+// static exchange evaluation.
+func (profile *Profile) reinit() *Profile {
+	for _, w := range profile.weights {
+		*w.weight = Score{ w.base.midgame * w.percent / 100, w.base.endgame * w.percent / 100 }
+	}
+
+	profile.pst = [14][64]Score{}
+	profile.initPST()
+
+	values := []int{ profile.valuePawn.midgame, profile.valueKnight.midgame, profile.valueBishop.midgame, profile.valueRook.midgame, profile.valueQueen.midgame }
+	profile.pieceValue = [7]int{ 0, values[0], values[1], values[2], values[3], values[4], 0 }
+	for i, value := range append(values, values[4] * 8) {
+		profile.exchangeScores[2 + i * 2], profile.exchangeScores[3 + i * 2] = value, value
+	}
+
+	return profile
+}
+
+// Brings the game up to date with changed evaluation parameters: positional
+// valuation of the game positions gets recomputed and the cached pawn
+// structures get dropped.
+func (game *Game) retune() *Game {
+	for _, worker := range game.workers {
+		worker.pawnCache = PawnCache{}
+	}
+
+	main := game.workers[0]
+	for i := 0; i <= main.played(); i++ {
+		if position := main.recall(i); position.board != 0 {
+			position.tally = position.valuation()
+		}
+	}
+
+	return game
+}
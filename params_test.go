@@ -0,0 +1,89 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `bytes`; `strings`; `testing`)
+
+// Saved profile loads back unchanged.
+func TestParams000(t *testing.T) {
+	profile := NewProfile()
+	snapshot := profile.snapshot()
+
+	var buffer bytes.Buffer
+	expect.Eq(t, profile.Save(&buffer), error(nil))
+	expect.Contain(t, buffer.String(), `"valuePawn": [100,129],`)
+	expect.Contain(t, buffer.String(), `"rookOnOpen": [22,10],`)
+	expect.Contain(t, buffer.String(), `"kingThreat": [0,0,2,2,3,5],`)
+
+	expect.Eq(t, profile.Load(&buffer), error(nil))
+	expect.Eq(t, profile.snapshot(), snapshot)
+	expect.Eq(t, len(snapshot), 1329)
+}
+
+// Loaded parameters change the evaluation along with derived tables; other
+// engines keep their own parameters.
+func TestParams010(t *testing.T) {
+	p := NewGame(`Ke1,Nd4`, `Ke8`).start()
+	profile := p.worker.profile
+	before, square := p.Evaluate(), profile.pst[Knight][D4]
+
+	expect.Eq(t, profile.Load(strings.NewReader(`{ "valueKnight": [508, 523] }`)), error(nil))
+	expect.Eq(t, profile.valueKnight, Score{ 508, 523 })
+	expect.Eq(t, profile.pieceValue[Knight / 2], 508)
+	expect.Eq(t, profile.exchangeScores[Knight], 508)
+	expect.Eq(t, profile.pst[Knight][D4], square.plus(Score{ 100, 100 }))
+	expect.Eq(t, profile.pst[BlackKnight][D4], profile.pst[Knight][D5].times(-1))
+
+	p.game().retune()
+	expect.True(t, p.Evaluate() > before)
+	expect.Eq(t, NewGame(`Ke1,Nd4`, `Ke8`).start().Evaluate(), before)
+	expect.Eq(t, defaultProfile.valueKnight, Score{ 408, 423 })
+}
+
+// Broken profiles get rejected leaving the parameters intact.
+func TestParams020(t *testing.T) {
+	profile := NewProfile()
+	snapshot := profile.snapshot()
+
+	expect.Contain(t, profile.Load(strings.NewReader(`{ "valuePawn": [1, 2], "valueKing": [0, 0] }`)).Error(), `unknown parameter "valueKing"`)
+	expect.Contain(t, profile.Load(strings.NewReader(`{ "valuePawn": [1, 2, 3] }`)).Error(), `parameter "valuePawn"`)
+	expect.Contain(t, profile.Load(strings.NewReader(`{ "kingThreat": [1, 2, 3] }`)).Error(), `expected 6 values, got 3`)
+	expect.Contain(t, profile.Load(strings.NewReader(`{ "mobilityKnight": [[1, 2]] }`)).Error(), `expected 9 scores, got 1`)
+	expect.Contain(t, profile.Load(strings.NewReader(`{ "valuePawn": `)).Error(), `invalid profile`)
+	expect.Eq(t, profile.snapshot(), snapshot)
+}
+
+// Evaluation weights could be scaled by UCI options.
+func TestParams030(t *testing.T) {
+	profile := NewProfile()
+
+	expect.True(t, profile.setWeight(`Mobility`, 50))
+	expect.Eq(t, profile.weightMobility, Score{ 54, 67 })
+	expect.True(t, profile.setWeight(`Mobility`, 100))
+	expect.Eq(t, profile.weightMobility, Score{ 108, 134 })
+	expect.False(t, profile.setWeight(`Hash`, 100))
+}
+
+// Profile sets the base of the weights it has, and scaled weights stay scaled
+// off their base no matter how many profiles get loaded.
+func TestParams040(t *testing.T) {
+	profile := NewProfile()
+	profile.setWeight(`Mobility`, 50)
+	profile.setWeight(`Threats`, 50)
+
+	expect.Eq(t, profile.Load(strings.NewReader(`{ "weightMobility": [200, 100] }`)), error(nil))
+	expect.Eq(t, profile.weightMobility, Score{ 100, 50 })
+	expect.Eq(t, profile.weightThreats, Score{ 74, 44 })
+	expect.Eq(t, profile.Load(strings.NewReader(`{ "rookOnOpen": [30, 15] }`)), error(nil))
+	expect.Eq(t, profile.weightThreats, Score{ 74, 44 })
+
+	var buffer bytes.Buffer
+	profile.Save(&buffer)
+	expect.Contain(t, buffer.String(), `"weightMobility": [200,100],`)
+	expect.Contain(t, buffer.String(), `"weightThreats": [148,88],`)
+
+	profile.setWeight(`Threats`, 100)
+	expect.Eq(t, profile.weightThreats, Score{ 148, 88 })
+}
@@ -67,10 +67,6 @@ func (p Piece) kind() int {
 	return int(p) & 0xFE
 }
 
-func (p Piece) value() int {
-	return pieceValue[p.id()]
-}
-
 func (p Piece) isWhite() bool {
 	return p & 1 == 0
 }
@@ -109,11 +105,10 @@ func (p Piece) char() byte {
 }
 
 func (p Piece) String() string {
-	plain := []string{ ` `, ` `, `P`, `p`, `N`, `n`, `B`, `b`, `R`, `r`, `Q`, `q`, `K`, `k` }
-	fancy := []string{ ` `, ` `, "\u2659", "\u265F", "\u2658", "\u265E", "\u2657", "\u265D", "\u2656", "\u265C", "\u2655", "\u265B", "\u2654", "\u265A" }
+	return []string{ ` `, ` `, `P`, `p`, `N`, `n`, `B`, `b`, `R`, `r`, `Q`, `q`, `K`, `k` }[p]
+}
 
-	if engine.fancy {
-		return fancy[p]
-	}
-	return plain[p]
+// Returns UTF-8 chess symbol for the piece.
+func (p Piece) fancy() string {
+	return []string{ ` `, ` `, "\u2659", "\u265F", "\u2658", "\u265E", "\u2657", "\u265D", "\u2656", "\u265C", "\u2655", "\u265B", "\u2654", "\u265A" }[p]
 }
@@ -11,9 +11,6 @@ import (
 	`strings`
 )
 
-var tree [1024]Position
-var node, rootNode int
-
 type Position struct {		 // 224 bytes long.
 	id           uint64      // Polyglot hash value for the position.
 	pawnId       uint64      // Polyglot hash value for position's pawn structure.
@@ -29,11 +26,14 @@ type Position struct {		 // 224 bytes long.
 	enpassant    uint8       // En-passant square caused by previous move.
 	castles      uint8       // Castle rights mask.
 	count50      uint8	 // 50 moves rule counter.
+	last         Move        // Last move made, or Move(0) after null move.
+	worker       *Worker     // Search worker that owns the position tree.
 }
 
 func NewPosition(game *Game, white, black string) *Position {
-	tree[node] = Position{}
-	p := &tree[node]
+	worker := game.workers[0]
+	worker.tree[worker.node] = Position{ worker: worker }
+	p := worker.position()
 
 	p.setupSide(white, White).setupSide(black, Black)
 
@@ -50,6 +50,7 @@ func NewPosition(game *Game, white, black string) *Position {
 	if p.pieces[E8] != BlackKing || p.pieces[A8] != BlackRook {
 		p.castles &= ^castleQueenside[Black]
 	}
+	game.castling = standardCastling
 
 	for square, piece := range p.pieces {
 		if !piece.nil() {
@@ -141,8 +142,9 @@ func NewInitialPosition(game *Game) *Position {
 
 // Decodes FEN string and creates new position.
 func NewPositionFromFEN(game *Game, fen string) *Position {
-	tree[node] = Position{}
-	p := &tree[node]
+	worker := game.workers[0]
+	worker.tree[worker.node] = Position{ worker: worker }
+	p := worker.position()
 
 	// Expected matches of interest are as follows:
 	// [0] - Pieces (entire board).
@@ -204,21 +206,32 @@ func NewPositionFromFEN(game *Game, fen string) *Position {
 	// [1] - Color of side to move.
 	p.color = uint8(let(matches[1] == `w`, White, Black))
 
-	// [2] - Castle rights.
+	// [2] - Castle rights. Besides standard KQkq this accepts X-FEN and
+	// Shredder-FEN file letters of the castling rooks used in Chess960.
+	kingside, queenside := [2]int{ -1, -1 }, [2]int{ -1, -1 }
 	for _, char := range(matches[2]) {
-		switch(char) {
-		case 'K':
-			p.castles |= castleKingside[White]
-		case 'Q':
-			p.castles |= castleQueenside[White]
-		case 'k':
-			p.castles |= castleKingside[Black]
-		case 'q':
-			p.castles |= castleQueenside[Black]
-		case '-':
-			// No castling rights.
+		switch {
+		case char == 'K' || char == 'k':
+			color := uint8(let(char == 'K', White, Black))
+			p.castles |= castleKingside[color]
+			kingside[color] = p.outerRook(color, true)
+		case char == 'Q' || char == 'q':
+			color := uint8(let(char == 'Q', White, Black))
+			p.castles |= castleQueenside[color]
+			queenside[color] = p.outerRook(color, false)
+		case (char >= 'A' && char <= 'H') || (char >= 'a' && char <= 'h'):
+			color := uint8(let(char <= 'H', White, Black))
+			square := square(let(color == White, A1H1, A8H8), int(char - 'A') & 7)
+			if square > int(p.king[color]) {
+				p.castles |= castleKingside[color]
+				kingside[color] = square
+			} else {
+				p.castles |= castleQueenside[color]
+				queenside[color] = square
+			}
 		}
 	}
+	p.setupCastling(kingside, queenside)
 
 	// [3] - En-passant square.
 	if matches[3] != `-` {
@@ -227,8 +240,10 @@ func NewPositionFromFEN(game *Game, fen string) *Position {
 	}
 
 	// [4] - Number of half-moves.
-	if n, err := strconv.Atoi(matches[4]); err == nil {
-		p.count50 = uint8(n)
+	if len(matches) > 4 {
+		if n, err := strconv.Atoi(matches[4]); err == nil {
+			p.count50 = uint8(n)
+		}
 	}
 
 	p.reversible = true
@@ -272,7 +287,7 @@ func (p *Position) valuation() (score Score) {
 	for board.any() {
 		square := board.pop()
 		piece := p.pieces[square]
-		score.add(pst[piece][square])
+		score.add(p.worker.profile.pst[piece][square])
 	}
 
 	return score
@@ -280,50 +295,74 @@ func (p *Position) valuation() (score Score) {
 
 // Returns true if material balance is insufficient to win the game.
 func (p *Position) insufficient() bool {
-	return materialBase[p.balance].flags & materialDraw != 0
+	return p.material().flags & materialDraw != 0
+}
+
+// Returns material base entry for the position. Material balance index
+// overflows when promotions leave either side with more pieces than the
+// table covers, ex. with the second queen, so the entry for such position
+// gets created on the fly.
+func (p *Position) material() *MaterialEntry {
+	if !p.promoted() {
+		return &materialBase[p.balance]
+	}
+
+	count := func(piece Piece) int {
+		return p.outposts[piece].count()
+	}
+	p.worker.material = newMaterial(count(Pawn), count(Knight), count(Bishop), count(Rook), count(Queen),
+		count(BlackPawn), count(BlackKnight), count(BlackBishop), count(BlackRook), count(BlackQueen))
+
+	return &p.worker.material
+}
+
+// Returns true if either side has more pieces than material table covers.
+func (p *Position) promoted() bool {
+	for color := uint8(White); color <= Black; color++ {
+		if p.outposts[queen(color)].count() > 1 || p.outposts[rook(color)].count() > 2 ||
+		   p.outposts[bishop(color)].count() > 2 || p.outposts[knight(color)].count() > 2 {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Reports game status for current position or after the given move. The status
 // helps to determine whether to continue with search or if the game is over.
+// Draws are detected regardless of the score since contempt and handicap noise
+// move it off zero.
 func (p *Position) status(move Move, blendedScore int) int {
 	if !move.nil() {
 		p = p.makeMove(move)
 		defer func() { p = p.undoLastMove() }()
 	}
 
-	switch ply, score := ply(), abs(blendedScore); score {
-	case 0:
-		if ply == 1 {
-			if p.insufficient() {
-				return Insufficient
-			} else if p.thirdRepetition() {
-				return Repetition
-			} else if p.fifty() {
-				return FiftyMoves
-			}
-		}
-		if !NewGen(p, MaxPly).generateMoves().anyValid() {
-			return Stalemate
-		}
-	case Checkmate - ply:
+	ply := p.ply()
+	if !NewGen(p, MaxPly).generateAllMoves().anyValid() {
 		if p.isInCheck(p.color) {
 			return let(p.color == White, BlackWon, WhiteWon)
 		}
 		return Stalemate
-	default:
-		if score > Checkmate - MaxDepth && (score + ply) / 2 > 0 {
-			return let(p.color == White, BlackWinning, WhiteWinning)
+	}
+	if ply == 1 {
+		if p.insufficient() {
+			return Insufficient
+		} else if p.thirdRepetition() {
+			return Repetition
+		} else if p.fifty() {
+			return FiftyMoves
 		}
 	}
+	if score := abs(blendedScore); score > Checkmate - MaxDepth && (score + ply) / 2 > 0 {
+		return let(p.color == White, BlackWinning, WhiteWinning)
+	}
 
 	return InProgress
 }
 
 // Encodes position as FEN string.
 func (p *Position) fen() (fen string) {
-	fancy := engine.fancy
-	engine.fancy = false; defer func() { engine.fancy = fancy }()
-
 	// Board: start from A8->H8 going down to A1->H1.
 	empty := 0
 	for row := A8H8; row >= A1H1; row-- {
@@ -363,17 +402,24 @@ func (p *Position) fen() (fen string) {
 	// Castle rights for both sides, if any.
 	if p.castles & 0x0F != 0 {
 		fen += ` `
-		if p.castles & castleKingside[White] != 0 {
-			fen += `K`
-		}
-		if p.castles & castleQueenside[White] != 0 {
-			fen += `Q`
-		}
-		if p.castles & castleKingside[Black] != 0 {
-			fen += `k`
-		}
-		if p.castles & castleQueenside[Black] != 0 {
-			fen += `q`
+		c := p.castling()
+		for color := uint8(White); color <= uint8(Black); color++ {
+			// Use X-FEN notation: the file of the castling rook is only
+			// needed when it's not the outermost rook.
+			if p.castles & castleKingside[color] != 0 {
+				if square := c.rookKing[color]; square == p.outerRook(color, true) {
+					fen += string(rune(let(color == White, 'K', 'k')))
+				} else {
+					fen += string(rune(let(color == White, 'A', 'a') + col(square)))
+				}
+			}
+			if p.castles & castleQueenside[color] != 0 {
+				if square := c.rookQueen[color]; square == p.outerRook(color, false) {
+					fen += string(rune(let(color == White, 'Q', 'q')))
+				} else {
+					fen += string(rune(let(color == White, 'A', 'a') + col(square)))
+				}
+			}
 		}
 	} else {
 		fen += ` -`
@@ -398,9 +444,6 @@ func (p *Position) fen() (fen string) {
 
 // Encodes position as DCF string (Donna Chess Format).
 func (p *Position) dcf() string {
-	fancy := engine.fancy
-	engine.fancy = false; defer func() { engine.fancy = fancy }()
-
 	encode := func (square int) string {
 		var buffer bytes.Buffer
 
@@ -466,6 +509,7 @@ func (p *Position) dcf() string {
 }
 
 func (p *Position) String() string {
+	fancy := p.engine().fancy
 	buffer := bytes.NewBufferString("  a b c d e f g h  " + C(p.color) + " to move")
 	if !p.isInCheck(p.color) {
 		buffer.WriteString("\n")
@@ -476,10 +520,12 @@ func (p *Position) String() string {
 		buffer.WriteByte('1' + byte(row))
 		for col := 0; col <= 7; col++ {
 			buffer.WriteByte(' ')
-			if piece := p.pieces[square(row, col)]; !piece.nil() {
-				buffer.WriteString(piece.String())
-			} else {
+			if piece := p.pieces[square(row, col)]; piece.nil() {
 				buffer.WriteString("\u22C5")
+			} else if fancy {
+				buffer.WriteString(piece.fancy())
+			} else {
+				buffer.WriteString(piece.String())
 			}
 		}
 		buffer.WriteByte('\n')
@@ -4,7 +4,14 @@
 
 package donna
 
-import `unsafe`
+import (
+	`bufio`
+	`encoding/binary`
+	`fmt`
+	`io`
+	`os`
+	`unsafe`
+)
 
 const (
 	cacheNone  = uint8(0)
@@ -12,8 +19,25 @@ const (
 	cacheBeta  = uint8(2) // Lower bound.
 	cacheExact = uint8(cacheAlpha | cacheBeta)
 	cacheEntrySize = int(unsafe.Sizeof(CacheEntry{}))
+	cacheBucketSize = 4 // Entries per bucket.
+	cacheBucketBytes = cacheBucketSize * cacheEntrySize
+	cacheAging = 4 // Depth penalty per search since the entry was stored.
+	cacheVersion = 1 // Bump when hash keys, bucket indexing, or entry layout change.
 )
 
+var cacheMagic = [8]byte{ 'D', 'o', 'n', 'n', 'a', 'T', 'T', 0 }
+
+// Cache entries are shared by all search workers and are read and written
+// without locking. To detect entries torn by concurrent writes the stored id
+// is XOR-ed with the checksum of the rest of the entry; the id only matches
+// when the entry as a whole was written by the same worker.
+//
+// The workers read and write the entries without locking, which is a
+// deliberate data race: locking every probe would cost more than the rare
+// torn entry, which gets treated as a cache miss anyway. With more than one
+// thread the race detector is expected to report cache(), probeCache() and
+// hashfull(); the transposition table is the only state the workers share
+// that way.
 type CacheEntry struct {
 	id    uint32
 	move  Move
@@ -23,16 +47,63 @@ type CacheEntry struct {
 	token uint8
 }
 
-type Cache []CacheEntry
+// Entries are grouped in buckets that fit in a single 64-byte cache line.
+// The position's id picks the bucket, and the entry within the bucket gets
+// picked by matching the id or by the replacement policy.
+type CacheBucket [cacheBucketSize]CacheEntry
+type Cache []CacheBucket
+
+// Cache file header followed by the buckets: the fields are exported for
+// binary.Read(). Each entry is stored as 16 little-endian bytes: id, move,
+// score, depth, flags, token, and two bytes of padding.
+type CacheHeader struct {
+	Magic   [8]byte  // "DonnaTT\0".
+	Version uint32   // Hash scheme version, see cacheVersion.
+	Entries uint32   // Entries per bucket.
+	Buckets uint64   // Number of buckets, i.e. the table size.
+	Token   uint8    // Cache expiration token at the time of saving.
+	_       [7]byte  // Reserved.
+}
 
-func cacheUsage() (hits int) {
-	for i := 0; i < len(game.cache); i++ {
-		if game.cache[i].id != uint32(0) {
-			hits++
+// Returns an estimate of cache usage in per mille as expected by UCI "info
+// hashfull". Only the entries stored by the current search within the first
+// thousand entries are counted.
+func (game *Game) hashfull() (hits int) {
+	buckets := min(len(game.cache), 1000 / cacheBucketSize)
+	for i := 0; i < buckets; i++ {
+		for _, entry := range game.cache[i] {
+			if !entry.nil() && entry.token == game.token {
+				hits++
+			}
 		}
 	}
+	if buckets == 0 {
+		return 0
+	}
+
+	return hits * 1000 / (buckets * cacheBucketSize)
+}
 
-	return hits
+// Returns true if the entry is a cache miss.
+func (entry CacheEntry) nil() bool {
+	return entry.flags == cacheNone
+}
+
+// Folds entry's payload into 32 bits.
+func (entry CacheEntry) checksum() uint32 {
+	return uint32(entry.move) ^ uint32(uint16(entry.score)) ^ uint32(uint16(entry.depth)) << 16 ^
+	       uint32(entry.flags) ^ uint32(entry.token) << 8
+}
+
+// Returns entry's worth when deciding which entry of the bucket to replace:
+// each search that has passed since the entry was stored costs as much as
+// cacheAging plies of depth. Empty entries are worth nothing.
+func (entry CacheEntry) worth(token uint8) int {
+	if entry.nil() {
+		return -0xFFFF
+	}
+
+	return int(entry.depth) - int(token - entry.token) * cacheAging
 }
 
 func uncache(score, ply int) int {
@@ -45,36 +116,69 @@ func uncache(score, ply int) int {
 	return score
 }
 
-// Creates new or resets existing game cache (aka transposition table).
-func NewCache(megaBytes float64) Cache {
+// Creates new or resets existing game cache (aka transposition table). The
+// existing cache gets reused if its size matches.
+func NewCache(megaBytes float64, existing Cache) Cache {
 	if megaBytes > 0.0 {
-		cacheSize := int(1024 * 1024 * megaBytes) / cacheEntrySize
-		// Cache size has changed.
-		if existing := len(game.cache); cacheSize != existing {
-			if existing == 0 {
-				// Create brand new zero-initialized cache.
-				return make(Cache, cacheSize)
-			} else {
-				// Reallocate existing cache (shrink or expand).
-				game.cache = append([]CacheEntry{}, game.cache[:cacheSize]...)
-			}
+		cacheSize := int(1024 * 1024 * megaBytes) / cacheBucketBytes
+		// Cache size has changed: create brand new zero-initialized cache.
+		if cacheSize != len(existing) {
+			return newCacheBuckets(cacheSize)
 		}
 		// Make sure the cache is all clear.
-		for i := 0; i < len(game.cache); i++ {
-			game.cache[i] = CacheEntry{}
-		}
-		return game.cache
+		existing.clear()
+		return existing
 	}
 
 	return nil
 }
 
+// Allocates zero-initialized cache of given number of buckets. The entries
+// have no pointers so they can live in a byte slice aligned on the cache line
+// boundary.
+func newCacheBuckets(cacheSize int) Cache {
+	memory := make([]byte, (cacheSize + 1) * cacheBucketBytes)
+	offset := (cacheBucketBytes - int(uintptr(unsafe.Pointer(&memory[0])) % uintptr(cacheBucketBytes))) % cacheBucketBytes
+	return unsafe.Slice((*CacheBucket)(unsafe.Pointer(&memory[offset])), cacheSize)
+}
+
+// Wipes out all cache entries.
+func (cache Cache) clear() Cache {
+	for i := 0; i < len(cache); i++ {
+		cache[i] = CacheBucket{}
+	}
+
+	return cache
+}
+
+// Returns the bucket for the position. Lower 32 bits of the id pick the
+// bucket while upper 32 bits are stored in the entry.
+func (cache Cache) bucket(id uint64) *CacheBucket {
+	return &cache[(id & 0xFFFFFFFF) * uint64(len(cache)) >> 32]
+}
+
+// Stores the search result in the bucket. The entry of the same position gets
+// updated unless it has been searched deeper by the current search. Otherwise
+// the entry with the least worth gets replaced.
 func (p *Position) cache(move Move, score, depth, ply int, flags uint8) *Position {
-	if cacheSize := len(game.cache); cacheSize > 0 {
-		index := p.id & uint64(cacheSize - 1)
-		entry := &game.cache[index]
+	if game := p.game(); len(game.cache) > 0 {
+		bucket, id := game.cache.bucket(p.id), uint32(p.id >> 32)
 
-		if depth > int(entry.depth) || game.token != entry.token {
+		slot, entry := &bucket[0], bucket[0] // <-- Might be torn, gets validated below.
+		for i := 0; i < cacheBucketSize; i++ {
+			if candidate := bucket[i]; id == candidate.id ^ candidate.checksum() {
+				slot, entry = &bucket[i], candidate
+				break
+			} else if candidate.worth(game.token) < entry.worth(game.token) {
+				slot, entry = &bucket[i], candidate
+			}
+		}
+
+		matched := id == entry.id ^ entry.checksum()
+		if !matched || depth >= int(entry.depth) || flags == cacheExact || game.token != entry.token {
+			if move != Move(0) || !matched {
+				entry.move = move
+			}
 			if score > Checkmate - MaxPly && score <= Checkmate {
 				entry.score = int16(score + ply)
 			} else if score < MaxPly - Checkmate && score >= -Checkmate {
@@ -82,35 +186,118 @@ func (p *Position) cache(move Move, score, depth, ply int, flags uint8) *Positio
 			} else {
 				entry.score = int16(score)
 			}
-			id := uint32(p.id >> 32)
-			if move != Move(0) || id != entry.id {
-				entry.move = move
-			}
 			entry.depth = int16(depth)
 			entry.flags = flags
 			entry.token = game.token
-			entry.id = id
+			entry.id = id ^ entry.checksum()
+			*slot = entry
 		}
 	}
 
 	return p
 }
 
-func (p *Position) probeCache() *CacheEntry {
-	if cacheSize := len(game.cache); cacheSize > 0 {
-		index := p.id & uint64(cacheSize - 1)
-		if entry := &game.cache[index]; entry.id == uint32(p.id >>32) {
-			return entry
+// Returns a copy of the cache entry for the position. The copy is empty if
+// there is no entry or if it has been torn by concurrent writes.
+func (p *Position) probeCache() (entry CacheEntry) {
+	if cache := p.game().cache; len(cache) > 0 {
+		bucket, id := cache.bucket(p.id), uint32(p.id >> 32)
+		for i := 0; i < cacheBucketSize; i++ {
+			if entry = bucket[i]; entry.id ^ entry.checksum() == id {
+				entry.id = id
+				return entry
+			}
 		}
 	}
 
-	return nil
+	return CacheEntry{}
 }
 
 func (p *Position) cachedMove() Move {
-	if cached := p.probeCache(); cached != nil {
-		return cached.move
+	return p.probeCache().move
+}
+
+// Saves the cache to the file so that it could be loaded back after restart.
+func (game *Game) SaveCache(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
 	}
+	defer file.Close()
 
-	return Move(0)
+	writer := bufio.NewWriter(file)
+	header := CacheHeader{ Magic: cacheMagic, Version: cacheVersion, Entries: cacheBucketSize, Buckets: uint64(len(game.cache)), Token: game.token }
+	if err = binary.Write(writer, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+
+	var buffer [cacheBucketSize * 16]byte
+	for i := range game.cache {
+		for j, entry := range game.cache[i] {
+			data := buffer[j * 16:]
+			binary.LittleEndian.PutUint32(data[0:], entry.id)
+			binary.LittleEndian.PutUint32(data[4:], uint32(entry.move))
+			binary.LittleEndian.PutUint16(data[8:], uint16(entry.score))
+			binary.LittleEndian.PutUint16(data[10:], uint16(entry.depth))
+			data[12], data[13] = entry.flags, entry.token
+		}
+		if _, err = writer.Write(buffer[:]); err != nil {
+			return err
+		}
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
+
+// Loads the cache saved by SaveCache(). Since the bucket is picked by the
+// table size the file must have been saved with the same Hash size; other
+// files are rejected leaving the cache intact. The file gets read into a
+// scratch table that replaces the cache only when all of it has been read.
+// The loaded cache survives the next new game since GUIs usually start one
+// right after setting the options.
+func (game *Game) LoadCache(fileName string) error {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := CacheHeader{}
+	if err = binary.Read(reader, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("donna: invalid cache file %q", fileName)
+	}
+	if header.Magic != cacheMagic || header.Version != cacheVersion || header.Entries != cacheBucketSize {
+		return fmt.Errorf("donna: unsupported cache file %q", fileName)
+	}
+	if header.Buckets != uint64(len(game.cache)) {
+		return fmt.Errorf("donna: cache file %q has %d buckets while the cache has %d", fileName, header.Buckets, len(game.cache))
+	}
+
+	if info, err := file.Stat(); err != nil || info.Size() != int64(binary.Size(header)) + int64(len(game.cache)) * cacheBucketSize * 16 {
+		return fmt.Errorf("donna: truncated cache file %q", fileName)
+	}
+
+	var buffer [cacheBucketSize * 16]byte
+	cache := newCacheBuckets(len(game.cache))
+	for i := range cache {
+		if _, err = io.ReadFull(reader, buffer[:]); err != nil {
+			return err
+		}
+		for j := range cache[i] {
+			data, entry := buffer[j * 16:], &cache[i][j]
+			entry.id = binary.LittleEndian.Uint32(data[0:])
+			entry.move = Move(binary.LittleEndian.Uint32(data[4:]))
+			entry.score = int16(binary.LittleEndian.Uint16(data[8:]))
+			entry.depth = int16(binary.LittleEndian.Uint16(data[10:]))
+			entry.flags, entry.token = data[12], data[13]
+		}
+	}
+	game.cache, game.token = cache, header.Token
+	game.engine.cacheLoaded = true
+
+	return nil
 }
@@ -4,11 +4,10 @@
 
 package donna
 
-import(`github.com/michaeldv/donna/expect`; `testing`)
+import(`github.com/michaeldv/donna/expect`; `io/ioutil`; `os`; `path/filepath`; `testing`; `unsafe`)
 
 func TestCache000(t *testing.T) {
-	engine.cacheSize = 0.5
-	p := NewGame().start()
+	p := NewEngine(`cache`, 0.5).NewGame().start()
 	move := NewMove(p, E2, E4)
 	p = p.makeMove(move).cache(move, 42, 1, 0, cacheExact)
 
@@ -19,3 +18,122 @@ func TestCache000(t *testing.T) {
 	expect.Eq(t, cached.flags, uint8(cacheExact))
 	expect.Eq(t, cached.id, uint32(p.id >> 32))
 }
+
+// Cache buckets fit in cache lines.
+func TestCache010(t *testing.T) {
+	cache := NewCache(0.5, nil)
+	expect.Eq(t, cacheBucketBytes, 64)
+	expect.Eq(t, len(cache), 8192)
+	expect.Eq(t, uintptr(unsafe.Pointer(&cache[0])) % 64, uintptr(0))
+	expect.Eq(t, len(NewCache(0.5, cache)), 8192)
+}
+
+// Positions that share the bucket replace the shallowest entry.
+func TestCache020(t *testing.T) {
+	p := NewEngine(`cache`, 0.5).NewGame().start()
+	for depth := 1; depth <= cacheBucketSize + 1; depth++ {
+		p.id = uint64(depth) << 32 | 42
+		p.cache(Move(depth), 0, depth, 0, cacheExact)
+	}
+
+	p.id = uint64(1) << 32 | 42
+	expect.True(t, p.probeCache().nil())
+	for depth := 2; depth <= cacheBucketSize + 1; depth++ {
+		p.id = uint64(depth) << 32 | 42
+		expect.Eq(t, p.probeCache().move, Move(depth))
+	}
+}
+
+// Entries left by previous searches lose their worth with age.
+func TestCache030(t *testing.T) {
+	p := NewEngine(`cache`, 0.5).NewGame().start()
+	for i := 1; i <= cacheBucketSize; i++ {
+		p.id = uint64(i) << 32 | 42
+		p.cache(Move(i), 0, 10 + i, 0, cacheExact)
+		p.game().token++
+	}
+
+	p.id = uint64(99) << 32 | 42
+	p.cache(Move(99), 0, 1, 0, cacheBeta)
+	expect.Eq(t, p.probeCache().move, Move(99))
+
+	p.id = uint64(1) << 32 | 42
+	expect.True(t, p.probeCache().nil())
+}
+
+// Deeper result for the same position doesn't get overwritten by shallower
+// one from the same search.
+func TestCache040(t *testing.T) {
+	p := NewEngine(`cache`, 0.5).NewGame().start()
+	move := NewMove(p, E2, E4)
+	p.cache(move, 42, 8, 0, cacheBeta)
+	p.cache(Move(0), 24, 4, 0, cacheAlpha)
+	expect.Eq(t, p.probeCache().score, int16(42))
+
+	p.cache(Move(0), 24, 8, 0, cacheAlpha)
+	expect.Eq(t, p.probeCache().score, int16(24))
+	expect.Eq(t, p.probeCache().move, move)
+}
+
+// Hash usage is sampled for entries of the current search.
+func TestCache050(t *testing.T) {
+	p := NewEngine(`cache`, 0.5).NewGame().start()
+	game := p.game()
+	expect.Eq(t, game.hashfull(), 0)
+
+	for i := 0; i < 100; i++ {
+		p.id = uint64(i) << 32 | uint64(i) << 19 // <-- Bucket #i.
+		p.cache(Move(0), 0, 1, 0, cacheAlpha)
+	}
+	expect.Eq(t, game.hashfull(), 100)
+
+	game.token++
+	expect.Eq(t, game.hashfull(), 0)
+
+	game.cache.clear()
+	p.id = 1 << 19
+	expect.True(t, p.probeCache().nil())
+}
+
+// Cache survives the round trip to the file.
+func TestCache060(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), `donna.hash`)
+	p := NewEngine(`cache`, 0.5).NewGame().start()
+	move := NewMove(p, E2, E4)
+	p.game().token = 42
+	p.cache(move, 42, 8, 0, cacheExact)
+	expect.Eq(t, p.game().SaveCache(fileName), error(nil))
+
+	info, _ := os.Stat(fileName)
+	expect.Eq(t, info.Size(), int64(32 + 8192 * 64))
+
+	q := NewEngine(`cache`, 0.5).NewGame().start()
+	expect.True(t, q.probeCache().nil())
+	expect.Eq(t, q.game().LoadCache(fileName), error(nil))
+	expect.Eq(t, q.game().token, uint8(42))
+	expect.Eq(t, q.probeCache(), p.probeCache())
+	expect.Eq(t, q.cachedMove(), move)
+}
+
+// Cache files of different size or version are rejected.
+func TestCache070(t *testing.T) {
+	dir := t.TempDir()
+	p := NewEngine(`cache`, 1).NewGame().start()
+	p.cache(NewMove(p, E2, E4), 42, 8, 0, cacheExact)
+	expect.Eq(t, p.game().SaveCache(filepath.Join(dir, `1.hash`)), error(nil))
+
+	q := NewEngine(`cache`, 0.5).NewGame().start()
+	q.cache(NewMove(q, D2, D4), 24, 8, 0, cacheExact)
+	err := q.game().LoadCache(filepath.Join(dir, `1.hash`))
+	expect.Contain(t, err.Error(), `has 16384 buckets while the cache has 8192`)
+	expect.Eq(t, q.cachedMove(), NewMove(q, D2, D4))
+
+	ioutil.WriteFile(filepath.Join(dir, `bad.hash`), make([]byte, 64), 0644)
+	expect.Contain(t, q.game().LoadCache(filepath.Join(dir, `bad.hash`)).Error(), `unsupported`)
+
+	data, _ := ioutil.ReadFile(filepath.Join(dir, `1.hash`))
+	data[17] = 0x20 // Pretend the file has 8192 buckets.
+	ioutil.WriteFile(filepath.Join(dir, `short.hash`), data, 0644)
+	expect.Contain(t, q.game().LoadCache(filepath.Join(dir, `short.hash`)).Error(), `truncated`)
+	expect.Eq(t, q.cachedMove(), NewMove(q, D2, D4))
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+// Castle setup: home squares of the king and castling rooks along with the
+// masks to validate the castle and update castle rights. In both standard
+// chess and Chess960 the castling king lands on G1 or C1 with the rook next
+// to it on F1 or D1.
+type Castling struct {
+	king        [2]int      // King's home square.
+	rookKing    [2]int      // Kingside rook's home square.
+	rookQueen   [2]int      // Queenside rook's home square.
+	gapKing     [2]Bitmask  // Squares that should be *empty* for kingside castle.
+	gapQueen    [2]Bitmask  // Squares that should be *empty* for queenside castle.
+	castleKing  [2]Bitmask  // Squares that should be *safe* for kingside castle.
+	castleQueen [2]Bitmask  // Squares that should be *safe* for queenside castle.
+	rights      [64]uint8   // Castle rights that remain after the move from or to the square.
+}
+
+// All standard chess games share the same castle setup.
+var standardCastling *Castling
+
+func newCastling() *Castling {
+	c := &Castling{}
+	for square := A1; square <= H8; square++ {
+		c.rights[square] = 0x0F
+	}
+
+	return c
+}
+
+// Sets up king and rook home squares for the given side.
+func (c *Castling) setup(color uint8, king, rookKing, rookQueen int) *Castling {
+	c.king[color], c.rookKing[color], c.rookQueen[color] = king, rookKing, rookQueen
+
+	// Kingside: king goes to G1, rook goes to F1.
+	kingTo, rookTo := G1 + 56 * int(color), F1 + 56 * int(color)
+	c.castleKing[color] = maskBlock[king][kingTo] | bit[king]
+	c.gapKing[color] = (c.castleKing[color] | maskBlock[rookKing][rookTo] | bit[rookTo]) & ^(bit[king] | bit[rookKing])
+
+	// Queenside: king goes to C1, rook goes to D1.
+	kingTo, rookTo = C1 + 56 * int(color), D1 + 56 * int(color)
+	c.castleQueen[color] = maskBlock[king][kingTo] | bit[king]
+	c.gapQueen[color] = (c.castleQueen[color] | maskBlock[rookQueen][rookTo] | bit[rookTo]) & ^(bit[king] | bit[rookQueen])
+
+	c.rights[king] &= ^(castleKingside[color] | castleQueenside[color])
+	c.rights[rookKing] &= ^castleKingside[color]
+	c.rights[rookQueen] &= ^castleQueenside[color]
+
+	return c
+}
+
+// Returns true if the setup is the one of standard chess.
+func (c *Castling) standard() bool {
+	return c.king == standardCastling.king && c.rookKing == standardCastling.rookKing && c.rookQueen == standardCastling.rookQueen
+}
+
+// Returns the square of the rook that castles with the king moving to the
+// given square.
+func (c *Castling) rook(color uint8, to int) int {
+	if col(to) == G1G8 {
+		return c.rookKing[color]
+	}
+	return c.rookQueen[color]
+}
+
+// Returns castle setup of the game the position belongs to.
+func (p *Position) castling() *Castling {
+	return p.worker.game.castling
+}
+
+// Figures out castle setup from castle rights and positions of the kings and
+// rooks. Castle rights that don't match the pieces on the board get dropped.
+// Games that start from standard setup share standard castling, Chess960
+// games get their own.
+func (p *Position) setupCastling(kingside, queenside [2]int) *Position {
+	c := newCastling()
+
+	for color := uint8(White); color <= uint8(Black); color++ {
+		home, kingRook, queenRook := standardCastling.king[color], standardCastling.rookKing[color], standardCastling.rookQueen[color]
+
+		if square := int(p.king[color]); p.outposts[king(color)].empty() || rank(color, square) != A1H1 {
+			p.castles &= ^(castleKingside[color] | castleQueenside[color])
+		} else if p.castles & (castleKingside[color] | castleQueenside[color]) != 0 {
+			home = square
+		}
+
+		if p.castles & castleKingside[color] != 0 {
+			if square := kingside[color]; square > home && row(square) == row(home) && p.pieces[square] == rook(color) {
+				kingRook = square
+			} else {
+				p.castles &= ^castleKingside[color]
+			}
+		}
+		if p.castles & castleQueenside[color] != 0 {
+			if square := queenside[color]; square >= 0 && square < home && row(square) == row(home) && p.pieces[square] == rook(color) {
+				queenRook = square
+			} else {
+				p.castles &= ^castleQueenside[color]
+			}
+		}
+		c.setup(color, home, kingRook, queenRook)
+	}
+
+	if c.standard() {
+		c = standardCastling
+	}
+	p.game().castling = c
+
+	return p
+}
+
+// Returns outermost rook on the back rank on the given side of the king, or
+// -1 if there is none.
+func (p *Position) outerRook(color uint8, kingside bool) int {
+	square := int(p.king[color])
+	rooks := p.outposts[rook(color)] & maskRank[row(square)]
+	if kingside {
+		if rooks &= maskBlock[square][H1 + 56 * int(color)]; rooks.any() {
+			return rooks.last()
+		}
+	} else if rooks &= maskBlock[square][A1 + 56 * int(color)]; rooks.any() {
+		return rooks.first()
+	}
+
+	return -1
+}
@@ -4,19 +4,10 @@
 
 package donna
 
-var exchangeScores = []int{
-	0, 0, 						// Zero score for non-capture moves.
-	valuePawn.midgame, valuePawn.midgame, 		// Pawn/BlackPawn captures.
-	valueKnight.midgame, valueKnight.midgame, 	// Knight/BlackKinght captures.
-	valueBishop.midgame, valueBishop.midgame, 	// Bishop/BlackBishop captures.
-	valueRook.midgame, valueRook.midgame, 		// Rook/BlackRook captures.
-	valueQueen.midgame, valueQueen.midgame, 	// Queen/BlackQueen captures.
-	valueQueen.midgame * 8, valueQueen.midgame * 8, // King/BlackKing specials.
-}
-
 // Static exchange evaluation.
 func (p *Position) exchange(move Move) int {
 	from, to, piece, capture := move.split()
+	exchangeScores := &p.worker.profile.exchangeScores
 
 	score := exchangeScores[capture]
 	if promo := move.promo(); !promo.nil() {
@@ -35,7 +26,7 @@ func (p *Position) exchangeScore(color uint8, to, score, extra int, board Bitmas
 		return score
 	}
 
-	from, best := 0, Checkmate
+	from, best, exchangeScores := 0, Checkmate, &p.worker.profile.exchangeScores
 	for attackers.any() {
 		square := attackers.pop()
 		if index := p.pieces[square]; exchangeScores[index] < best {
@@ -6,8 +6,8 @@ package donna
 
 func (p *Position) movePiece(piece Piece, from, to int) *Position {
 	p.pieces[from], p.pieces[to] = 0, piece
-	p.outposts[piece] ^= bit[from] | bit[to]
-	p.outposts[piece.color()] ^= bit[from] | bit[to]
+	p.outposts[piece] ^= bit[from] ^ bit[to] // Chess960 castle might leave the king where it was.
+	p.outposts[piece.color()] ^= bit[from] ^ bit[to]
 
 	// Update position's hash values.
 	random := piece.polyglot(from) ^ piece.polyglot(to)
@@ -16,8 +16,12 @@ func (p *Position) movePiece(piece Piece, from, to int) *Position {
 		p.pawnId ^= random
 	}
 
-	// Update positional score.
+	// Update positional score and network sums.
+	pst := &p.worker.profile.pst
 	p.tally.sub(pst[piece][from]).add(pst[piece][to])
+	if acc := p.accumulator(); acc != nil {
+		acc.move(piece, from, to)
+	}
 
 	return p
 }
@@ -34,8 +38,12 @@ func (p *Position) promotePawn(pawn Piece, from, to int, promo Piece) *Position
 	p.pawnId ^= random
 	p.balance += materialBalance[promo] - materialBalance[pawn]
 
-	// Update positional score.
+	// Update positional score and network sums.
+	pst := &p.worker.profile.pst
 	p.tally.sub(pst[pawn][from]).add(pst[promo][to])
+	if acc := p.accumulator(); acc != nil {
+		acc.sub(pawn, from).add(promo, to)
+	}
 
 	return p
 }
@@ -52,8 +60,11 @@ func (p *Position) capturePiece(capture Piece, from, to int) *Position {
 	}
 	p.balance -= materialBalance[capture]
 
-	// Update positional score.
-	p.tally.sub(pst[capture][to])
+	// Update positional score and network sums.
+	p.tally.sub(p.worker.profile.pst[capture][to])
+	if acc := p.accumulator(); acc != nil {
+		acc.sub(capture, to)
+	}
 
 	return p
 }
@@ -71,8 +82,11 @@ func (p *Position) captureEnpassant(capture Piece, from, to int) *Position {
 	p.pawnId ^= random
 	p.balance -= materialBalance[capture]
 
-	// Update positional score.
-	p.tally.sub(pst[capture][enpassant])
+	// Update positional score and network sums.
+	p.tally.sub(p.worker.profile.pst[capture][enpassant])
+	if acc := p.accumulator(); acc != nil {
+		acc.sub(capture, enpassant)
+	}
 
 	return p
 }
@@ -82,9 +96,11 @@ func (p *Position) makeMove(move Move) *Position {
 	from, to, piece, capture := move.split()
 
 	// Copy over the contents of previous tree node to the current one.
-	node++
-	tree[node] = *p // => tree[node] = tree[node - 1]
-	pp := &tree[node]
+	w := p.worker
+	w.node++
+	w.tree[w.node] = *p // => tree[node] = tree[node - 1]
+	pp := &w.tree[w.node]
+	w.inheritAccumulator(p)
 
 	pp.enpassant, pp.reversible = 0, true
 	pp.count50++
@@ -106,16 +122,14 @@ func (p *Position) makeMove(move Move) *Position {
 			pp.king[color] = uint8(to)
 			if move.isCastle() {
 				pp.reversible = false
-				switch to {
-				case G1:
-					pp.movePiece(Rook, H1, F1)
-				case C1:
-					pp.movePiece(Rook, A1, D1)
-				case G8:
-					pp.movePiece(BlackRook, H8, F8)
-				case C8:
-					pp.movePiece(BlackRook, A8, D8)
-				}
+
+				// In Chess960 the king and the rook might land on each
+				// other's home squares so put them back on the board
+				// once both have moved.
+				rook, square := rook(color), p.castling().rook(color, to)
+				target := let(col(to) == G1G8, F1, D1) + 56 * int(color)
+				pp.movePiece(rook, square, target)
+				pp.pieces[to], pp.pieces[target] = piece, rook
 			}
 		} else if piece.isPawn() {
 			pp.count50, pp.reversible = 0, false
@@ -132,21 +146,27 @@ func (p *Position) makeMove(move Move) *Position {
 	// Set up the board bitmask, update castle rights, finish off incremental
 	// hash value, and flip the color.
 	pp.board = pp.outposts[White] | pp.outposts[Black]
-	pp.castles &= castleRights[from] & castleRights[to]
+	pp.castles &= p.castling().rights[from] & p.castling().rights[to]
 	pp.id ^= hashCastle[p.castles] ^ hashCastle[pp.castles]
 	pp.id ^= polyglotRandomWhite
 	pp.color ^= 1 // <-- Flip side to move.
 	pp.score = Unknown
+	pp.last = move
+	if acc := pp.accumulator(); acc != nil {
+		acc.id = pp.id
+	}
 
-	return &tree[node] // pp
+	return pp
 }
 
 // Makes "null" move by copying over previous node position (i.e. preserving all pieces
 // intact) and flipping the color.
 func (p *Position) makeNullMove() *Position {
-	node++
-	tree[node] = *p // => tree[node] = tree[node - 1]
-	pp := &tree[node]
+	w := p.worker
+	w.node++
+	w.tree[w.node] = *p // => tree[node] = tree[node - 1]
+	pp := &w.tree[w.node]
+	w.inheritAccumulator(p)
 
 	// Flipping side to move obviously invalidates the enpassant square.
 	if pp.enpassant != 0 {
@@ -156,16 +176,47 @@ func (p *Position) makeNullMove() *Position {
 	pp.id ^= polyglotRandomWhite
 	pp.color ^= 1 // <-- Flip side to move.
 	pp.count50++
+	pp.last = Move(0)
+	if acc := pp.accumulator(); acc != nil {
+		acc.id = pp.id
+	}
 
-	return &tree[node] // pp
+	return pp
+}
+
+// Makes the move in the game rather than in the search tree: the positions
+// that precede the new one go to the game history, and the new position
+// becomes the root of the search tree.
+func (p *Position) playMove(move Move) *Position {
+	w := p.worker
+	p.makeMove(move)
+	w.past = append(w.past, w.tree[:w.node]...)
+	w.tree[0], w.node, w.rootNode = w.tree[w.node], 0, 0
+
+	return w.position()
+}
+
+// Takes back the last move made in the game restoring previous position from
+// the game history.
+func (p *Position) takeBack() *Position {
+	w := p.worker
+	if w.node > 0 {
+		return p.undoLastMove()
+	}
+	if last := len(w.past) - 1; last >= 0 {
+		w.tree[0], w.past, w.rootNode = w.past[last], w.past[:last], 0
+	}
+
+	return w.position()
 }
 
 // Restores previous position effectively taking back the last move made.
 func (p *Position) undoLastMove() *Position {
-	if node > 0 {
-		node--
+	w := p.worker
+	if w.node > 0 {
+		w.node--
 	}
-	return &tree[node]
+	return w.position()
 }
 
 func (p *Position) undoNullMove() *Position {
@@ -181,23 +232,49 @@ func (p *Position) isInCheck(color uint8) bool {
 }
 
 func (p *Position) isNull() bool {
-	return node > 0 && tree[node].board == tree[node-1].board
+	w := p.worker
+	return w.node > 0 && w.tree[w.node].board == w.tree[w.node-1].board
+}
+
+// Returns a distance between current node and the root one.
+func (p *Position) ply() int {
+	return p.worker.ply()
+}
+
+// Returns the game the position belongs to.
+func (p *Position) game() *Game {
+	return p.worker.game
+}
+
+// Returns the engine that plays position's game.
+func (p *Position) engine() *Engine {
+	return p.worker.game.engine
 }
 
 func (p *Position) fifty() bool {
 	return p.count50 >= 100
 }
 
+// Returns the draw score from the point of view of the side to move: the
+// engine's side gets the contempt penalty while the opponent gets the bonus.
+func (p *Position) drawScore() int {
+	return let(p.ply() % 2 == 0, p.game().draw, -p.game().draw)
+}
+
+// Repetitions are looked up in the search stack followed by the game
+// history going back till the last irreversible move.
 func (p *Position) repetition() bool {
+	w, node := p.worker, p.worker.played()
 	if !p.reversible || node < 1 {
 		return false
 	}
 
 	for previous := node - 1; previous >= 0; previous-- {
-		if !tree[previous].reversible {
+		position := w.recall(previous)
+		if !position.reversible {
 			return false
 		}
-		if tree[previous].id == p.id {
+		if position.id == p.id {
 			return true
 		}
 	}
@@ -206,15 +283,16 @@ func (p *Position) repetition() bool {
 }
 
 func (p *Position) thirdRepetition() bool {
+	w, node := p.worker, p.worker.played()
 	if !p.reversible || node < 4 {
 		return false
 	}
 
 	for previous, repetitions := node - 2, 1; previous >= 0; previous -= 2 {
-		if !tree[previous].reversible || !tree[previous + 1].reversible {
+		if !w.recall(previous).reversible || !w.recall(previous + 1).reversible {
 			return false
 		}
-		if tree[previous].id == p.id {
+		if w.recall(previous).id == p.id {
 			repetitions++
 			if repetitions == 3 {
 				return true
@@ -228,17 +306,28 @@ func (p *Position) thirdRepetition() bool {
 // Returns a pair of booleans that indicate whether given side is allowed to
 // castle kingside and queenside.
 func (p *Position) canCastle(color uint8) (kingside, queenside bool) {
+	c := p.castling()
 
 	// Start off with simple checks.
-	kingside = (p.castles & castleKingside[color] != 0) && (gapKing[color] & p.board == 0)
-	queenside = (p.castles & castleQueenside[color] != 0) && (gapQueen[color] & p.board == 0)
+	kingside = (p.castles & castleKingside[color] != 0) && (c.gapKing[color] & p.board == 0)
+	queenside = (p.castles & castleQueenside[color] != 0) && (c.gapQueen[color] & p.board == 0)
 
 	// If it still looks like the castles are possible perform more expensive
 	// final check.
 	if kingside || queenside {
 		attacks := p.allAttacks(color^1)
-		kingside = kingside && (castleKing[color] & attacks == 0)
-		queenside = queenside && (castleQueen[color] & attacks == 0)
+		kingside = kingside && (c.castleKing[color] & attacks == 0)
+		queenside = queenside && (c.castleQueen[color] & attacks == 0)
+
+		// In Chess960 the castling rook might shield the king's target
+		// square from enemy rook or queen.
+		sliders := p.outposts[rook(color^1)] | p.outposts[queen(color^1)]
+		if kingside {
+			kingside = (p.rookMovesAt(G1 + 56 * int(color), p.board ^ bit[c.rookKing[color]]) & sliders).empty()
+		}
+		if queenside {
+			queenside = (p.rookMovesAt(C1 + 56 * int(color), p.board ^ bit[c.rookQueen[color]]) & sliders).empty()
+		}
 	}
 
 	return kingside, queenside
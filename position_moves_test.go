@@ -259,11 +259,11 @@ func TestPositionMoves130(t *testing.T) {
 
 	// White rook is zigzaging while black king bounces back and forth.
 	for move := 1; move < len(squares); move++ {
-		p = p.makeMove(NewMove(p, squares[move-1], squares[move]))
+		p = p.playMove(NewMove(p, squares[move-1], squares[move]))
 		if p.king[Black] == A8 {
-			p = p.makeMove(NewMove(p, A8, B8))
+			p = p.playMove(NewMove(p, A8, B8))
 		} else {
-			p = p.makeMove(NewMove(p, B8, A8))
+			p = p.playMove(NewMove(p, B8, A8))
 		}
 
 		expect.Eq(t, p.fifty(), move >= 50)
@@ -613,3 +613,93 @@ func TestPositionMoves410(t *testing.T) {
 	expect.True(t, position.isInCheck(position.color))
 	expect.True(t, position.isInCheck(p.color^1))
 }
+
+// Chess960 castles.
+func TestPositionMoves500(t *testing.T) { // King stays on G1.
+	p := NewGame(`6k1/8/8/8/8/8/8/6KR w H - 0 1`).start()
+	kingside, queenside := p.canCastle(p.color)
+	expect.True(t, kingside)
+	expect.False(t, queenside)
+
+	p = p.makeMove(NewCastle(p, G1, G1))
+	expect.Eq(t, p.pieces[G1], Piece(King))
+	expect.Eq(t, p.pieces[F1], Piece(Rook))
+	expect.Eq(t, p.pieces[H1], Piece(0))
+	expect.Eq(t, p.outposts[King], bit[G1])
+	expect.Eq(t, p.outposts[Rook], bit[F1])
+	expect.Eq(t, p.outposts[White], bit[F1]|bit[G1])
+	expect.Eq(t, p.castles, uint8(0))
+}
+
+func TestPositionMoves510(t *testing.T) { // King and rook swap places.
+	p := NewGame(`6k1/8/8/8/8/8/8/5KR1 w G - 0 1`).start()
+	p = p.makeMove(NewCastle(p, F1, G1))
+	expect.Eq(t, p.pieces[G1], Piece(King))
+	expect.Eq(t, p.pieces[F1], Piece(Rook))
+	expect.Eq(t, p.outposts[King], bit[G1])
+	expect.Eq(t, p.outposts[Rook], bit[F1])
+	expect.Eq(t, p.outposts[White], bit[F1]|bit[G1])
+}
+
+func TestPositionMoves520(t *testing.T) { // Queenside castle from B1.
+	p := NewGame(`6k1/8/8/8/8/8/8/RK6 w A - 0 1`).start()
+	kingside, queenside := p.canCastle(p.color)
+	expect.False(t, kingside)
+	expect.True(t, queenside)
+
+	p = p.makeMove(NewCastle(p, B1, C1))
+	expect.Eq(t, p.pieces[C1], Piece(King))
+	expect.Eq(t, p.pieces[D1], Piece(Rook))
+	expect.Eq(t, p.outposts[White], bit[C1]|bit[D1])
+}
+
+func TestPositionMoves530(t *testing.T) { // Castling rook shields C1 from enemy rook.
+	p := NewGame(`4k3/8/8/8/8/8/8/rR2K3 w B - 0 1`).start()
+	kingside, queenside := p.canCastle(p.color)
+	expect.False(t, kingside)
+	expect.False(t, queenside)
+
+	p = NewGame(`4k3/8/8/8/8/8/8/1R2K3 w B - 0 1`).start()
+	kingside, queenside = p.canCastle(p.color)
+	expect.False(t, kingside)
+	expect.True(t, queenside)
+}
+
+// Game history grows as needed: knights dancing for 1200 plies.
+func TestPositionMoves600(t *testing.T) {
+	p := NewGame().start()
+	initial := p.id
+	dance := [][2]int{ { G1, F3 }, { G8, F6 }, { F3, G1 }, { F6, G8 } }
+	for ply := 0; ply < 1200; ply++ {
+		p = p.playMove(NewMove(p, dance[ply % 4][0], dance[ply % 4][1]))
+	}
+	expect.Eq(t, len(p.worker.past), 1200)
+	expect.Eq(t, p.worker.node, 0)
+	expect.Eq(t, p.id, initial)
+	expect.True(t, p.thirdRepetition())
+
+	p = p.takeBack().takeBack()
+	expect.Eq(t, len(p.worker.past), 1198)
+	expect.Eq(t, p.pieces[F3], Piece(Knight))
+	expect.Eq(t, p.pieces[F6], Piece(BlackKnight))
+	expect.Eq(t, p.color, uint8(White))
+}
+
+// Search stack sees repetitions of the positions from the game history.
+func TestPositionMoves610(t *testing.T) {
+	p := NewGame().start()
+	p = p.playMove(NewMove(p, G1, F3))
+	p = p.playMove(NewMove(p, G8, F6))
+	expect.False(t, p.repetition())
+
+	p = p.makeMove(NewMove(p, F3, G1)) // Search tree from now on.
+	p = p.makeMove(NewMove(p, F6, G8))
+	expect.True(t, p.repetition())
+	expect.False(t, p.thirdRepetition())
+	expect.Eq(t, p.worker.node, 2)
+
+	p = p.undoLastMove().undoLastMove()
+	p = p.playMove(NewMove(p, E2, E4)) // Irreversible.
+	p = p.makeMove(NewMove(p, F6, G8))
+	expect.False(t, p.repetition())
+}
@@ -122,8 +122,8 @@ func TestPosition200(t *testing.T) {
 // Mate in 1 move.
 func TestPosition210(t *testing.T) {
 	p := NewGame(`Kf8,Rh1,g6`, `Kh8,Bg8,g7,h7`).start()
-	rootNode = node // Reset ply().
-	expect.Eq(t, p.status(NewMove(p, H1, H6), Checkmate - ply()), WhiteWinning)
+	p.worker.rootNode = p.worker.node // Reset ply().
+	expect.Eq(t, p.status(NewMove(p, H1, H6), Checkmate - p.ply()), WhiteWinning)
 }
 
 // Forced stalemate.
@@ -153,7 +153,7 @@ func TestPosition240(t *testing.T) {
 	p = p.makeMove(NewMove(p, A1, A2))
 	p = p.makeMove(NewMove(p, H6, H5)) // -- No NewMove(p, A2, A1) here --
 
-	rootNode = node // Reset ply().
+	p.worker.rootNode = p.worker.node // Reset ply().
 	expect.Eq(t, p.status(NewMove(p, A2, A1), 0), Repetition) // <-- Ka2-a1 causes rep #3.
 }
 
@@ -183,3 +183,106 @@ func TestPosition310(t *testing.T) {
 	expect.Eq(t, p.Evaluate(), -320)
 
 }
+
+// Chess960: Shredder-FEN castle rights.
+func TestPosition400(t *testing.T) {
+	p := NewGame(`bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9`).start()
+	expect.Eq(t, p.castles, uint8(0x0F))
+	expect.Eq(t, p.castling().rookKing, [2]int{ H1, H8 })
+	expect.Eq(t, p.castling().rookQueen, [2]int{ F1, F8 })
+	expect.Eq(t, p.castling().king, [2]int{ G1, G8 })
+	expect.Eq(t, p.fen(), `bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w KQkq - 2 1`)
+}
+
+// Chess960: X-FEN castle rights with inner rooks.
+func TestPosition410(t *testing.T) {
+	p := NewGame(`rk2r2r/8/8/8/8/8/8/RK2R2R w EQeq - 0 1`).start()
+	expect.Eq(t, p.castles, uint8(0x0F))
+	expect.Eq(t, p.castling().rookKing, [2]int{ E1, E8 })
+	expect.Eq(t, p.castling().rookQueen, [2]int{ A1, A8 })
+	expect.Eq(t, p.fen(), `rk2r2r/8/8/8/8/8/8/RK2R2R w EQeq - 0 1`)
+}
+
+// Castle rights that don't match the pieces get dropped; standard setup is shared.
+func TestPosition420(t *testing.T) {
+	p := NewGame(`r3k2r/8/8/8/8/8/8/R3K2R w KQkq - 0 1`).start()
+	expect.True(t, p.castling() == standardCastling)
+
+	p = NewGame(`4k3/8/8/8/8/8/8/R3K3 w KQc - 0 1`).start()
+	expect.Eq(t, p.castles, castleQueenside[White])
+	expect.True(t, p.castling() == standardCastling)
+}
+
+// Contempt: the engine's side gets the draw penalty, the opponent gets the bonus.
+func TestPosition500(t *testing.T) {
+	game := NewEngine(`contempt`, 30).NewGame()
+	p := game.start()
+	game.getReady()
+	expect.Eq(t, p.drawScore(), -30)
+	p = p.makeMove(NewMove(p, E2, E4))
+	expect.Eq(t, p.drawScore(), 30)
+	p = p.makeMove(NewMove(p, E7, E5))
+	expect.Eq(t, p.drawScore(), -30)
+}
+
+// Dynamic contempt fades away as the material comes off the board.
+func TestPosition510(t *testing.T) {
+	engine := NewEngine(`contempt`, 40)
+	engine.dynamic = true
+	game := engine.NewGame()
+	game.start(); game.getReady()
+	expect.Eq(t, game.draw, -40)
+
+	game = engine.NewGame(`Kg1,Rd1,a2,b2`, `Kg8,Re8,a7,b7`)
+	game.start(); game.getReady()
+	expect.Eq(t, game.draw, -40 * 36 / 256)
+
+	game = engine.NewGame(`Kg1,a2,b2`, `Kg8,a7,b7`)
+	game.start(); game.getReady()
+	expect.Eq(t, game.draw, 0)
+}
+
+// Stalemate is recognized when the draw score includes contempt or gets off
+// by handicap noise.
+func TestPosition520(t *testing.T) {
+	game := NewEngine(`contempt`, 50).NewGame(`Kf7,b2,b4,h6`, `Kh8,Ba4,b3,b5,h7`)
+	p := game.start()
+	game.getReady()
+	expect.Eq(t, p.status(NewMove(p, F7, F8), -50), Stalemate)
+	expect.Eq(t, p.status(NewMove(p, F7, F8), -37), Stalemate)
+	expect.Eq(t, p.status(NewMove(p, F7, E7), -37), InProgress)
+}
+
+// Draw by repetition is recognized regardless of the score.
+func TestPosition530(t *testing.T) {
+	p := NewGame(`Ka1,g3,h2`, `M,Kh5,h3,g4,g5,g6,h7`).start()
+	for i := 0; i < 2; i++ {
+		p = p.makeMove(NewMove(p, H5, H6))
+		p = p.makeMove(NewMove(p, A1, A2))
+		p = p.makeMove(NewMove(p, H6, H5))
+		if i == 0 {
+			p = p.makeMove(NewMove(p, A2, A1))
+		}
+	}
+
+	p.worker.rootNode = p.worker.node // Reset ply().
+	expect.Eq(t, p.status(NewMove(p, A2, A1), 12), Repetition)
+}
+
+// Material entry of the position outside of material table gets created from
+// the actual pieces rather than looked up by overflowed index.
+func TestPosition540(t *testing.T) {
+	p := NewGame(`Ke1,Qd1,Qd2`, `Ke8`).start()
+	expect.True(t, p.promoted())
+	expect.Eq(t, p.material().flags, uint8(knownEndgame))
+	expect.Eq(t, p.material().phase, 88)
+
+	p = NewGame(`Ke1,Nb1,Nc3,Ng1`, `Ke8,Nb8`).start()
+	expect.True(t, p.promoted())
+	expect.False(t, p.insufficient())
+	expect.True(t, p.Evaluate() > 0)
+
+	p = NewGame(`Ke1,Nb1,Ng1`, `Ke8`).start()
+	expect.False(t, p.promoted())
+	expect.Eq(t, p.material(), &materialBase[p.balance])
+}
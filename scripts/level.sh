@@ -0,0 +1,32 @@
+#!/usr/bin/env bash
+#
+usage() {
+  echo "Usage: ./level.sh \$1 \$2 \$3 [\$4]"
+  echo "Where \$1 - number of games to play at each Elo level"
+  echo "      \$2 - command to launch Donna"
+  echo "      \$3 - number of nodes per move for both engines"
+  echo "      \$4 - openings .epd file name (optional)"
+  echo "Example:"
+  echo "\$ ./level.sh 100 ../donna 20000 ./mfl.epd"
+  echo
+}
+
+if [ -z "$CUTE" ]; then
+  echo "Please set CUTE environment variable to point to cutechess-cli executable."
+  echo "See https://github.com/cutechess/cutechess/blob/master/projects/cli/res/doc/help.txt for details."
+  exit 1
+fi
+
+if [ $# -lt 3 ] || [ $# -gt 4 ]; then
+  usage
+else
+  openings=""
+  if [ $# -eq 4 ]; then
+    openings="-openings file=$4 format=epd"
+  fi
+  for elo in 1000 1200 1400 1600 1800 2000 2200 2400 2600 2800; do
+    cmd="$CUTE -games $1 -engine cmd=$2 name=Donna-$elo option.UCI_LimitStrength=true option.UCI_Elo=$elo -engine cmd=$2 name=Donna -each tc=inf nodes=$3 proto=uci -draw movenumber=40 movecount=8 score=0 -resign movecount=8 score=350 -pgnout /tmp/level-$elo.pgn -repeat $openings"
+    echo $cmd
+    eval $cmd
+  done
+fi
@@ -7,23 +7,32 @@ package donna
 // Root node search. Basic principle is expressed by Boob's Law: you always find
 // something in the last place you look.
 func (p *Position) search(alpha, beta, depth int) (score int) {
-	ply, inCheck := ply(), p.isInCheck(p.color)
+	ply, inCheck := p.ply(), p.isInCheck(p.color)
+	game, engine := p.game(), p.engine()
 
 	// Root move generator makes sure all generated moves are valid. The
-	// best move found so far is always the first one we search.
-	gen := NewRootGen(p, depth)
-	if depth == 1 {
+	// best move found so far is always the first one we search. In MultiPV
+	// mode we skip the moves of the lines found at this depth so far, and
+	// reuse root moves generated for the first line. Within the tablebase
+	// we only search the moves that preserve the best outcome.
+	first := p.worker.multipv
+	gen := NewRootGen(p, let(first == 0, depth, 0))
+	if depth == 1 && first == 0 {
 		gen.generateRootMoves()
+		if tb := engine.tablebase(); gen.size() > 1 && p.tbProbable(tb, engine.syzygyLimit) {
+			gen.tablebaseRootMoves(tb)
+		}
 	} else {
 		gen.reset()
+		gen.head = first
 	}
 
 	bestAlpha, bestScore := alpha, alpha
 	bestMove, moveCount := Move(0), 0
 	for move := gen.NextMove(); !move.nil(); move = gen.NextMove() {
 		position := p.makeMove(move)
-		moveCount++; game.nodes++
-		if engine.uci {
+		moveCount++; p.worker.nodes.Add(1)
+		if engine.uci && p.worker.isMain() {
 			engine.uciMove(move, moveCount, depth)
 		}
 
@@ -31,43 +40,57 @@ func (p *Position) search(alpha, beta, depth int) (score int) {
 		giveCheck := position.isInCheck(position.color)
 		newDepth := let(giveCheck && p.exchange(move) >= 0, depth, depth - 1)
 
+		// Handicapped engine adds noise to root move scores by shifting
+		// the search window, and occasionally searches the move too
+		// shallow to see the tactics.
+		noise, blunder := game.handicap(move)
+		if blunder && moveCount > 1 {
+			newDepth /= 2
+		}
+		lo, hi := max(alpha - noise, -Checkmate), min(beta - noise, Checkmate)
+
 		// Start search with full window.
-		game.deepening = (moveCount == 1)
+		if p.worker.isMain() && first == 0 {
+			game.deepening = (moveCount == 1)
+		}
 		if moveCount == 1 {
-			score = -position.searchTree(-beta, -alpha, newDepth)
+			score = -position.searchTree(-hi, -lo, newDepth)
 		} else {
 			reduction := 0
-			if !inCheck && !giveCheck && depth > 2 && move.isQuiet() && !move.isKiller(ply) && !move.isPawnAdvance() {
+			if !inCheck && !giveCheck && depth > 2 && move.isQuiet() && !p.worker.isKiller(move, ply) && !move.isPawnAdvance() {
 				reduction = lateMoveReductions[min(63, moveCount-1)][min(63, depth)]
-				if game.history[move.piece()][move.to()] < 0 {
+				if p.worker.good(move) < 0 {
 					reduction++
 				}
 			}
 
-			score = -position.searchTree(-alpha - 1, -alpha, max(0, newDepth - reduction))
+			score = -position.searchTree(-lo - 1, -lo, max(0, newDepth - reduction))
 
 			// Verify late move reduction and re-run the search if necessary.
-			if reduction > 0 && score > alpha {
-				score = -position.searchTree(-alpha - 1, -alpha, newDepth)
+			if reduction > 0 && score > lo {
+				score = -position.searchTree(-lo - 1, -lo, newDepth)
 			}
 
 			// If zero window fails then try full window.
-			if score > alpha {
-				score = -position.searchTree(-beta, -alpha, newDepth)
+			if score > lo {
+				score = -position.searchTree(-hi, -lo, newDepth)
 			}
 		}
 		position.undoLastMove()
+		if !isMate(score) {
+			score += noise
+		}
 
 		// Don't touch anything if the time has elapsed and we need to abort th search.
-		if engine.clock.halt {
+		if engine.clock.halt.Load() {
 			return alpha
 		}
 
 		if moveCount == 1 || score > alpha {
 			bestMove = move
-			game.saveBest(0, move)
-			gen.scoreMove(depth, score).rearrangeRootMoves()
-			if moveCount > 1 {
+			p.worker.saveBest(0, move)
+			gen.scoreMove(depth, score).rearrangeRootMovesFrom(first)
+			if moveCount > 1 && p.worker.isMain() && first == 0 {
 				game.volatility++
 			}
 		} else {
@@ -77,14 +100,14 @@ func (p *Position) search(alpha, beta, depth int) (score int) {
 		if score > bestScore {
 			bestScore = score
 			if score > alpha {
-				game.saveBest(ply, move)
+				p.worker.saveBest(ply, move)
 				if score < beta {
 					alpha = score
 					bestMove = move
 				} else {
 					p.cache(move, score, depth, ply, cacheBeta)
 					if !inCheck && alpha > bestAlpha {
-						game.saveGood(depth, bestMove).updatePoor(depth, bestMove, gen.reset())
+						p.worker.saveGood(depth, bestMove).updatePoor(depth, bestMove, gen.reset())
 					}
 					return score
 				}
@@ -94,8 +117,8 @@ func (p *Position) search(alpha, beta, depth int) (score int) {
 
 
 	if moveCount == 0 {
-		score = let(inCheck, -Checkmate, 0) // Mate if in check, stalemate otherwise.
-		if engine.uci {
+		score = let(inCheck, -Checkmate, p.drawScore()) // Mate if in check, stalemate otherwise.
+		if engine.uci && p.worker.isMain() {
 			engine.uciScore(depth, score, alpha, beta)
 		}
 		return score
@@ -103,7 +126,7 @@ func (p *Position) search(alpha, beta, depth int) (score int) {
 	score = bestScore
 
 	if !inCheck && alpha > bestAlpha {
-		game.saveGood(depth, bestMove).updatePoor(depth, bestMove, gen.reset())
+		p.worker.saveGood(depth, bestMove).updatePoor(depth, bestMove, gen.reset())
 	}
 
 	cacheFlags := cacheAlpha
@@ -112,9 +135,11 @@ func (p *Position) search(alpha, beta, depth int) (score int) {
 	} else if bestMove != Move(0) {
 		cacheFlags = cacheExact
 	}
-	p.cache(bestMove, score, depth, ply, cacheFlags)
-	if engine.uci {
-		engine.uciScore(depth, score, alpha, beta)
+	if first == 0 {
+		p.cache(bestMove, score, depth, ply, cacheFlags)
+		if engine.uci && p.worker.isMain() {
+			engine.uciScore(depth, score, alpha, beta)
+		}
 	}
 
 	return
@@ -126,7 +151,7 @@ func (p *Position) solve(depth int) Move {
 		NewRootGen(p, 1).generateRootMoves()
 	}
 	p.search(-Checkmate, Checkmate, depth)
-	return game.pv[0].moves[0]
+	return p.worker.pv[0].moves[0]
 }
 
 func (p *Position) Perft(depth int) (total int64) {
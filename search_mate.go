@@ -0,0 +1,235 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (`context`; `errors`; `fmt`; `time`)
+
+// Longest mate the solver looks for: the mating line must fit into the
+// search tree.
+const MaxMate = MaxPly / 2 - 1
+
+type Proof struct {
+	moves int   // Mate in X moves or less.
+	move  Move  // Mating move.
+}
+
+// Mate solver remembers the positions where the mate has been proven or
+// disproven so that transpositions don't get searched twice.
+type MateSolver struct {
+	p         *Position
+	proven    map[uint64]Proof  // Position is mate in X moves or less.
+	disproven map[uint64]int    // Position has no mate in X moves or less.
+}
+
+func NewMateSolver(p *Position) *MateSolver {
+	return &MateSolver{ p: p, proven: map[uint64]Proof{}, disproven: map[uint64]int{} }
+}
+
+// Looks for the shortest forced mate in up to given number of moves and
+// returns verified mating line where the defender puts up the longest
+// resistance. No line means there is no mate within given number of moves
+// or the search has been stopped.
+func (ms *MateSolver) solve(moves int) (mate int, line []Move) {
+	p, engine := ms.p, ms.p.engine()
+	p.worker.rootNode = p.worker.node
+
+	for n := 1; n <= min(moves, MaxMate) && !engine.clock.halt.Load(); n++ {
+		if move := ms.attack(p, n); !move.nil() {
+			if line, err := ms.verify(n); err == nil {
+				return n, line
+			} else if engine.uci {
+				engine.reply("info string %s\n", err)
+			}
+			break
+		}
+		if engine.uci && !engine.clock.halt.Load() {
+			nodes, _ := p.game().nodes()
+			engine.reply("info depth %d nodes %d\n", n * 2 - 1, nodes)
+		}
+	}
+
+	return 0, nil
+}
+
+// Returns the move that forces mate in given number of moves, if any.
+func (ms *MateSolver) attack(p *Position, moves int) Move {
+	if moves <= 0 || p.worker.exhausted() {
+		return Move(0)
+	}
+	if proof, ok := ms.proven[p.id]; ok && proof.moves <= moves {
+		return proof.move
+	}
+	if n, ok := ms.disproven[p.id]; ok && n >= moves {
+		return Move(0)
+	}
+
+	for _, move := range ms.candidates(p, moves) {
+		position := p.makeMove(move)
+		p.worker.nodes.Add(1)
+		mated := ms.defend(position, moves - 1)
+		position.undoLastMove()
+
+		if mated {
+			ms.proven[p.id] = Proof{ moves, move }
+			return move
+		}
+	}
+
+	// Don't trust the results of the search that has been stopped.
+	if !p.engine().clock.halt.Load() {
+		ms.disproven[p.id] = moves
+	}
+
+	return Move(0)
+}
+
+// Returns true if every defender's reply runs into the mate in given number
+// of moves, or the defender has already been checkmated.
+func (ms *MateSolver) defend(p *Position, moves int) bool {
+	replies := NewMoveGen(p).generateAllMoves().validOnly().allMoves()
+	if len(replies) == 0 {
+		return p.isInCheck(p.color) // Checkmate rather than stalemate.
+	}
+	if moves == 0 {
+		return false
+	}
+
+	for _, reply := range replies {
+		position := p.makeMove(reply)
+		p.worker.nodes.Add(1)
+		move := ms.attack(position, moves)
+		position.undoLastMove()
+
+		if move.nil() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns attacker's moves worth trying: checks go first since they leave
+// the defender fewer replies, followed by captures and promotions, and then
+// by the rest of the moves. Mate in one could only be delivered by a check.
+func (ms *MateSolver) candidates(p *Position, moves int) []Move {
+	var checks, captures, others []Move
+
+	for _, move := range NewMoveGen(p).generateAllMoves().validOnly().allMoves() {
+		position := p.makeMove(move)
+		check := position.isInCheck(position.color)
+		position.undoLastMove()
+
+		if check {
+			checks = append(checks, move)
+		} else if moves > 1 {
+			if move.isCapture() || move.isPromo() {
+				captures = append(captures, move)
+			} else {
+				others = append(others, move)
+			}
+		}
+	}
+
+	return append(append(checks, captures...), others...)
+}
+
+// Returns the number of moves till mate or zero if there is no mate within
+// given number of moves.
+func (ms *MateSolver) distance(p *Position, moves int) int {
+	for n := 1; n <= moves; n++ {
+		if !ms.attack(p, n).nil() {
+			return n
+		}
+	}
+
+	return 0
+}
+
+// Plays out the mate in given number of moves picking defender's replies
+// that delay the mate the most, and makes sure the line is legal and ends
+// up with the checkmate.
+func (ms *MateSolver) verify(moves int) (line []Move, err error) {
+	p := ms.p
+	defer func() {
+		for p != ms.p {
+			p = p.undoLastMove()
+		}
+	}()
+
+	for n := moves; ; n-- {
+		move := ms.attack(p, n)
+		if move.nil() || !NewMoveGen(p).generateAllMoves().validOnly().amongValid(move) {
+			return nil, fmt.Errorf("unable to verify mate in %d", moves)
+		}
+		p = p.makeMove(move)
+		line = append(line, move)
+
+		replies := NewMoveGen(p).generateAllMoves().validOnly().allMoves()
+		if len(replies) == 0 {
+			if !p.isInCheck(p.color) {
+				return nil, errors.New(`stalemate at the end of mating line`)
+			}
+			return line, nil
+		}
+		if n == 1 {
+			return nil, fmt.Errorf("no checkmate after %d moves", moves)
+		}
+
+		// Defender's best reply is the one that delays the mate the most.
+		best, longest := Move(0), 0
+		for _, reply := range replies {
+			position := p.makeMove(reply)
+			distance := ms.distance(position, n - 1)
+			position.undoLastMove()
+			if distance == 0 {
+				return nil, fmt.Errorf("%s refutes mate in %d", reply, moves)
+			}
+			if distance > longest {
+				best, longest = reply, distance
+			}
+		}
+		p = p.makeMove(best)
+		line = append(line, best)
+	}
+}
+
+// Runs the mate solver in current position and reports the mating line or
+// the lack of thereof. The solver stops when its limits are reached. If there
+// is no mate we still come up with the move since GUIs take null move for
+// resignation: regular search looks as deep as the mate we were asked for, or
+// the first legal move is picked if the solver has been stopped.
+func (game *Game) mate(ctx context.Context, moves int, start time.Time) Result {
+	engine, position := game.engine, game.position()
+
+	mate, line := NewMateSolver(position).solve(moves)
+	if mate == 0 {
+		if engine.uci {
+			engine.reply("info string no mate in %d\n", moves)
+		} else if engine.repl {
+			fmt.Printf("No mate in %d\n", moves)
+		}
+		if !engine.clock.halt.Load() {
+			engine.options.mate, engine.options.maxDepth = 0, min(moves * 2, MaxDepth)
+			return game.think(ctx)
+		}
+		move := NewRootGen(position, 1).generateRootMoves().NextMove()
+		engine.waitToReply()
+		game.printBestMove(move, since(start))
+		return game.result(move, 0, 0, since(start))
+	}
+
+	score := Checkmate - len(line)
+	game.rootpv.score, game.rootpv.size = score, len(line)
+	copy(game.rootpv.moves[:], line)
+
+	if engine.uci {
+		engine.uciPrincipal(len(line), score, since(start))
+		engine.waitToReply().uciBestMove(line[0], since(start))
+	} else if engine.repl {
+		fmt.Printf("Mate in %d: %s\n", mate, engine.replMoves(line))
+	}
+
+	return game.result(line[0], score, len(line), since(start))
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `context`; `fmt`; `testing`)
+
+func solveMate(white, black string, moves int) (int, string) {
+	p := NewGame(white, black).start()
+	mate, line := NewMateSolver(p).solve(moves)
+	return mate, fmt.Sprintf(`%v`, line)
+}
+
+// Mate in 2.
+func TestMate000(t *testing.T) {
+	mate, line := solveMate(`Kf8,Rh1,g6`, `Kh8,Bg8,g7,h7`, 2)
+	expect.Eq(t, mate, 2)
+	expect.Eq(t, line, `[Rh1-h6 g7xh6 g6-g7]`)
+}
+
+// Underpromotion avoids the stalemate.
+func TestMate010(t *testing.T) {
+	mate, line := solveMate(`Kc6,c7`, `Ka7`, 3)
+	expect.Eq(t, mate, 2)
+	expect.Eq(t, line, `[c7-c8R Ka7-a6 Rc8-a8]`)
+}
+
+// Mate in 3: defender's replies delay the mate the most.
+func TestMate020(t *testing.T) {
+	mate, line := solveMate(`Kf8,Re7,Nd5`, `Kh8,Bh5`, 5)
+	expect.Eq(t, mate, 3)
+	expect.Eq(t, line, `[Re7-g7 Bh5-d1 Nd5-f6 Bd1-c2 Rg7-g8]`)
+}
+
+// Mate in 4.
+func TestMate030(t *testing.T) {
+	mate, line := solveMate(`Kc6,Bc1,Ne5`, `Kc8,Ra8,a7,a6`, 4)
+	expect.Eq(t, mate, 4)
+	expect.Contain(t, line, `[Ne5-f7 `)
+}
+
+// No mate within given number of moves.
+func TestMate040(t *testing.T) {
+	mate, line := solveMate(`Kc6,Bc1,Ne5`, `Kc8,Ra8,a7,a6`, 3)
+	expect.Eq(t, mate, 0)
+	expect.Eq(t, line, `[]`)
+
+	mate, line = solveMate(`Ke1,Nb1`, `Ke8`, 5)
+	expect.Eq(t, mate, 0)
+}
+
+// Mate search through public API.
+func TestMate050(t *testing.T) {
+	game := NewEngine().NewGame(`Kf4,Qc2,Nc5`, `Kd4`)
+	game.Start()
+	result, err := game.Search(context.Background(), Limits{ Mate: 3 })
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, result.Mate, 2)
+	expect.Eq(t, result.Move.str(), `Nc5-b7`)
+	expect.Eq(t, len(result.PV), 3)
+}
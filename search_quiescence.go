@@ -6,16 +6,16 @@ package donna
 
 // Quiescence search.
 func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score int) {
-	ply := ply()
+	ply, engine := p.ply(), p.engine()
 
 	// Return if it's time to stop search.
-	if ply >= MaxPly || engine.clock.halt {
+	if ply >= MaxPly || engine.clock.halt.Load() || p.worker.exhausted() {
 		return p.Evaluate()
 	}
 
 	// Insufficient material and repetition/perpetual check pruning.
 	if p.fifty() || p.insufficient() || p.repetition() {
-		return 0
+		return p.drawScore()
 	}
 
 	// Checkmate distance pruning.
@@ -30,7 +30,7 @@ func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score
 	isNull := p.isNull()
 	isPrincipal := (beta - alpha > 1)
 	if isPrincipal {
-		game.pv[ply].size = 0 // Reset principal variation.
+		p.worker.pv[ply].size = 0 // Reset principal variation.
 	}
 
 	// Use fixed depth for caching.
@@ -39,7 +39,7 @@ func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score
 	// Probe cache.
 	cachedMove := Move(0)
 	cached := p.probeCache()
-	if cached != nil {
+	if !cached.nil() {
 		cachedMove = cached.move
 		if int(cached.depth) >= newDepth {
 			cachedScore := uncache(int(cached.score), ply)
@@ -54,13 +54,13 @@ func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score
 	if inCheck {
 		p.score = Unknown
 	} else {
-		if cached != nil {
+		if !cached.nil() {
 			if p.score == Unknown {
 				p.score = p.Evaluate()
 			}
 		} else {
 			if isNull {
-				p.score = rightToMove.midgame * 2 - tree[node-1].score
+				p.score = p.worker.profile.rightToMove.midgame * 2 - p.worker.tree[p.worker.node - 1].score
 			} else {
 				p.score = p.Evaluate()
 			}
@@ -95,11 +95,11 @@ func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score
 		}
 
 		position := p.makeMove(move)
-		moveCount++; game.qnodes += moveCount
+		moveCount++; p.worker.qnodes.Add(int64(moveCount))
 		giveCheck := position.isInCheck(position.color)
 
 		// Prune useless captures -- but make sure it's not a capture move that checks.
-		if !inCheck && !giveCheck && !isPrincipal && capture != 0 && !move.isPromo() && p.score + pieceValue[capture.id()] + 72 < alpha {
+		if !inCheck && !giveCheck && !isPrincipal && capture != 0 && !move.isPromo() && p.score + p.worker.profile.pieceValue[capture.id()] + 72 < alpha {
 			position.undoLastMove()
 			continue
 		}
@@ -107,7 +107,7 @@ func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score
 		position.undoLastMove()
 
 		// Don't touch anything if the time has elapsed and we need to abort th search.
-		if engine.clock.halt {
+		if engine.clock.halt.Load() {
 			return alpha
 		}
 
@@ -115,7 +115,7 @@ func (p *Position) searchQuiescence(alpha, beta, depth int, inCheck bool) (score
 			bestScore = score
 			if score > alpha {
 				if isPrincipal {
-					game.saveBest(ply, move)
+					p.worker.saveBest(ply, move)
 				}
 				if isPrincipal && score < beta {
 					alpha = score
@@ -4,7 +4,7 @@
 
 package donna
 
-import(`github.com/michaeldv/donna/expect`; `testing`)
+import(`github.com/michaeldv/donna/expect`; `context`; `testing`; `time`)
 
 // Mate in 2.
 
@@ -205,3 +205,117 @@ func TestSearch450(t *testing.T) {
 	position := NewGame().start()
 	expect.Eq(t, position.Perft(5), int64(4865609))
 }
+
+// Chess960 perft.
+func TestSearch460(t *testing.T) {
+	position := NewGame(`bqnb1rkr/pp3ppp/3ppn2/2p5/5P2/P2P4/NPP1P1PP/BQ1BNRKR w HFhf - 2 9`).start()
+	expect.Eq(t, position.Perft(4), int64(326672))
+}
+
+func TestSearch470(t *testing.T) {
+	position := NewGame(`b1q1rrkb/pppppppp/3nn3/8/P7/1PPP4/4PPPP/BQNNRKRB w GE - 1 9`).start()
+	expect.Eq(t, position.Perft(4), int64(273318))
+}
+
+func TestSearch480(t *testing.T) {
+	position := NewGame(`qbbnnrkr/2pp2pp/p7/1p2pp2/8/P3PP2/1PPP1KPP/QBBNNR1R w hf - 0 9`).start()
+	expect.Eq(t, position.Perft(4), int64(382958))
+}
+
+func TestSearch490(t *testing.T) {
+	position := NewGame(`qnbnr1kr/ppp1b1pp/4p3/3p1p2/8/2NPP3/PPP1BPPP/QNB1R1KR w HEhe - 1 9`).start()
+	expect.Eq(t, position.Perft(4), int64(824055))
+}
+
+// Lazy SMP: helper workers search along with the main one sharing the cache.
+func TestSearch500(t *testing.T) {
+	game := NewEngine(`threads`, 4).NewGame(`Kc6,Bc1,Ne5`, `Kc8,Ra8,a7,a6`)
+	position := game.start()
+	done := game.startHelpers()
+	move := position.solve(7)
+	game.stopHelpers(done)
+
+	expect.Eq(t, move, `Ne5-f7`)
+	expect.Eq(t, len(game.workers), 4)
+	for _, worker := range game.workers[1:] {
+		expect.True(t, worker.nodes.Load() > 0)
+		expect.Eq(t, worker.node, game.workers[0].node)
+	}
+}
+
+// Engines running side by side produce the same results as each engine alone.
+func TestSearch510(t *testing.T) {
+	solve := func(white, black string) (Move, int) {
+		p := NewEngine(`cache`, 1).NewGame(white, black).start()
+		move := p.solve(7)
+		return move, int(p.worker.nodes.Load())
+	}
+
+	move1, nodes1 := solve(`Kc6,Bc1,Ne5`, `Kc8,Ra8,a7,a6`)
+	move2, nodes2 := solve(`Kf6,Nf8,Nh6`, `Kh8,f7,h7`)
+
+	var move3, move4 Move
+	var nodes3, nodes4 int
+	done := make(chan bool)
+	go func() { move3, nodes3 = solve(`Kc6,Bc1,Ne5`, `Kc8,Ra8,a7,a6`); done <- true }()
+	go func() { move4, nodes4 = solve(`Kf6,Nf8,Nh6`, `Kh8,f7,h7`); done <- true }()
+	<-done; <-done
+
+	expect.Eq(t, move3, move1)
+	expect.Eq(t, nodes3, nodes1)
+	expect.Eq(t, move4, move2)
+	expect.Eq(t, nodes4, nodes2)
+}
+
+// Pondering search doesn't reply until "ponderhit" even if it's done.
+func TestSearch520(t *testing.T) {
+	engine := NewEngine()
+	game := engine.NewGame(`Kf8,Rh1,g6`, `Kh8,Bg8,g7,h7`)
+	game.start()
+	engine.fixedLimit(Options{ ponder: true, moveTime: 1000 })
+
+	done := make(chan Move)
+	go func() { done <- game.Think() }()
+
+	time.Sleep(time.Millisecond * 200)
+	select {
+	case <-done:
+		t.Errorf("Pondering search replied before ponderhit")
+		return
+	default:
+		engine.ponderHit()
+	}
+	expect.Eq(t, <-done, `Rh1-h6`)
+}
+
+// Node limited search stops at the same node every time.
+func TestSearch530(t *testing.T) {
+	search := func() (Move, int) {
+		engine := NewEngine(`cache`, 1)
+		game := engine.NewGame(`rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq - 0 1`)
+		game.start()
+		engine.fixedLimit(Options{ maxNodes: 20000 })
+		move := game.Think()
+		nodes, qnodes := game.nodes()
+		return move, nodes + qnodes
+	}
+
+	move1, nodes1 := search()
+	move2, nodes2 := search()
+	expect.Eq(t, move1, move2)
+	expect.Eq(t, nodes1, nodes2)
+	expect.True(t, nodes1 >= 20000 && nodes1 < 20000 + 100)
+}
+
+// Contempt turns dead draw into the score relative to the engine's side.
+func TestSearch540(t *testing.T) {
+	game := NewEngine(`contempt`, 25).NewGame(`Ka1,Nb1`, `Kh8`)
+	game.Start()
+	result, _ := game.Search(context.Background(), Limits{ Depth: 4 })
+	expect.Eq(t, result.Score, -25)
+
+	game = NewEngine(`contempt`, -25).NewGame(`Ka1,Nb1`, `M,Kh8`)
+	game.Start()
+	result, _ = game.Search(context.Background(), Limits{ Depth: 4 })
+	expect.Eq(t, result.Score, 25)
+}
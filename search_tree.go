@@ -5,19 +5,19 @@
 package donna
 
 func (p *Position) searchTree(alpha, beta, depth int) (score int) {
-	ply := ply()
+	ply, engine := p.ply(), p.engine()
 
 	// Return if it's time to stop search.
-	if ply >= MaxPly || engine.clock.halt {
+	if ply >= MaxPly || engine.clock.halt.Load() || p.worker.exhausted() {
 		return p.Evaluate()
 	}
 
 	// Reset principal variation.
-	game.pv[ply].size = 0
+	p.worker.pv[ply].size = 0
 
 	// Insufficient material and repetition/perpetual check pruning.
 	if p.fifty() || p.insufficient() || p.repetition() {
-		return 0
+		return p.drawScore()
 	}
 
 	// Checkmate distance pruning.
@@ -34,7 +34,7 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 	// Probe cache.
 	cachedMove := Move(0)
 	cached := p.probeCache()
-	if cached != nil {
+	if !cached.nil() {
 		cachedMove = cached.move
 		if int(cached.depth) >= depth {
 			cachedScore := uncache(int(cached.score), ply)
@@ -42,24 +42,50 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 			   ((cached.flags == cacheBeta && cachedScore >= beta) ||
 			   (cached.flags == cacheAlpha && cachedScore <= alpha)) {
 				if cachedScore >= beta && !inCheck && !cachedMove.nil() {
-					game.saveGood(depth, cachedMove)
+					p.worker.saveGood(depth, cachedMove)
 				}
 				return cachedScore
 			}
 		}
 	}
 
+	// Probe endgame tablebase right after captures and pawn moves: the
+	// win or loss cuts off the search and draw is the exact score. If win
+	// or loss doesn't cut off then it bounds the score of principal node.
+	tbCeiling := Checkmate
+	if ply > 0 && p.count50 == 0 {
+		if tb := engine.tablebase(); p.tbProbable(tb, engine.syzygyLimit) {
+			if tbScore, ok := p.probeScore(tb, ply); ok {
+				cacheFlags := cacheExact
+				if tbScore > DrawScore + 1 {
+					cacheFlags = cacheBeta
+				} else if tbScore < DrawScore - 1 {
+					cacheFlags = cacheAlpha
+				}
+				if cacheFlags == cacheExact || (cacheFlags == cacheBeta && tbScore >= beta) || (cacheFlags == cacheAlpha && tbScore <= alpha) {
+					p.cache(Move(0), tbScore, depth, ply, cacheFlags)
+					return tbScore
+				}
+				if isPrincipal && cacheFlags == cacheBeta {
+					alpha = max(alpha, tbScore)
+				} else if isPrincipal {
+					tbCeiling = tbScore
+				}
+			}
+		}
+	}
+
 	if !inCheck {
 		if depth < 1 {
-			return p.searchQuiescence(alpha, beta, 0, inCheck)
+			return min(p.searchQuiescence(alpha, beta, 0, inCheck), tbCeiling)
 		}
-		if cached != nil {
+		if !cached.nil() {
 			if p.score == Unknown {
 				p.score = p.Evaluate()
 			}
 		} else {
 			if isNull {
-				p.score = rightToMove.midgame * 2 - tree[node-1].score
+				p.score = p.worker.profile.rightToMove.midgame * 2 - p.worker.tree[p.worker.node - 1].score
 			} else {
 				p.score = p.Evaluate()
 			}
@@ -99,7 +125,7 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 		// Null move pruning.
 		if !isNull && depth > 1 && p.outposts[p.color].count() > 5 {
 			position := p.makeNullMove()
-			game.nodes++
+			p.worker.nodes.Add(1)
 			nullScore := -position.searchTree(-beta, -beta + 1, depth - 1 - 3)
 			position.undoNullMove()
 
@@ -119,16 +145,14 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 			newDepth = depth - 2
 		}
 		p.searchTree(alpha, beta, newDepth)
-		if cached := p.probeCache(); cached != nil {
-			cachedMove = cached.move
-		}
+		cachedMove = p.cachedMove()
 	}
 
 	gen := NewGen(p, ply)
 	if inCheck {
 		gen.generateEvasions().quickRank()
 	} else {
-		gen.generateMoves().rank(cachedMove)
+		gen.generateStaged(cachedMove)
 	}
 
 	bestScore := alpha
@@ -139,7 +163,7 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 		}
 
 		position := p.makeMove(move)
-		moveCount++; game.nodes++
+		moveCount++; p.worker.nodes.Add(1)
 
 		// Reduce search depth if we're not checking.
 		giveCheck := position.isInCheck(position.color)
@@ -150,9 +174,9 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 			score = -position.searchTree(-beta, -alpha, newDepth)
 		} else {
 			reduction := 0
-			if !isPrincipal && !inCheck && !giveCheck && depth > 2 && move.isQuiet() && !move.isKiller(ply) && !move.isPawnAdvance() {
+			if !isPrincipal && !inCheck && !giveCheck && depth > 2 && move.isQuiet() && !p.worker.isKiller(move, ply) && !move.isPawnAdvance() {
 				reduction = lateMoveReductions[min(63, moveCount-1)][min(63, depth)]
-				if game.history[move.piece()][move.to()] < 0 {
+				if p.worker.good(move) < 0 {
 					reduction++
 				}
 			}
@@ -172,7 +196,7 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 		position.undoLastMove()
 
 		// Don't touch anything if the time has elapsed and we need to abort th search.
-		if engine.clock.halt {
+		if engine.clock.halt.Load() {
 			return alpha
 		}
 
@@ -180,7 +204,7 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 			bestScore = score
 			if score > alpha {
 				if isPrincipal {
-					game.saveBest(ply, move)
+					p.worker.saveBest(ply, move)
 				}
 				if isPrincipal && score < beta {
 					alpha = score
@@ -194,11 +218,11 @@ func (p *Position) searchTree(alpha, beta, depth int) (score int) {
 	}
 
 	if moveCount == 0 {
-		score = let(inCheck, matedIn(ply), 0)
+		score = let(inCheck, matedIn(ply), p.drawScore())
 	} else {
-		score = bestScore
+		score = min(bestScore, tbCeiling)
 		if !inCheck {
-			game.saveGood(depth, bestMove)
+			p.worker.saveGood(depth, bestMove)
 		}
 	}
 
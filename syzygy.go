@@ -0,0 +1,1128 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`encoding/binary`
+	`io/ioutil`
+	`path/filepath`
+	`sort`
+	`strings`
+	`sync`
+)
+
+// Syzygy tablebases. The file format along with the probing code follows the
+// original tbprobe by Ronald de Man: WDL tables (.rtbw) tell win, draw, or loss
+// for both sides to move, and DTZ tables (.rtbz) tell the distance to zeroing
+// the 50 moves rule counter for one side to move.
+const (
+	tbPieces    = 7          // Largest supported number of pieces.
+	tbWdlMagic  = 0x5D23E871 // First four bytes of .rtbw file.
+	tbDtzMagic  = 0xA50C66D7 // First four bytes of .rtbz file.
+)
+
+// WDL scores as seen by the side to move.
+const (
+	tbLoss        = -2
+	tbBlessedLoss = -1 // Loss that gets saved by the 50 moves rule.
+	tbDraw        = 0
+	tbCursedWin   = 1  // Win that gets spoiled by the 50 moves rule.
+	tbWin         = 2
+)
+
+// Probe results.
+const (
+	tbChangeStm   = -1 // DTZ table is only available for the other side to move.
+	tbFail        = 0
+	tbOk          = 1
+	tbZeroingBest = 2  // Best move zeroes the 50 moves rule counter.
+)
+
+// Table flags.
+const (
+	tbStm         = 1
+	tbMapped      = 2
+	tbWinPlies    = 4
+	tbLossPlies   = 8
+	tbWide        = 16
+	tbSingleValue = 128
+)
+
+var (
+	tbMapA1D1D4     [64]int
+	tbMapB1H1H7     [64]int
+	tbMapKK         [10][64]int
+	tbMapPawns      [64]int
+	tbBinomial      [6][64]int
+	tbLeadPawnIdx   [6][64]int
+	tbLeadPawnsSize [6][4]int
+)
+
+// Decoding data for one side to move and one lead pawn file. Offsets point
+// into the table's data.
+type tbPairs struct {
+	flags           int
+	pieces          [tbPieces]int   // Syzygy piece codes in the encoding order.
+	groupLen        [tbPieces+1]int // Number of pieces in each group.
+	groupIdx        [tbPieces+1]int // Start index used for the encoding of the group's pieces.
+	blockSize       int             // Block size in bytes.
+	span            int             // Values covered by each sparse index entry.
+	numBlocks       int
+	maxSymLen       int
+	minSymLen       int             // Also the value of the single value tables.
+	sparseIndex     int             // Offset of the sparse index.
+	sparseIndexSize int
+	blockLength     int             // Offset of the block lengths.
+	blockLengthSize int
+	blocks          int             // Offset of the first compressed block.
+	lowestSym       int             // Offset of the lowest symbols for each length.
+	btree           int             // Offset of the symbol tree.
+	base64          []uint64
+	symlen          []uint8
+	dtzMap          [4]int          // DTZ map indices for each WDL outcome.
+}
+
+// One WDL or DTZ table.
+type tbTable struct {
+	name            string          // Table name, ex. `KRvK`.
+	dtz             bool
+	pieceCount      int
+	pawnCount       [2]int          // Lead color pawns first.
+	hasPawns        bool
+	hasUniquePieces bool
+	symmetric       bool            // Both sides have the same material.
+	data            []byte
+	dtzMap          int             // Offset of the DTZ map.
+	pairs           [2][4]tbPairs   // Indexed by side to move and lead pawn file.
+	once            sync.Once
+	loaded          bool
+}
+
+// Tablebase keeps track of table files found in the search path. The tables
+// get loaded into memory when they are probed for the first time.
+type Tablebase struct {
+	wdl       map[string]*tbTable
+	dtz       map[string]*tbTable
+	files     map[string]string   // Table file paths by file name.
+	pieces    int                 // Largest number of pieces of the tables found.
+}
+
+func init() {
+	initSyzygy()
+}
+
+func initSyzygy() {
+	// Squares of A1-D1-D4 triangle below the diagonal to 0..5, and A1-D4
+	// diagonal squares to 6..9.
+	code := 0
+	for square := A1; square <= D4; square++ {
+		if tbOffA1H8(square) < 0 && col(square) <= 3 {
+			tbMapA1D1D4[square] = code; code++
+		}
+	}
+	for square := A1; square <= D4; square++ {
+		if tbOffA1H8(square) == 0 && col(square) <= 3 {
+			tbMapA1D1D4[square] = code; code++
+		}
+	}
+
+	// Squares below the A1-H8 diagonal to 0..27.
+	code = 0
+	for square := A1; square <= H8; square++ {
+		if row(square) < col(square) {
+			tbMapB1H1H7[square] = code; code++
+		}
+	}
+
+	// King pairs with the first king in the A1-D1-D4 triangle, 462 in total.
+	code = 0
+	var both [][2]int
+	for idx := 0; idx < 10; idx++ {
+		for s1 := A1; s1 <= D4; s1++ {
+			if tbMapA1D1D4[s1] != idx || (idx == 0 && s1 != B1) {
+				continue // Only B1 maps to zero.
+			}
+			for s2 := A1; s2 <= H8; s2++ {
+				if s1 == s2 || kingMoves[s1].on(s2) {
+					continue
+				} else if tbOffA1H8(s1) == 0 && tbOffA1H8(s2) > 0 {
+					continue
+				} else if tbOffA1H8(s1) == 0 && tbOffA1H8(s2) == 0 {
+					both = append(both, [2]int{ idx, s2 })
+				} else {
+					tbMapKK[idx][s2] = code; code++
+				}
+			}
+		}
+	}
+	for _, pair := range both {
+		tbMapKK[pair[0]][pair[1]] = code; code++
+	}
+
+	// Binomial coefficients: number of ways to place k identical pieces on n
+	// squares.
+	tbBinomial[0][0] = 1
+	for n := 1; n < 64; n++ {
+		for k := 0; k < 6 && k <= n; k++ {
+			tbBinomial[k][n] = tbBinomial[k][n-1]
+			if k > 0 {
+				tbBinomial[k][n] += tbBinomial[k-1][n-1]
+			}
+		}
+	}
+
+	// Pawn squares A2-H7 to 0..47 so that the lead pawn is the one closest to
+	// the board edge and to the first rank.
+	available := 47
+	for leadPawns := 1; leadPawns <= 5; leadPawns++ {
+		for file := 0; file <= 3; file++ {
+			idx := 0
+			for rank := 1; rank <= 6; rank++ {
+				square := square(rank, file)
+				if leadPawns == 1 {
+					tbMapPawns[square] = available; available--
+					tbMapPawns[square ^ 7] = available; available--
+				}
+				tbLeadPawnIdx[leadPawns][square] = idx
+				idx += tbBinomial[leadPawns - 1][tbMapPawns[square]]
+			}
+			tbLeadPawnsSize[leadPawns][file] = idx
+		}
+	}
+}
+
+// Returns the signed distance from A1-H8 diagonal: positive above, negative
+// below, and zero on the diagonal.
+func tbOffA1H8(square int) int {
+	return row(square) - col(square)
+}
+
+// Returns Syzygy piece code: 1..6 for white pawn to king, 9..14 for black.
+func tbPiece(piece Piece) int {
+	return int(piece >> 1) | int(piece & 1) << 3
+}
+
+// Returns table name for the given material, ex. `KQvK`. Material of either
+// side gets listed in K, Q, R, B, N, P order.
+func tbName(white, black string) string {
+	return white + `v` + black
+}
+
+// Scans path list separated by the OS specific separator (: or ;) for the
+// table files. Returns nil if no tables were found.
+func NewTablebase(path string) *Tablebase {
+	tb := &Tablebase{ wdl: map[string]*tbTable{}, dtz: map[string]*tbTable{}, files: map[string]string{} }
+
+	for _, dir := range filepath.SplitList(path) {
+		if dir == `` || dir == `<empty>` {
+			continue
+		}
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ext := entry.Name(), filepath.Ext(entry.Name())
+			if ext != `.rtbw` && ext != `.rtbz` {
+				continue
+			}
+			if table := newTbTable(strings.TrimSuffix(name, ext), ext == `.rtbz`); table != nil {
+				if _, exists := tb.files[name]; !exists {
+					tb.files[name] = filepath.Join(dir, name)
+					if table.dtz {
+						tb.dtz[table.name] = table
+					} else {
+						tb.wdl[table.name] = table
+						tb.pieces = max(tb.pieces, table.pieceCount)
+					}
+				}
+			}
+		}
+	}
+
+	if len(tb.wdl) == 0 {
+		return nil
+	}
+	return tb
+}
+
+// Returns the number of pieces of the largest tables.
+func (tb *Tablebase) MaxPieces() int {
+	if tb == nil {
+		return 0
+	}
+	return tb.pieces
+}
+
+// Creates the table for given name, ex. `KRPvKR`, setting up everything but
+// the actual data.
+func newTbTable(name string, dtz bool) *tbTable {
+	sides := strings.Split(name, `v`)
+	if len(sides) != 2 || !strings.HasPrefix(sides[0], `K`) || !strings.HasPrefix(sides[1], `K`) {
+		return nil
+	}
+	for _, char := range sides[0] + sides[1] {
+		if !strings.ContainsRune(`KQRBNP`, char) {
+			return nil
+		}
+	}
+
+	table := &tbTable{ name: name, dtz: dtz, pieceCount: len(sides[0]) + len(sides[1]) }
+	if table.pieceCount > tbPieces {
+		return nil
+	}
+	table.symmetric = sides[0] == sides[1]
+
+	// The lead color is the one with fewer pawns but at least one.
+	white, black := strings.Count(sides[0], `P`), strings.Count(sides[1], `P`)
+	table.hasPawns = white + black > 0
+	if black > 0 && (white == 0 || black < white) {
+		table.pawnCount = [2]int{ black, white }
+	} else {
+		table.pawnCount = [2]int{ white, black }
+	}
+
+	for _, side := range sides {
+		for _, char := range `QRBNP` { // Pawns count too.
+			if strings.Count(side, string(char)) == 1 {
+				table.hasUniquePieces = true
+			}
+		}
+	}
+	return table
+}
+
+// Returns the table for the position's material. The second value is true
+// if the table's white is position's black.
+func (tb *Tablebase) table(p *Position, dtz bool) (*tbTable, bool) {
+	white, black := p.tbMaterial(White), p.tbMaterial(Black)
+	tables := tb.wdl
+	if dtz {
+		tables = tb.dtz
+	}
+
+	for flipped, name := range []string{ tbName(white, black), tbName(black, white) } {
+		if table := tables[name]; table != nil {
+			if !tb.load(table) {
+				return nil, false
+			}
+			return table, flipped == 1
+		}
+	}
+
+	return nil, false
+}
+
+// Reads the table file into memory and parses its header. Returns false if the
+// file could not be read or is invalid.
+func (tb *Tablebase) load(table *tbTable) bool {
+	table.once.Do(func() {
+		name := table.name + `.rtbw`
+		if table.dtz {
+			name = table.name + `.rtbz`
+		}
+		if data, err := ioutil.ReadFile(tb.files[name]); err == nil {
+			table.loaded = table.init(data)
+		}
+	})
+
+	return table.loaded
+}
+
+// Returns the side's material as used in table names.
+func (p *Position) tbMaterial(color uint8) string {
+	material := `K`
+	for _, piece := range []Piece{ Queen, Rook, Bishop, Knight, Pawn } {
+		material += strings.Repeat(string("-PNBRQK"[piece >> 1]), p.outposts[piece | Piece(color)].count())
+	}
+	return material
+}
+
+// Parses table header setting up the data offsets. Returns false if the table
+// is not valid.
+func (t *tbTable) init(data []byte) bool {
+	if len(data) < 5 {
+		return false
+	}
+	magic := binary.LittleEndian.Uint32(data)
+	if (t.dtz && magic != tbDtzMagic) || (!t.dtz && magic != tbWdlMagic) {
+		return false
+	}
+	defer func() {
+		if recover() != nil { // Truncated or broken file.
+			t.data = nil
+		}
+	}()
+
+	t.data = data
+	if (data[4] & 2 != 0) != t.hasPawns || (data[4] & 1 != 0) != !t.symmetric {
+		return false
+	}
+
+	sides, files := t.sides(), t.files()
+	pos := 5
+	for f := 0; f < files; f++ {
+		pawnPawn := t.hasPawns && t.pawnCount[1] > 0
+		order := [2][2]int{ { int(data[pos] & 0x0F), 0x0F }, { int(data[pos] >> 4), 0x0F } }
+		if pawnPawn {
+			order[0][1], order[1][1] = int(data[pos+1] & 0x0F), int(data[pos+1] >> 4)
+		}
+		pos += 1 + let(pawnPawn, 1, 0)
+
+		for k := 0; k < t.pieceCount; k++ {
+			for i := 0; i < sides; i++ {
+				t.pairs[i][f].pieces[k] = let(i == 0, int(data[pos] & 0x0F), int(data[pos] >> 4))
+			}
+			pos++
+		}
+		for i := 0; i < sides; i++ {
+			t.setGroups(&t.pairs[i][f], order[i], f)
+		}
+	}
+	pos += pos & 1
+
+	for f := 0; f < files; f++ {
+		for i := 0; i < sides; i++ {
+			pos = t.setSizes(&t.pairs[i][f], pos)
+		}
+	}
+
+	if t.dtz {
+		pos = t.setDtzMap(pos)
+	}
+
+	for f := 0; f < files; f++ {
+		for i := 0; i < sides; i++ {
+			t.pairs[i][f].sparseIndex = pos
+			pos += t.pairs[i][f].sparseIndexSize * 6
+		}
+	}
+	for f := 0; f < files; f++ {
+		for i := 0; i < sides; i++ {
+			t.pairs[i][f].blockLength = pos
+			pos += t.pairs[i][f].blockLengthSize * 2
+		}
+	}
+	for f := 0; f < files; f++ {
+		for i := 0; i < sides; i++ {
+			pos = (pos + 0x3F) &^ 0x3F
+			t.pairs[i][f].blocks = pos
+			pos += t.pairs[i][f].numBlocks * t.pairs[i][f].blockSize
+		}
+	}
+
+	return pos <= len(data)
+}
+
+// Number of sides to move stored in the table.
+func (t *tbTable) sides() int {
+	return let(t.dtz || t.symmetric, 1, 2)
+}
+
+// Number of lead pawn files stored in the table.
+func (t *tbTable) files() int {
+	return let(t.hasPawns, 4, 1)
+}
+
+// Groups the pieces that get encoded together: leading pieces (kings or lead
+// pawns), other pawns, and then the remaining pieces of the same kind. Each
+// group gets the index range so that the whole position could be encoded as
+// a sum of group indices multiplied by their ranges.
+func (t *tbTable) setGroups(d *tbPairs, order [2]int, file int) {
+	n, firstLen := 0, 0
+	if t.hasPawns {
+		firstLen = 0 // Lead pawns are of the same kind anyway.
+	} else if t.hasUniquePieces {
+		firstLen = 3
+	} else {
+		firstLen = 2
+	}
+	d.groupLen[n] = 1
+
+	// Pieces of the same kind and color go into the same group.
+	for i := 1; i < t.pieceCount; i++ {
+		if firstLen--; firstLen > 0 || d.pieces[i] == d.pieces[i-1] {
+			d.groupLen[n]++
+		} else {
+			n++
+			d.groupLen[n] = 1
+		}
+	}
+	n++
+	d.groupLen[n] = 0 // Zero-terminated.
+
+	// The lead pawns or kings go first, the remaining pawns, if any, follow
+	// and then the rest of the pieces.
+	pawnPawn := t.hasPawns && t.pawnCount[1] > 0
+	next := let(pawnPawn, 2, 1)
+	freeSquares := 64 - d.groupLen[0] - let(pawnPawn, d.groupLen[1], 0)
+	idx := 1
+
+	for k := 0; next < n || k == order[0] || k == order[1]; k++ {
+		if k == order[0] { // Leading pawns or pieces.
+			d.groupIdx[0] = idx
+			if t.hasPawns {
+				idx *= tbLeadPawnsSize[d.groupLen[0]][file]
+			} else if t.hasUniquePieces {
+				idx *= 31332
+			} else {
+				idx *= 462
+			}
+		} else if k == order[1] { // Remaining pawns.
+			d.groupIdx[1] = idx
+			idx *= tbBinomial[d.groupLen[1]][48 - d.groupLen[0]]
+		} else { // Remaining pieces.
+			d.groupIdx[next] = idx
+			idx *= tbBinomial[d.groupLen[next]][freeSquares]
+			freeSquares -= d.groupLen[next]
+			next++
+		}
+	}
+	d.groupIdx[n] = idx
+}
+
+// Reads compression parameters and sets up canonical Huffman code.
+func (t *tbTable) setSizes(d *tbPairs, pos int) int {
+	data := t.data
+	d.flags = int(data[pos])
+	if d.flags & tbSingleValue != 0 {
+		d.numBlocks, d.span, d.sparseIndexSize = 0, 0, 0
+		d.minSymLen = int(data[pos+1]) // Here we store the single value.
+		return pos + 2
+	}
+
+	// Number of values covered by the table is the group index of the
+	// terminating group.
+	tableSize := 0
+	for i := 0; ; i++ {
+		if d.groupLen[i] == 0 {
+			tableSize = d.groupIdx[i]
+			break
+		}
+	}
+
+	d.blockSize = 1 << data[pos+1]
+	d.span = 1 << data[pos+2]
+	d.sparseIndexSize = (tableSize + d.span - 1) / d.span
+	padding := int(data[pos+3])
+	d.numBlocks = int(binary.LittleEndian.Uint32(data[pos+4:]))
+	d.blockLengthSize = d.numBlocks + padding
+	d.maxSymLen = int(data[pos+8])
+	d.minSymLen = int(data[pos+9])
+	d.lowestSym = pos + 10
+	pos = d.lowestSym
+
+	// Canonical Huffman: base64[l] is the 64-bit padded lowest code of
+	// length (minSymLen + l).
+	lengths := d.maxSymLen - d.minSymLen + 1
+	d.base64 = make([]uint64, lengths)
+	for i := lengths - 2; i >= 0; i-- {
+		d.base64[i] = (d.base64[i+1] + uint64(t.u16(d.lowestSym + 2 * i)) - uint64(t.u16(d.lowestSym + 2 * (i + 1)))) / 2
+	}
+	for i := 0; i < lengths; i++ {
+		d.base64[i] <<= uint(64 - i - d.minSymLen)
+	}
+
+	pos += lengths * 2
+	symbols := int(t.u16(pos))
+	pos += 2
+	d.btree = pos
+	d.symlen = make([]uint8, symbols)
+	visited := make([]bool, symbols)
+	for sym := 0; sym < symbols; sym++ {
+		if !visited[sym] {
+			t.setSymlen(d, sym, visited)
+		}
+	}
+
+	return pos + symbols * 3 + (symbols & 1)
+}
+
+// Computes the number of values represented by the symbol. Symbols are either
+// leaves or pairs of other symbols.
+func (t *tbTable) setSymlen(d *tbPairs, sym int, visited []bool) {
+	left, right := t.pair(d, sym)
+	if right == 0xFFF {
+		d.symlen[sym] = 0
+	} else {
+		if !visited[left] {
+			t.setSymlen(d, left, visited)
+		}
+		if !visited[right] {
+			t.setSymlen(d, right, visited)
+		}
+		d.symlen[sym] = d.symlen[left] + d.symlen[right] + 1
+	}
+	visited[sym] = true
+}
+
+// Returns left and right symbols of the symbol's pair. The leaf symbols have
+// right symbol of 0xFFF and left one is the value.
+func (t *tbTable) pair(d *tbPairs, sym int) (int, int) {
+	lr := t.data[d.btree + 3 * sym:]
+	return int(lr[0]) | int(lr[1] & 0x0F) << 8, int(lr[2]) << 4 | int(lr[1] >> 4)
+}
+
+// Sets up the maps that convert DTZ table values to plies or moves.
+func (t *tbTable) setDtzMap(pos int) int {
+	t.dtzMap = pos
+	for f := 0; f < t.files(); f++ {
+		d := &t.pairs[0][f]
+		if d.flags & tbMapped != 0 {
+			if d.flags & tbWide != 0 {
+				pos += pos & 1
+				for i := 0; i < 4; i++ {
+					d.dtzMap[i] = (pos - t.dtzMap) / 2 + 1
+					pos += 2 * int(t.u16(pos)) + 2
+				}
+			} else {
+				for i := 0; i < 4; i++ {
+					d.dtzMap[i] = pos - t.dtzMap + 1
+					pos += int(t.data[pos]) + 1
+				}
+			}
+		}
+	}
+
+	return pos + pos & 1
+}
+
+func (t *tbTable) u16(pos int) uint16 {
+	return binary.LittleEndian.Uint16(t.data[pos:])
+}
+
+// Returns big endian 32-bit word at the given offset. Compressed blocks might
+// be read a few bytes past the end of the file.
+func (t *tbTable) u32be(pos int) uint32 {
+	var word [4]byte
+	if pos < len(t.data) {
+		copy(word[:], t.data[pos:])
+	}
+	return binary.BigEndian.Uint32(word[:])
+}
+
+// Decompresses the value stored at the given index.
+func (t *tbTable) decompress(d *tbPairs, idx int) int {
+	if d.flags & tbSingleValue != 0 {
+		return d.minSymLen
+	}
+
+	// Find the block with the index using the sparse index: the entry
+	// covers span values and points to the middle one.
+	k := idx / d.span
+	entry := t.data[d.sparseIndex + 6 * k:]
+	block := int(binary.LittleEndian.Uint32(entry))
+	offset := int(binary.LittleEndian.Uint16(entry[4:])) + idx % d.span - d.span / 2
+
+	for offset < 0 {
+		block--
+		offset += int(t.u16(d.blockLength + 2 * block)) + 1
+	}
+	for length := int(t.u16(d.blockLength + 2 * block)); offset > length; length = int(t.u16(d.blockLength + 2 * block)) {
+		offset -= length + 1
+		block++
+	}
+
+	// Read the block's symbols until we get to the one that covers the
+	// offset.
+	pos := d.blocks + block * d.blockSize
+	buf64 := uint64(t.u32be(pos)) << 32 | uint64(t.u32be(pos + 4))
+	pos += 8
+	bits, sym := 64, 0
+
+	for {
+		length := 0
+		for buf64 < d.base64[length] {
+			length++
+		}
+		sym = int((buf64 - d.base64[length]) >> uint(64 - length - d.minSymLen))
+		sym += int(t.u16(d.lowestSym + 2 * length))
+
+		if offset < int(d.symlen[sym]) + 1 {
+			break
+		}
+		offset -= int(d.symlen[sym]) + 1
+		length += d.minSymLen
+		buf64 <<= uint(length)
+		bits -= length
+
+		if bits <= 32 {
+			bits += 32
+			buf64 |= uint64(t.u32be(pos)) << uint(64 - bits)
+			pos += 4
+		}
+	}
+
+	// Walk the symbol tree down to the leaf.
+	for d.symlen[sym] != 0 {
+		left, right := t.pair(d, sym)
+		if offset < int(d.symlen[left]) + 1 {
+			sym = left
+		} else {
+			offset -= int(d.symlen[left]) + 1
+			sym = right
+		}
+	}
+
+	left, _ := t.pair(d, sym)
+	return left
+}
+
+// Returns true if the DTZ table stores given side to move. Symmetric tables
+// without pawns are stored for one side only.
+func (t *tbTable) dtzStm(stm, file int) bool {
+	return t.pairs[0][file].flags & tbStm == stm || (t.symmetric && !t.hasPawns)
+}
+
+// Encodes the placement of the pieces into table index. The squares and the
+// pieces are in the table's order with the lead pawns going first, and the
+// lead pawn is the one with the highest tbMapPawns value.
+func (t *tbTable) index(d *tbPairs, squares []int, leadPawns int) int {
+	size := t.pieceCount
+
+	// Flip the squares so that the lead pawn is on A-D files, and the
+	// leading pieces are in A1-D1-D4 triangle.
+	if col(squares[0]) > 3 {
+		for i := 0; i < size; i++ {
+			squares[i] ^= 7
+		}
+	}
+	if !t.hasPawns {
+		if row(squares[0]) > 3 {
+			for i := 0; i < size; i++ {
+				squares[i] ^= 56
+			}
+		}
+		for i := 0; i < d.groupLen[0]; i++ {
+			if tbOffA1H8(squares[i]) == 0 {
+				continue
+			}
+			if tbOffA1H8(squares[i]) > 0 { // Above the diagonal: flip it.
+				for j := i; j < size; j++ {
+					squares[j] = tbFlipDiagonal(squares[j])
+				}
+			}
+			break
+		}
+	}
+
+	idx := 0
+	if t.hasPawns {
+		// Lead pawns: the first one is encoded with the file, and the
+		// rest as a combination of the remaining squares.
+		idx = tbLeadPawnIdx[leadPawns][squares[0]]
+		lead := squares[1:leadPawns]
+		sort.Slice(lead, func(i, j int) bool { return tbMapPawns[lead[i]] < tbMapPawns[lead[j]] })
+		for i := 1; i < leadPawns; i++ {
+			idx += tbBinomial[i][tbMapPawns[squares[i]]]
+		}
+	} else if t.hasUniquePieces {
+		// Three unique leading pieces: 31332 combinations.
+		adjust1 := let(squares[1] > squares[0], 1, 0)
+		adjust2 := let(squares[2] > squares[0], 1, 0) + let(squares[2] > squares[1], 1, 0)
+
+		if tbOffA1H8(squares[0]) != 0 {
+			idx = tbMapA1D1D4[squares[0]] * 63 * 62 + (squares[1] - adjust1) * 62 + squares[2] - adjust2
+		} else if tbOffA1H8(squares[1]) != 0 {
+			idx = 6 * 63 * 62 + row(squares[0]) * 28 * 62 + tbMapB1H1H7[squares[1]] * 62 + squares[2] - adjust2
+		} else if tbOffA1H8(squares[2]) != 0 {
+			idx = 6 * 63 * 62 + 4 * 28 * 62 + row(squares[0]) * 7 * 28 + (row(squares[1]) - adjust1) * 28 + tbMapB1H1H7[squares[2]]
+		} else {
+			idx = 6 * 63 * 62 + 4 * 28 * 62 + 4 * 7 * 28 + row(squares[0]) * 7 * 6 + (row(squares[1]) - adjust1) * 6 + row(squares[2]) - adjust2
+		}
+	} else {
+		// Two kings: 462 combinations.
+		idx = tbMapKK[tbMapA1D1D4[squares[0]]][squares[1]]
+	}
+	idx *= d.groupIdx[0]
+
+	// Encode the remaining groups as combinations of the squares left
+	// available by the previous groups.
+	remainingPawns := t.hasPawns && t.pawnCount[1] > 0
+	next := 1
+	groupStart := d.groupLen[0]
+	for d.groupLen[next] != 0 {
+		group := squares[groupStart : groupStart + d.groupLen[next]]
+		sort.Ints(group)
+		n := 0
+
+		for i, square := range group {
+			adjust := 0
+			for _, previous := range squares[:groupStart] {
+				if square > previous {
+					adjust++
+				}
+			}
+			n += tbBinomial[i+1][square - adjust - let(remainingPawns, 8, 0)]
+		}
+
+		remainingPawns = false
+		idx += n * d.groupIdx[next]
+		groupStart += d.groupLen[next]
+		next++
+	}
+
+	return idx
+}
+
+// Flips the square along A1-H8 diagonal.
+func tbFlipDiagonal(square int) int {
+	return (square >> 3) | (square & 7) << 3
+}
+
+// Looks up the position in the WDL or DTZ table. For DTZ tables the expected
+// WDL result is required to map the stored value.
+func (p *Position) probeTable(tb *Tablebase, dtz bool, wdl int) (int, int) {
+	if p.board.count() == 2 {
+		return tbDraw, tbOk // Bare kings don't have a table.
+	}
+
+	table, blackStronger := tb.table(p, dtz)
+	if table == nil {
+		return 0, tbFail
+	}
+
+	// The tables are stored with the stronger side being white. The
+	// symmetric tables store white to move only.
+	flip := blackStronger || (table.symmetric && p.color == Black)
+	flipColor, flipSquares, stm := 0, 0, int(p.color)
+	if flip {
+		flipColor, flipSquares, stm = 8, 56, stm ^ 1
+	}
+
+	var squares [tbPieces]int
+	var pieces [tbPieces]int
+	size, leadPawnsCount, file := 0, 0, 0
+	leadPawns := Bitmask(0)
+
+	if table.hasPawns {
+		// The lead color pawns go first, the lead pawn being the one
+		// with the highest tbMapPawns value.
+		color := uint8((table.pairs[0][0].pieces[0] ^ flipColor) >> 3)
+		leadPawns = p.outposts[pawn(color)]
+		for bm := leadPawns; bm.any(); size++ {
+			squares[size] = bm.pop() ^ flipSquares
+		}
+		leadPawnsCount = size
+		for i := 1; i < size; i++ {
+			if tbMapPawns[squares[i]] > tbMapPawns[squares[0]] {
+				squares[0], squares[i] = squares[i], squares[0]
+			}
+		}
+		file = min(col(squares[0]), 7 - col(squares[0]))
+	}
+
+	if dtz && !table.dtzStm(stm, file) {
+		return 0, tbChangeStm
+	}
+
+	for bm := p.board & ^leadPawns; bm.any(); size++ {
+		square := bm.pop()
+		squares[size] = square ^ flipSquares
+		pieces[size] = tbPiece(p.pieces[square]) ^ flipColor
+	}
+
+	// Reorder the pieces to match the table's encoding order.
+	d := &table.pairs[stm % table.sides()][file]
+	for i := leadPawnsCount; i < size - 1; i++ {
+		for j := i + 1; j < size; j++ {
+			if d.pieces[i] == pieces[j] {
+				pieces[i], pieces[j] = pieces[j], pieces[i]
+				squares[i], squares[j] = squares[j], squares[i]
+				break
+			}
+		}
+	}
+
+	value := table.decompress(d, table.index(d, squares[:size], leadPawnsCount))
+	if !dtz {
+		return value - 2, tbOk
+	}
+
+	return table.mapDtz(d, value, wdl), tbOk
+}
+
+// Converts DTZ table value to plies as seen by the side to move.
+func (t *tbTable) mapDtz(d *tbPairs, value, wdl int) int {
+	outcome := [...]int{ 1, 3, 0, 2, 0 }[wdl + 2] // Map index for WDL outcome.
+	if d.flags & tbMapped != 0 {
+		if d.flags & tbWide != 0 {
+			value = int(t.u16(t.dtzMap + 2 * (d.dtzMap[outcome] + value)))
+		} else {
+			value = int(t.data[t.dtzMap + d.dtzMap[outcome] + value])
+		}
+	}
+
+	// DTZ tables store distance in moves unless the table says otherwise.
+	// Wins and losses within the 50 moves rule store exact plies.
+	if (wdl == tbWin && d.flags & tbWinPlies == 0) || (wdl == tbLoss && d.flags & tbLossPlies == 0) ||
+	   wdl == tbCursedWin || wdl == tbBlessedLoss {
+		value *= 2
+	}
+
+	return value + 1
+}
+
+// Returns true if the position has no castling rights and few enough pieces
+// to be looked up in the tablebase.
+func (p *Position) tbProbable(tb *Tablebase, limit int) bool {
+	return tb != nil && p.castles == 0 && p.board.count() <= min(limit, tb.pieces)
+}
+
+// Returns the position's WDL score as seen by the side to move. The tables
+// don't store positions where the best move is a capture (or a pawn move
+// in DTZ tables), so the captures get resolved by a search.
+func (p *Position) probeWdl(tb *Tablebase) (int, int) {
+	return p.tbSearch(tb, false)
+}
+
+func (p *Position) tbSearch(tb *Tablebase, checkZeroing bool) (int, int) {
+	bestValue, moveCount, total := tbLoss, 0, 0
+
+	gen := p.tbMoves()
+	for move := gen.NextMove(); !move.nil(); move = gen.NextMove() {
+		total++
+		if move.capture() == 0 && (!checkZeroing || !move.piece().isPawn()) {
+			continue
+		}
+		moveCount++
+
+		position := p.makeMove(move)
+		value, state := position.tbSearch(tb, false)
+		value = -value
+		position.undoLastMove()
+
+		if state == tbFail {
+			return 0, tbFail
+		}
+		if value > bestValue {
+			bestValue = value
+			if value >= tbWin {
+				return value, tbZeroingBest // Winning capture or pawn move.
+			}
+		}
+	}
+
+	// If all the moves are captures or pawn moves then the table has no
+	// value for the position.
+	noMoreMoves := moveCount != 0 && moveCount == total
+
+	value, state := 0, tbOk
+	if noMoreMoves {
+		value = bestValue
+	} else {
+		if value, state = p.probeTable(tb, false, 0); state == tbFail {
+			return 0, tbFail
+		}
+	}
+
+	// DTZ tables don't store the positions where the best move zeroes the
+	// 50 moves counter.
+	if bestValue >= value {
+		return bestValue, let(bestValue > tbDraw || noMoreMoves, tbZeroingBest, tbOk)
+	}
+
+	return value, tbOk
+}
+
+// Returns the position's DTZ: the number of plies to the zeroing move with
+// the best play, positive when winning and negative when losing. Positions
+// won (or lost) after the 50 moves rule are off by 100.
+func (p *Position) probeDtz(tb *Tablebase) (int, int) {
+	wdl, state := p.tbSearch(tb, true)
+	if state == tbFail {
+		return 0, tbFail
+	} else if wdl == tbDraw {
+		return 0, state
+	}
+
+	// The best move zeroes the counter: it's a capture, pawn move or mate.
+	if state == tbZeroingBest {
+		return tbDtzBeforeZeroing(wdl), state
+	}
+
+	dtz, state := p.probeTable(tb, true, wdl)
+	if state == tbFail {
+		return 0, tbFail
+	} else if state != tbChangeStm {
+		return (dtz + 100 * let(wdl == tbBlessedLoss || wdl == tbCursedWin, 1, 0)) * sign(wdl), state
+	}
+
+	// The table is for the other side to move: try all the moves and pick
+	// the best one.
+	best := 0xFFFF
+	gen := p.tbMoves()
+	for move := gen.NextMove(); !move.nil(); move = gen.NextMove() {
+		zeroing := move.capture() != 0 || move.piece().isPawn()
+		position := p.makeMove(move)
+
+		// For zeroing moves we want DTZ before making the move, and
+		// the search after the move tells its sign.
+		value := 0
+		if zeroing {
+			value, state = position.tbSearch(tb, false)
+			value = -tbDtzBeforeZeroing(value)
+		} else {
+			value, state = position.probeDtz(tb)
+			value = -value
+		}
+
+		// Mating move has DTZ of 1.
+		if value == 1 && position.isInCheck(position.color) && !position.tbMoves().anyValid() {
+			best = 1
+		}
+		if !zeroing {
+			value += sign(value)
+		}
+		if value < best && sign(value) == sign(wdl) {
+			best = value
+		}
+		position.undoLastMove()
+
+		if state == tbFail {
+			return 0, tbFail
+		}
+	}
+
+	// No moves means we've been checkmated.
+	return let(best == 0xFFFF, -1, best), tbOk
+}
+
+// DTZ of the position where the best move zeroes the 50 moves counter.
+func tbDtzBeforeZeroing(wdl int) int {
+	return [...]int{ -1, -101, 0, 101, 1 }[wdl + 2]
+}
+
+func sign(n int) int {
+	return let(n > 0, 1, let(n < 0, -1, 0))
+}
+
+// Returns move generator with legal moves only. The generator is not owned by
+// any ply so that probing doesn't clobber the search's move lists, and it's
+// not staged so that it only returns the moves it has generated.
+func (p *Position) tbMoves() *MoveGen {
+	gen := &MoveGen{ p: p, pins: p.pins(p.king[p.color]), stage: stageDone }
+	return gen.generateAllMoves().validOnly()
+}
+
+// Returns tablebase score of the position as seen by the side to move, or
+// false if the position is not in the tablebase.
+func (p *Position) probeScore(tb *Tablebase, ply int) (int, bool) {
+	wdl, state := p.probeWdl(tb)
+	if state == tbFail {
+		return 0, false
+	}
+
+	switch wdl {
+	case tbWin:
+		return TablebaseWin - ply, true
+	case tbLoss:
+		return ply - TablebaseWin, true
+	}
+	return p.drawScore(), true // Draws, including cursed wins and blessed losses.
+}
+
+// Filters out root moves that don't preserve the tablebase result. Winning side
+// keeps the moves with the shortest distance to zeroing the 50 moves counter,
+// and losing side keeps the ones with the longest. Falls back to WDL tables
+// when DTZ tables are not available. Returns false if the root position is
+// not in the tablebase.
+func (gen *MoveGen) tablebaseRootMoves(tb *Tablebase) bool {
+	p := gen.p
+	ranks := make([]int, 0, gen.size())
+	dtz := len(tb.dtz) > 0
+
+	for move := gen.NextMove(); !move.nil(); move = gen.NextMove() {
+		position := p.makeMove(move)
+		value, state := 0, tbOk
+		if dtz {
+			if value, state = position.rootDtz(tb); state == tbFail {
+				dtz = false
+			}
+		}
+		if !dtz {
+			value, state = position.probeWdl(tb)
+			value = -value
+		}
+		position.undoLastMove()
+		if state == tbFail {
+			gen.reset()
+			return false
+		}
+		ranks = append(ranks, value)
+	}
+
+	// Pick the best rank: the shortest win, the longest loss, or a draw.
+	best := ranks[0]
+	for _, rank := range ranks {
+		if tbBetter(rank, best, dtz) {
+			best = rank
+		}
+	}
+
+	gen.reset()
+	for i := 0; gen.NextMove() != Move(0); i++ {
+		if ranks[i] != best {
+			gen.remove()
+		}
+	}
+	gen.reset()
+
+	return true
+}
+
+// Returns DTZ of the root move that has just been made as seen by the side
+// that made it.
+func (p *Position) rootDtz(tb *Tablebase) (int, int) {
+	if p.isInCheck(p.color) && !p.tbMoves().anyValid() {
+		return 1, tbOk // Checkmate.
+	}
+
+	if p.count50 == 0 {
+		wdl, state := p.probeWdl(tb)
+		return tbDtzBeforeZeroing(-wdl), state
+	}
+
+	dtz, state := p.probeDtz(tb)
+	if dtz > 0 {
+		return -dtz - 1, state
+	} else if dtz < 0 {
+		return -dtz + 1, state
+	}
+	return 0, state
+}
+
+// Returns true if DTZ (or WDL) rank is better than the other one.
+func tbBetter(rank, other int, dtz bool) bool {
+	if !dtz {
+		return rank > other
+	}
+	switch {
+	case rank > 0 && other > 0:
+		return rank < other // Faster win.
+	case rank < 0 && other < 0:
+		return rank < other // Slower loss.
+	}
+	return sign(rank) > sign(other)
+}
+
+// Opens the tablebase if it hasn't been opened yet.
+func (e *Engine) tablebase() *Tablebase {
+	e.syzygyOnce.Do(func() {
+		if e.syzygyPath != `` {
+			e.syzygy = NewTablebase(e.syzygyPath)
+		}
+	})
+	return e.syzygy
+}
+
+// Sets tablebase path; the tables get scanned on the next probe.
+func (e *Engine) setTablebasePath(path string) *Engine {
+	if path == `<empty>` {
+		path = ``
+	}
+	e.syzygyPath, e.syzygy, e.syzygyOnce = path, nil, sync.Once{}
+	return e
+}
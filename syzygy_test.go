@@ -0,0 +1,1198 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`github.com/michaeldv/donna/expect`
+	`context`
+	`flag`
+	`fmt`
+	`io/ioutil`
+	`math/rand`
+	`os`
+	`path/filepath`
+	`sort`
+	`strings`
+	`testing`
+)
+
+// Test tables in testdata/syzygy get generated by retrograde analysis below.
+// To rebuild them run: go test -run TestSyzygy000 -syzygy
+var syzygyGenerate = flag.Bool(`syzygy`, false, `regenerate Syzygy tables in testdata/syzygy`)
+
+const syzygyTestdata = `testdata/syzygy`
+
+func syzygyEngine() *Engine {
+	return NewEngine(`syzygypath`, syzygyTestdata)
+}
+
+func syzygyPosition(engine *Engine, fen string) *Position {
+	return engine.NewGame(fen).start()
+}
+
+// Known results.
+func TestSyzygy000(t *testing.T) {
+	if *syzygyGenerate {
+		generateSyzygy(t)
+	}
+
+	engine := syzygyEngine()
+	tb := engine.tablebase()
+	expect.Eq(t, tb.MaxPieces(), 4)
+
+	for fen, expected := range map[string]int{
+		`8/8/8/4k3/8/8/8/KQ6 w - - 0 1`:   tbWin,  // Queen up.
+		`8/8/8/4k3/8/8/8/KQ6 b - - 0 1`:   tbLoss,
+		`8/8/8/8/8/2k5/1Q6/7K b - - 0 1`:  tbDraw, // Black takes the queen.
+		`k7/2Q5/1K6/8/8/8/8/8 b - - 0 1`:  tbDraw, // Stalemate.
+		`k7/1Q6/1K6/8/8/8/8/8 b - - 0 1`:  tbLoss, // Checkmate.
+		`8/8/3k4/8/8/8/8/R3K3 b - - 0 1`:  tbLoss,
+		`4k3/8/4K3/4P3/8/8/8/8 w - - 0 1`: tbWin,  // King in front of the pawn.
+		`4k3/8/4K3/4P3/8/8/8/8 b - - 0 1`: tbLoss,
+		`4k3/4P3/4K3/8/8/8/8/8 w - - 0 1`: tbWin,  // Kd6 Kf7 Kd7.
+		`4k3/4P3/4K3/8/8/8/8/8 b - - 0 1`: tbDraw, // Stalemate.
+		`8/8/8/8/8/2k5/8/K5B1 w - - 0 1`:  tbDraw,
+		`7r/6k1/8/8/8/8/Q2K4/8 b - - 0 1`: tbWin,  // Rook skewers the king and the queen.
+		`7r/6k1/8/8/8/8/Q2K4/8 w - - 0 1`: tbWin,
+		`r3k3/8/8/8/8/8/8/R3K3 w - - 0 1`: tbWin,
+		`3rk3/8/8/8/8/8/8/3RK3 w - - 0 1`: tbDraw,
+		`8/8/8/4k3/8/8/8/KBN5 w - - 0 1`:  tbWin,
+		`8/8/8/4k3/8/8/8/KNN5 w - - 0 1`:  tbDraw,
+		`7k/4N3/6K1/6N1/8/8/8/8 w - - 0 1`: tbWin, // Nf7 mate.
+	} {
+		wdl, state := syzygyPosition(engine, fen).probeWdl(tb)
+		expect.Ne(t, state, tbFail)
+		expect.Eq(t, wdl, expected)
+	}
+}
+
+// Distance to zeroing the 50 moves counter.
+func TestSyzygy010(t *testing.T) {
+	engine := syzygyEngine()
+	tb := engine.tablebase()
+
+	for fen, expected := range map[string]int{
+		`k7/8/1K6/8/8/8/8/7R w - - 0 1`:   1,  // Mate in one.
+		`k7/1Q6/1K6/8/8/8/8/8 b - - 0 1`:  -1, // Checkmated.
+		`4k3/8/4K3/4P3/8/8/8/8 w - - 0 1`: 3,  // Kd6 (Kf7) Kd8 and then e6.
+		`8/8/8/8/8/2k5/1Q6/7K b - - 0 1`:  0,
+		`7r/6k1/8/8/8/8/Q2K4/8 b - - 0 1`: 3,  // Rh2 and Rxa2.
+		`r3k3/8/8/8/8/8/8/R3K3 w - - 0 1`: 1,
+		`7k/4N3/6K1/6N1/8/8/8/8 w - - 0 1`: 1,
+	} {
+		dtz, state := syzygyPosition(engine, fen).probeDtz(tb)
+		expect.Ne(t, state, tbFail)
+		expect.Eq(t, dtz, expected)
+	}
+}
+
+// Probed scores of random positions must agree with the scores after each
+// legal move.
+func TestSyzygy020(t *testing.T) {
+	engine := syzygyEngine()
+	tb := engine.tablebase()
+	random := rand.New(rand.NewSource(2016))
+
+	for _, pieces := range [][]Piece{ { King, Queen, BlackKing }, { King, Rook, BlackKing }, { King, Pawn, BlackKing },
+		{ King, Queen, BlackKing, BlackRook }, { King, Rook, BlackKing, BlackRook },
+		{ King, Bishop, Knight, BlackKing }, { King, Knight, Knight, BlackKing } } {
+		for count := 0; count < 100; {
+			p := syzygyRandomPosition(engine, random, pieces)
+			if p == nil {
+				continue
+			}
+			count++
+
+			wdl, state := p.probeWdl(tb)
+			expect.Ne(t, state, tbFail)
+			dtz, state := p.probeDtz(tb)
+			expect.Ne(t, state, tbFail)
+
+			bestWdl, bestDtz, moves := tbLoss, 0, p.Moves()
+			if len(moves) == 0 && p.isInCheck(p.color) {
+				bestDtz = -1
+			} else if len(moves) == 0 {
+				bestWdl = tbDraw
+			}
+			for _, move := range moves {
+				position := p.makeMove(move)
+				childWdl, _ := position.probeWdl(tb)
+				childDtz, _ := position.probeDtz(tb)
+				mated := position.isInCheck(position.color) && len(position.Moves()) == 0
+				position.undoLastMove()
+
+				bestWdl = max(bestWdl, -childWdl)
+				zeroing := move.capture() != 0 || move.piece().isPawn()
+				value := 0
+				switch {
+				case mated:
+					value = 1
+				case zeroing:
+					value = tbDtzBeforeZeroing(-childWdl)
+				case childDtz < 0:
+					value = -childDtz + 1
+				case childDtz > 0:
+					value = -childDtz - 1
+				}
+				switch {
+				case wdl > 0 && value > 0:
+					bestDtz = let(bestDtz <= 0, value, min(bestDtz, value))
+				case wdl < 0 && value < 0:
+					bestDtz = min(bestDtz, value)
+				}
+			}
+
+			expect.Eq(t, wdl, bestWdl)
+			expect.Eq(t, dtz, bestDtz)
+		}
+	}
+}
+
+// Root moves get filtered down to the ones that preserve the win.
+func TestSyzygy030(t *testing.T) {
+	engine := syzygyEngine()
+	p := syzygyPosition(engine, `k7/8/1K6/8/8/8/8/7R w - - 0 1`)
+
+	gen := NewRootGen(p, 1).generateRootMoves()
+	expect.True(t, gen.tablebaseRootMoves(engine.tablebase()))
+	expect.Eq(t, gen.size(), 1)
+	expect.Eq(t, gen.NextMove(), NewMove(p, H1, H8))
+
+	// Drawn position: only the moves that keep the draw survive.
+	p = syzygyPosition(engine, `8/8/8/8/3k4/8/4P3/4K3 b - - 0 1`)
+	gen = NewRootGen(p, 1).generateRootMoves()
+	size := gen.size()
+	expect.True(t, gen.tablebaseRootMoves(engine.tablebase()))
+	expect.True(t, gen.size() < size)
+	for move := gen.NextMove(); !move.nil(); move = gen.NextMove() {
+		position := p.makeMove(move)
+		wdl, _ := position.probeWdl(engine.tablebase())
+		position.undoLastMove()
+		expect.Eq(t, wdl, tbDraw)
+	}
+}
+
+// Search gets tablebase scores after pawn moves and stays within the win.
+func TestSyzygy040(t *testing.T) {
+	engine := syzygyEngine()
+	game := engine.NewGame(`4k3/8/4K3/4P3/8/8/8/8 w - - 0 1`)
+	game.Start()
+
+	result, err := game.Search(context.Background(), Limits{ Depth: 4 })
+	expect.Eq(t, err, nil)
+	expect.True(t, result.Score > TablebaseWin - MaxPly)
+	expect.Eq(t, result.Mate, 0)
+
+	// Probe limit turns the probes off.
+	engine.syzygyLimit = 2
+	result, _ = game.Search(context.Background(), Limits{ Depth: 4 })
+	expect.True(t, result.Score < TablebaseWin - MaxPly)
+}
+
+// Path list with missing directories and paths with spaces.
+func TestSyzygy050(t *testing.T) {
+	dir, err := ioutil.TempDir(``, `donna syzygy`)
+	expect.Eq(t, err, nil)
+	defer os.RemoveAll(dir)
+
+	expect.True(t, NewTablebase(dir) == nil)
+	expect.True(t, NewTablebase(``) == nil)
+
+	data, _ := ioutil.ReadFile(filepath.Join(syzygyTestdata, `KRvK.rtbw`))
+	ioutil.WriteFile(filepath.Join(dir, `KRvK.rtbw`), data, 0644)
+	ioutil.WriteFile(filepath.Join(dir, `KQvK.rtbw`), data[:64], 0644) // Broken.
+
+	engine := NewEngine()
+	engine.setTablebasePath(`nowhere` + string(os.PathListSeparator) + dir)
+	tb := engine.tablebase()
+	expect.Eq(t, tb.MaxPieces(), 3)
+
+	_, state := syzygyPosition(engine, `8/8/3k4/8/8/8/8/R3K3 b - - 0 1`).probeWdl(tb)
+	expect.Eq(t, state, tbOk)
+	_, state = syzygyPosition(engine, `8/8/8/4k3/8/8/8/KQ6 w - - 0 1`).probeWdl(tb)
+	expect.Eq(t, state, tbFail)
+
+	engine.setTablebasePath(`<empty>`)
+	expect.True(t, engine.tablebase() == nil)
+}
+
+// Tablebase draw gets the same score as any other draw, i.e. with contempt.
+func TestSyzygy060(t *testing.T) {
+	engine := NewEngine(`syzygypath`, syzygyTestdata, `contempt`, 50)
+	p := syzygyPosition(engine, `8/8/8/8/3k4/8/4P3/4K3 b - - 0 1`)
+	p.game().getReady()
+
+	score, ok := p.probeScore(engine.tablebase(), p.ply())
+	expect.True(t, ok)
+	expect.Eq(t, score, p.drawScore())
+	expect.Ne(t, score, 0)
+}
+
+// Tablebase move list has legal moves only, ex. the king can't step back along
+// the queen's diagonal.
+func TestSyzygy070(t *testing.T) {
+	p := syzygyPosition(syzygyEngine(), `8/8/8/8/8/4k3/8/K1Q5 b - - 0 1`)
+	expect.Eq(t, p.tbMoves().allMoves(), p.Moves())
+	expect.False(t, p.tbMoves().amongValid(NewMove(p, E3, F4)))
+}
+
+// Tables agree with the bitbases.
+func TestSyzygy080(t *testing.T) {
+	engine := syzygyEngine()
+	tb := engine.tablebase()
+	random := rand.New(rand.NewSource(2016))
+
+	for _, pieces := range [][]Piece{ { King, Pawn, BlackKing }, { King, Rook, BlackKing }, { King, Queen, BlackKing }, { King, Queen, BlackKing, BlackRook } } {
+		for count := 0; count < 1000; {
+			p := syzygyRandomPosition(engine, random, pieces)
+			if p == nil {
+				continue
+			}
+			count++
+
+			wdl, state := p.probeWdl(tb)
+			expect.Ne(t, state, tbFail)
+			win := (&Evaluation{ position: p }).bitbaseWin(pieces[1])
+			expect.Eq(t, win, wdl == let(p.color == White, tbWin, tbLoss))
+		}
+	}
+}
+
+// Longest wins match the endgame theory: mate in 10 with the queen, in 16
+// with the rook, and in 33 with the bishop and knight.
+func TestSyzygy090(t *testing.T) {
+	engine := syzygyEngine()
+	tb := engine.tablebase()
+
+	for fen, expected := range map[string]int{
+		`8/8/8/4k3/8/8/8/KQ6 w - - 0 1`:  19,
+		`8/8/8/4k3/8/8/8/KR6 w - - 0 1`:  31,
+		`8/8/8/4k3/8/8/8/KBN5 w - - 0 1`: 65,
+	} {
+		p := syzygyPosition(engine, fen)
+		table, _ := tb.table(p, true)
+
+		// Win map is the first one: value count followed by the values
+		// stored as plies minus one.
+		longest, pos := 0, table.dtzMap + table.pairs[0][0].dtzMap[0]
+		for i := 0; i < int(table.data[pos - 1]); i++ {
+			longest = max(longest, int(table.data[pos + i]) + 1)
+		}
+		expect.Eq(t, longest, expected)
+	}
+}
+
+// Returns random legal position with given pieces or nil.
+func syzygyRandomPosition(engine *Engine, random *rand.Rand, pieces []Piece) *Position {
+	p := &Position{}
+	for _, piece := range pieces {
+		square := random.Intn(64)
+		if !p.pieces[square].nil() || (piece.isPawn() && (row(square) == 0 || row(square) == 7)) {
+			return nil
+		}
+		p.pieces[square] = piece
+	}
+
+	p.color = uint8(random.Intn(2))
+	position := syzygyPosition(engine, p.fen())
+	if position.isInCheck(position.color ^ 1) {
+		return nil
+	}
+
+	return position
+}
+
+// Retrograde analysis of the endgames with up to four pieces. The white king
+// goes first, and the rest of the pieces are listed in the table's order.
+// Positions are indexed by the side to move, the white king's square within
+// its symmetry area, and the squares of the rest of the pieces.
+type syzygyGenerator struct {
+	name    string
+	pieces  []Piece
+	pawns   bool
+	kings   []int   // White king squares that are not mirror images of each other.
+	area    [64]int // White king square to its number in kings[].
+	size    int
+	valid   []bool
+	checked []bool  // Side to move is in check.
+	first   []int32 // First move of the position in moves[].
+	moves   []int32 // Positions after each move, see below.
+	wdl     []int8
+	dtz     []int16
+}
+
+const syzygyZeroing = 1 << 30 // Capture or pawn move.
+
+var syzygyGenerated = map[string]*syzygyGenerator{}
+
+func generateSyzygy(t *testing.T) {
+	os.MkdirAll(syzygyTestdata, 0755)
+	for _, pieces := range [][]Piece{
+		{ King, Bishop, BlackKing }, { King, Knight, BlackKing }, { King, Queen, BlackKing },
+		{ King, Rook, BlackKing }, { King, Pawn, BlackKing },
+		{ King, Queen, BlackKing, BlackRook }, { King, Rook, BlackKing, BlackRook },
+		{ King, Knight, Knight, BlackKing }, { King, Bishop, Knight, BlackKing },
+	} {
+		generator := newSyzygyGenerator(pieces)
+		generator.analyze()
+		syzygyGenerated[generator.name] = generator
+
+		for _, dtz := range []bool{ false, true } {
+			name := generator.name + []string{ `.rtbw`, `.rtbz` }[let(dtz, 1, 0)]
+			if err := ioutil.WriteFile(filepath.Join(syzygyTestdata, name), generator.write(dtz), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Read the tables back and make sure the probes return what has been
+	// written.
+	engine := syzygyEngine()
+	tb := engine.tablebase()
+	game := engine.NewGame()
+	for _, generator := range syzygyGenerated {
+		for index := 0; index < generator.size; index++ {
+			p := generator.setup(game, index)
+			if p == nil || !generator.valid[index] {
+				continue
+			}
+			if wdl, _ := p.probeWdl(tb); wdl != int(generator.wdl[index]) {
+				t.Fatalf("%s: %s WDL %d instead of %d", generator.name, p.fen(), wdl, generator.wdl[index])
+			}
+			if dtz, _ := p.probeDtz(tb); dtz != int(generator.dtz[index]) {
+				t.Fatalf("%s: %s DTZ %d instead of %d", generator.name, p.fen(), dtz, generator.dtz[index])
+			}
+		}
+	}
+}
+
+func newSyzygyGenerator(pieces []Piece) *syzygyGenerator {
+	g := &syzygyGenerator{ pieces: pieces }
+	p := &Position{}
+	for _, piece := range pieces {
+		p.outposts[piece].set(p.board.count())
+		p.board.set(p.board.count())
+		g.pawns = g.pawns || piece.isPawn()
+	}
+	g.name = tbName(p.tbMaterial(White), p.tbMaterial(Black))
+
+	// The tables with pawns are only symmetric left to right, and the rest
+	// of them keep the white king within A1-D1-D4 triangle.
+	for square := A1; square <= H8; square++ {
+		if col(square) <= 3 && (g.pawns || (row(square) <= col(square))) {
+			g.area[square] = len(g.kings)
+			g.kings = append(g.kings, square)
+		}
+	}
+	g.size = 2 * len(g.kings) << uint(6 * (len(pieces) - 1))
+
+	return g
+}
+
+// Returns the position's index given the squares of the pieces in the
+// generator's order.
+func (g *syzygyGenerator) index(color int, squares []int) int {
+	flip, transposed := 0, false
+	if col(squares[0]) > 3 {
+		flip ^= 7
+	}
+	if !g.pawns {
+		if row(squares[0]) > 3 {
+			flip ^= 56
+		}
+		transposed = row(squares[0] ^ flip) > col(squares[0] ^ flip)
+	}
+
+	index := color * len(g.kings)
+	for i, square := range squares {
+		square ^= flip
+		if transposed {
+			square = transpose(square)
+		}
+		if i == 0 {
+			index += g.area[square]
+		} else {
+			index = index << 6 | square
+		}
+	}
+
+	return index
+}
+
+// Returns the index of the position that has the generator's material. The
+// colors get swapped if the generator's white is position's black.
+func (g *syzygyGenerator) lookup(p *Position, swap int) int {
+	squares, taken := make([]int, len(g.pieces)), Bitmask(0)
+	for i, piece := range g.pieces {
+		square := (p.outposts[piece ^ Piece(swap)] & ^taken).first()
+		taken.set(square)
+		squares[i] = square ^ (56 * swap)
+	}
+
+	return g.index(int(p.color) ^ swap, squares)
+}
+
+// Returns the squares of the pieces and the side to move, or nil if the
+// index doesn't make sense.
+func (g *syzygyGenerator) squares(index int) ([]int, int) {
+	squares := make([]int, len(g.pieces))
+	for i := len(g.pieces) - 1; i > 0; i-- {
+		squares[i], index = index & 63, index >> 6
+	}
+	squares[0] = g.kings[index % len(g.kings)]
+
+	return squares, index / len(g.kings)
+}
+
+func (g *syzygyGenerator) setup(game *Game, index int) *Position {
+	squares, color := g.squares(index)
+	worker := game.workers[0]
+	worker.node = 0
+	worker.tree[0] = Position{ worker: worker, color: uint8(color), score: Unknown }
+	p := &worker.tree[0]
+
+	for i, piece := range g.pieces {
+		square := squares[i]
+		if !p.pieces[square].nil() || (piece.isPawn() && (row(square) == 0 || row(square) == 7)) {
+			return nil
+		}
+		p.pieces[square] = piece
+		p.outposts[piece].set(square)
+		p.outposts[piece.color()].set(square)
+		if piece.isKing() {
+			p.king[piece.color()] = uint8(square)
+		}
+	}
+	p.board = p.outposts[White] | p.outposts[Black]
+
+	return p
+}
+
+// Returns WDL score of the position using the tables generated so far.
+func syzygyGeneratedWdl(p *Position) int {
+	if p.board.count() == 2 {
+		return tbDraw
+	}
+
+	white, black := p.tbMaterial(White), p.tbMaterial(Black)
+	if g := syzygyGenerated[tbName(white, black)]; g != nil {
+		return int(g.wdl[g.lookup(p, 0)])
+	}
+	if g := syzygyGenerated[tbName(black, white)]; g != nil {
+		return int(g.wdl[g.lookup(p, 1)])
+	}
+	panic(`donna: missing table ` + tbName(white, black))
+}
+
+// Generates the moves. The position after the move is either the index of
+// the position in the same table or, for the captures and promotions, its
+// negative WDL score minus three.
+func (g *syzygyGenerator) analyze() {
+	game := NewGame()
+	g.valid, g.checked = make([]bool, g.size), make([]bool, g.size)
+	g.first = make([]int32, g.size + 1)
+
+	for index := 0; index < g.size; index++ {
+		g.first[index] = int32(len(g.moves))
+		p := g.setup(game, index)
+		if p == nil || p.isInCheck(p.color ^ 1) {
+			continue
+		}
+		g.valid[index], g.checked[index] = true, p.isInCheck(p.color)
+
+		gen := NewGen(p, MaxPly).generateAllMoves().validOnly()
+		for move := gen.NextMove(); !move.nil(); move = gen.NextMove() {
+			position := p.makeMove(move)
+			if tbName(position.tbMaterial(White), position.tbMaterial(Black)) == g.name {
+				g.moves = append(g.moves, int32(g.lookup(position, 0) | let(move.piece().isPawn(), syzygyZeroing, 0)))
+			} else {
+				g.moves = append(g.moves, int32(-(syzygyGeneratedWdl(position) + 3)))
+			}
+			position.undoLastMove()
+		}
+	}
+	g.first[g.size] = int32(len(g.moves))
+
+	// Win, draw, or loss: the position is won if there is a move to lost
+	// position and lost if all the moves lead to won positions.
+	known := make([]bool, g.size)
+	g.wdl = make([]int8, g.size)
+	for index := range g.valid {
+		if g.valid[index] && g.first[index] == g.first[index + 1] {
+			known[index], g.wdl[index] = true, int8(let(g.checked[index], tbLoss, tbDraw))
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for index := range g.valid {
+			if !g.valid[index] || known[index] {
+				continue
+			}
+			win, loss := false, true
+			for _, move := range g.moves[g.first[index]:g.first[index + 1]] {
+				value, ok := g.moveWdl(move), move < 0 || known[move &^ syzygyZeroing]
+				win = win || (ok && value == tbLoss)
+				loss = loss && ok && value == tbWin
+			}
+			if win || loss {
+				known[index], g.wdl[index], changed = true, int8(let(win, tbWin, tbLoss)), true
+			}
+		}
+	}
+
+	// Distance to zeroing in plies: winning side picks the shortest way to
+	// zero the counter, and losing side picks the longest one.
+	g.dtz = make([]int16, g.size)
+	for index := range g.valid {
+		if !g.valid[index] || g.wdl[index] == tbDraw {
+			continue
+		}
+		zeroing := true
+		for _, move := range g.moves[g.first[index]:g.first[index + 1]] {
+			if move >= 0 && move & syzygyZeroing == 0 {
+				zeroing = false
+				if g.wdl[index] == tbWin && g.wdl[move] == tbLoss && g.first[move] == g.first[move + 1] {
+					g.dtz[index] = 1 // Checkmate.
+				}
+			} else if g.wdl[index] == tbWin && g.moveWdl(move) == tbLoss {
+				g.dtz[index] = 1
+			}
+		}
+		if g.wdl[index] == tbLoss && zeroing {
+			g.dtz[index] = -1
+		}
+	}
+	for distance, changed := 2, true; changed; distance++ {
+		changed = false
+		for index := range g.valid {
+			if !g.valid[index] || g.wdl[index] == tbDraw || g.dtz[index] != 0 {
+				continue
+			}
+			win, loss := false, true
+			for _, move := range g.moves[g.first[index]:g.first[index + 1]] {
+				if move >= 0 && move & syzygyZeroing == 0 {
+					value := int(g.dtz[move])
+					win = win || value == 1 - distance
+					loss = loss && value > 0 && value < distance
+				}
+			}
+			if g.wdl[index] == tbWin && win {
+				g.dtz[index], changed = int16(distance), true
+			} else if g.wdl[index] == tbLoss && loss {
+				g.dtz[index], changed = int16(-distance), true
+			}
+		}
+		if distance > 100 {
+			panic(`donna: no support for the 50 moves rule in ` + g.name)
+		}
+	}
+	g.first, g.moves = nil, nil
+}
+
+// Returns WDL score of the position after the move.
+func (g *syzygyGenerator) moveWdl(move int32) int {
+	if move < 0 {
+		return -int(move) - 3
+	}
+	return int(g.wdl[move &^ syzygyZeroing])
+}
+
+// Syzygy encoding of the positions written from the format description: it
+// doesn't share any code with the reader so that the two could check each
+// other. Each side to move has its own order of the pieces, and the index
+// is a sum of the groups' indices multiplied by their factors.
+type syzygyLayout struct {
+	order   []int // Generator's piece numbers in the table's order.
+	lead    int   // Order of the leading group's factor.
+	pawns   bool  // Lead pawn goes first.
+	unique  bool  // Three unique leading pieces instead of two kings.
+	groups  []int // Group lengths, leading group first.
+	factors []int
+	size    int
+}
+
+// Squares of A1-D1-D4 triangle, the ones below the diagonal first.
+var syzygyTriangle = []int{ B1, C1, D1, C2, D2, D3, A1, B2, C3, D4 }
+
+// Index of two kings with the first one in A1-D1-D4 triangle.
+var syzygyKings = func() (kings [10][64]int) {
+	code, deferred := 0, [][2]int{}
+	for i, king := range syzygyTriangle {
+		for other := A1; other <= H8; other++ {
+			if abs(row(king) - row(other)) <= 1 && abs(col(king) - col(other)) <= 1 {
+				continue // Same or adjacent square.
+			}
+			if row(king) == col(king) && row(other) > col(other) {
+				continue // Mirror image of the position below the diagonal.
+			}
+			if row(king) == col(king) && row(other) == col(other) {
+				deferred = append(deferred, [2]int{ i, other })
+			} else {
+				kings[i][other] = code; code++
+			}
+		}
+	}
+	for _, pair := range deferred {
+		kings[pair[0]][pair[1]] = code; code++
+	}
+	return
+}()
+
+// Number of ways to pick k out of n.
+func syzygyChoose(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 0; i < k; i++ {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
+
+// Number of the square below A1-H8 diagonal counting from B1.
+func syzygyBelow(square int) int {
+	n := 0
+	for s := A1; s < square; s++ {
+		if row(s) < col(s) {
+			n++
+		}
+	}
+	return n
+}
+
+// Sets up encoding layout given the order of the pieces and the position of
+// the leading group's factor.
+func (g *syzygyGenerator) layout(order []int, lead int) *syzygyLayout {
+	l := &syzygyLayout{ order: order, lead: lead, pawns: g.pawns }
+	counts := map[Piece]int{}
+	for _, piece := range g.pieces {
+		counts[piece]++
+	}
+	for piece, count := range counts {
+		l.unique = l.unique || (count == 1 && !piece.isKing())
+	}
+
+	leading := let(g.pawns, 1, let(l.unique, 3, 2))
+	l.groups = []int{ leading }
+	for i := leading; i < len(order); i++ {
+		if i > leading && g.pieces[order[i]] == g.pieces[order[i-1]] {
+			l.groups[len(l.groups) - 1]++
+		} else {
+			l.groups = append(l.groups, 1)
+		}
+	}
+
+	l.factors = make([]int, len(l.groups))
+	size, free, next := 1, 64 - leading, 1
+	for k := 0; next < len(l.groups) || k == lead; k++ {
+		if k == lead {
+			l.factors[0] = size
+			size *= let(g.pawns, 6, let(l.unique, 31332, 462))
+		} else {
+			l.factors[next] = size
+			size *= syzygyChoose(free, l.groups[next])
+			free -= l.groups[next]
+			next++
+		}
+	}
+	l.size = size
+
+	return l
+}
+
+// Returns the index and lead pawn file of the pieces placed on the given
+// squares, which are in generator's order.
+func (l *syzygyLayout) encode(generator []int) (int, int) {
+	squares := make([]int, len(l.order))
+	for i, n := range l.order {
+		squares[i] = generator[n]
+	}
+
+	// Mirror the board so that the first piece ends up on A-D files, and for
+	// the tables without pawns within A1-D1-D4 triangle, with the first of
+	// the leading pieces that is off the diagonal being below it.
+	mirror := func(from int, transform func(int) int) {
+		for i := from; i < len(squares); i++ {
+			squares[i] = transform(squares[i])
+		}
+	}
+	if col(squares[0]) > 3 {
+		mirror(0, func(square int) int { return square ^ 7 })
+	}
+	if !l.pawns {
+		if row(squares[0]) > 3 {
+			mirror(0, func(square int) int { return square ^ 56 })
+		}
+		for i := 0; i < l.groups[0]; i++ {
+			if row(squares[i]) > col(squares[i]) {
+				mirror(i, transpose)
+			}
+			if row(squares[i]) != col(squares[i]) {
+				break
+			}
+		}
+	}
+
+	index := 0
+	switch {
+	case l.pawns:
+		index = row(squares[0]) - 1
+	case !l.unique:
+		for i, square := range syzygyTriangle {
+			if square == squares[0] {
+				index = syzygyKings[i][squares[1]]
+			}
+		}
+	default:
+		s0, s1, s2 := squares[0], squares[1], squares[2]
+		s1 -= let(s1 > s0, 1, 0)
+		s2 -= let(s2 > squares[0], 1, 0) + let(s2 > squares[1], 1, 0)
+		switch {
+		case row(s0) != col(s0):
+			for i, square := range syzygyTriangle[:6] {
+				if square == s0 {
+					index = (i * 63 + s1) * 62 + s2
+				}
+			}
+		case row(squares[1]) != col(squares[1]):
+			index = 6 * 63 * 62 + (row(s0) * 28 + syzygyBelow(squares[1])) * 62 + s2
+		case row(squares[2]) != col(squares[2]):
+			index = 6 * 63 * 62 + 4 * 28 * 62 + (row(s0) * 7 + row(squares[1]) - let(squares[1] > s0, 1, 0)) * 28 + syzygyBelow(squares[2])
+		default:
+			index = 6 * 63 * 62 + 4 * 28 * 62 + 4 * 7 * 28 + (row(s0) * 7 + row(squares[1]) - let(squares[1] > s0, 1, 0)) * 6 + row(squares[2]) - let(squares[2] > s0, 1, 0) - let(squares[2] > squares[1], 1, 0)
+		}
+	}
+	index *= l.factors[0]
+
+	// The rest of the groups are combinations of the squares not taken by
+	// the preceding pieces.
+	start := l.groups[0]
+	for n, length := range l.groups[1:] {
+		group := append([]int{}, squares[start : start + length]...)
+		sort.Ints(group)
+		combination := 0
+		for i, square := range group {
+			taken := 0
+			for _, previous := range squares[:start] {
+				taken += let(previous < square, 1, 0)
+			}
+			combination += syzygyChoose(square - taken, i + 1)
+		}
+		index += combination * l.factors[n + 1]
+		start += length
+	}
+
+	return index, col(squares[0])
+}
+
+// Returns encoding layouts for white and black to move. Kings go first for
+// white, and for black the order is different so that the reader wouldn't
+// get away with assuming the same order for both sides.
+func (g *syzygyGenerator) layouts() (layouts [2]*syzygyLayout) {
+	var kings, pieces, pawns []int
+	for n, piece := range g.pieces {
+		switch {
+		case piece.isKing():
+			kings = append(kings, n)
+		case piece.isPawn():
+			pawns = append(pawns, n)
+		default:
+			pieces = append(pieces, n)
+		}
+	}
+	reversed := []int{ kings[1], kings[0] }
+
+	if g.pawns {
+		layouts[0] = g.layout(append(append(append([]int{}, pawns...), kings...), pieces...), 0)
+		layouts[1] = g.layout(append(append(append([]int{}, pawns...), reversed...), pieces...), 2)
+		return
+	}
+
+	layouts[0] = g.layout(append(append([]int{}, kings...), pieces...), 0)
+	if layouts[0].unique {
+		layouts[1] = g.layout(append(append([]int{}, pieces...), reversed...), let(len(g.pieces) > 3, 1, 0))
+	} else {
+		layouts[1] = g.layout(append(append([]int{}, kings...), pieces...), 1)
+	}
+	return
+}
+
+// Writes the table: the values get compressed with RE-PAIR and Huffman code
+// just like in the genuine tables, and DTZ values go through the maps.
+func (g *syzygyGenerator) write(dtz bool) []byte {
+	const blockLog, spanLog = 6, 10
+
+	sides := strings.Split(g.name, `v`)
+	symmetric := sides[0] == sides[1]
+	stored, files := let(dtz || symmetric, 1, 2), let(g.pawns, 4, 1)
+	stm := let(dtz && g.name == `KQvKR`, 1, 0) // One DTZ table for black to move.
+	layouts := g.layouts()
+	if stored == 1 {
+		layouts[0] = layouts[stm]
+	}
+
+	// Collect the values for each side to move and lead pawn file. Mirror
+	// images of the same position must end up with the same value.
+	var values [2][4][]int
+	for i := 0; i < stored; i++ {
+		for f := 0; f < files; f++ {
+			values[i][f] = make([]int, layouts[i].size)
+			for n := range values[i][f] {
+				values[i][f][n] = -1
+			}
+		}
+	}
+	for index := range g.valid {
+		squares, color := g.squares(index)
+		if !g.valid[index] || (stored == 1 && color != stm) || (dtz && g.wdl[index] == tbDraw) {
+			continue
+		}
+		side := color % stored
+		value := int(g.wdl[index]) + 2
+		if dtz {
+			value = let(g.wdl[index] < 0, 1 << 16, 0) | (abs(int(g.dtz[index])) - 1)
+		}
+		// The table might keep some of the mirror images apart, ex. when
+		// all its leading pieces are on the diagonal.
+		for mirror := 0; mirror < let(g.pawns, 2, 8); mirror++ {
+			image := make([]int, len(squares))
+			for i, square := range squares {
+				image[i] = square ^ (mirror & 1) * 7 ^ (mirror >> 1 & 1) * 56
+				if mirror & 4 != 0 {
+					image[i] = transpose(image[i])
+				}
+			}
+			n, file := layouts[side].encode(image)
+			file = let(g.pawns, file, 0)
+			if previous := values[side][file][n]; previous >= 0 && previous != value {
+				panic(fmt.Sprintf("donna: %s index %d gets %d and %d", g.name, n, previous, value))
+			}
+			values[side][file][n] = value
+		}
+	}
+
+	// DTZ maps list the distances for wins and losses, most frequent ones
+	// first, and the tables store their positions in the map.
+	var maps [4][4][]int
+	for f := 0; f < files && dtz; f++ {
+		for outcome := 0; outcome < 2; outcome++ {
+			counts := map[int]int{}
+			for _, value := range values[0][f] {
+				if value >= 0 && value >> 16 == outcome {
+					counts[value & 0xFFFF]++
+				}
+			}
+			for distance := range counts {
+				maps[f][outcome] = append(maps[f][outcome], distance)
+			}
+			list := maps[f][outcome]
+			sort.Slice(list, func(i, j int) bool {
+				return counts[list[i]] > counts[list[j]] || (counts[list[i]] == counts[list[j]] && list[i] < list[j])
+			})
+		}
+		for n, value := range values[0][f] {
+			if value >= 0 {
+				for rank, distance := range maps[f][value >> 16] {
+					if distance == value & 0xFFFF {
+						values[0][f][n] = rank
+					}
+				}
+			}
+		}
+	}
+
+	// Don't care values repeat the previous ones.
+	for i := 0; i < stored; i++ {
+		for f := 0; f < files; f++ {
+			previous := 0
+			for _, value := range values[i][f] {
+				if value >= 0 {
+					previous = value
+					break
+				}
+			}
+			for n, value := range values[i][f] {
+				if value < 0 {
+					values[i][f][n] = previous
+				}
+				previous = values[i][f][n]
+			}
+		}
+	}
+
+	data := []byte{}
+	u8 := func(n int) { data = append(data, byte(n)) }
+	u16 := func(n int) { data = append(data, byte(n), byte(n >> 8)) }
+	u32 := func(n int) { data = append(data, byte(n), byte(n >> 8), byte(n >> 16), byte(n >> 24)) }
+	code := func(n int) int { return int(g.pieces[n] >> 1) | int(g.pieces[n] & 1) << 3 }
+
+	u32(let(dtz, tbDtzMagic, tbWdlMagic))
+	u8(let(symmetric, 0, 1) | let(g.pawns, 2, 0))
+	last := stored - 1
+	for f := 0; f < files; f++ {
+		u8(layouts[0].lead | layouts[last].lead << 4)
+		for k := range g.pieces {
+			u8(code(layouts[0].order[k]) | code(layouts[last].order[k]) << 4)
+		}
+	}
+	if len(data) & 1 != 0 {
+		u8(0)
+	}
+
+	var compressed [2][4]*syzygyData
+	for f := 0; f < files; f++ {
+		for i := 0; i < stored; i++ {
+			d := syzygyCompress(values[i][f], 8 << blockLog, 1 << spanLog)
+			compressed[i][f] = d
+			flags := let(dtz, tbMapped | tbWinPlies | tbLossPlies | stm, 0)
+			if d.blocks == nil {
+				u8(flags | tbSingleValue); u8(d.value)
+				continue
+			}
+			u8(flags)
+			u8(blockLog); u8(spanLog); u8(0)
+			u32(len(d.blocks))
+			u8(d.maxLen); u8(d.minLen)
+			for _, symbol := range d.lowest {
+				u16(symbol)
+			}
+			u16(len(d.pairs))
+			for _, pair := range d.pairs {
+				u8(pair[0] & 0xFF); u8(pair[0] >> 8 | (pair[1] & 0x0F) << 4); u8(pair[1] >> 4)
+			}
+			if len(d.pairs) & 1 != 0 {
+				u8(0)
+			}
+		}
+	}
+
+	if dtz {
+		for f := 0; f < files; f++ {
+			for outcome := 0; outcome < 4; outcome++ {
+				u8(len(maps[f][outcome]))
+				for _, distance := range maps[f][outcome] {
+					u8(distance)
+				}
+			}
+		}
+		if len(data) & 1 != 0 {
+			u8(0)
+		}
+	}
+
+	for f := 0; f < files; f++ {
+		for i := 0; i < stored; i++ {
+			for _, entry := range compressed[i][f].sparse {
+				u32(entry[0]); u16(entry[1])
+			}
+		}
+	}
+	for f := 0; f < files; f++ {
+		for i := 0; i < stored; i++ {
+			for _, length := range compressed[i][f].lengths {
+				u16(length - 1)
+			}
+		}
+	}
+	for f := 0; f < files; f++ {
+		for i := 0; i < stored; i++ {
+			for len(data) & 0x3F != 0 {
+				u8(0)
+			}
+			for _, block := range compressed[i][f].blocks {
+				data = append(data, block...)
+			}
+		}
+	}
+
+	return data
+}
+
+// Compressed values of one side to move and lead pawn file.
+type syzygyData struct {
+	value   int      // The only value if all the values are the same.
+	pairs   [][2]int // Symbols: pairs of other symbols, or the value and 0xFFF.
+	minLen  int      // Shortest and longest Huffman codes.
+	maxLen  int
+	lowest  []int    // Lowest symbol of each code length starting with minLen.
+	blocks  [][]byte
+	lengths []int    // Number of values in each block.
+	sparse  [][2]int // Block and offset for every span of values.
+}
+
+// RE-PAIR keeps replacing the most frequent pairs of adjacent symbols with
+// new symbols, and then canonical Huffman code packs them into the blocks.
+func syzygyCompress(values []int, blockBits, span int) *syzygyData {
+	const maxSymbols = 1024
+	d := &syzygyData{ value: values[0] }
+
+	leaves, stream, expands := map[int]int32{}, make([]int32, len(values)), []int{}
+	for n, value := range values {
+		symbol, ok := leaves[value]
+		if !ok {
+			symbol = int32(len(d.pairs))
+			leaves[value] = symbol
+			d.pairs = append(d.pairs, [2]int{ value, 0xFFF })
+			expands = append(expands, 1)
+		}
+		stream[n] = symbol
+	}
+	if len(d.pairs) == 1 {
+		return d
+	}
+
+	counts := make([]int32, maxSymbols * maxSymbols)
+	for len(d.pairs) < maxSymbols {
+		for n := 0; n + 1 < len(stream); n++ {
+			counts[int(stream[n]) * maxSymbols + int(stream[n+1])]++
+			if n + 2 < len(stream) && stream[n] == stream[n+1] && stream[n] == stream[n+2] {
+				n++ // Don't count overlapping pairs twice.
+			}
+		}
+		candidates := []int{}
+		for n := 0; n + 1 < len(stream); n++ {
+			pair := int(stream[n]) * maxSymbols + int(stream[n+1])
+			if counts[pair] >= 8 && expands[stream[n]] + expands[stream[n+1]] <= 256 {
+				candidates = append(candidates, pair)
+			}
+			counts[pair] = -counts[pair] // Seen.
+		}
+		sort.Slice(candidates, func(i, j int) bool { return counts[candidates[i]] < counts[candidates[j]] })
+		for n := 0; n + 1 < len(stream); n++ {
+			counts[int(stream[n]) * maxSymbols + int(stream[n+1])] = 0
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		// New symbols are marked with negative counts.
+		for _, pair := range candidates[:min(len(candidates), min(32, maxSymbols - len(d.pairs)))] {
+			counts[pair] = -int32(len(d.pairs)) - 1
+			d.pairs = append(d.pairs, [2]int{ pair / maxSymbols, pair % maxSymbols })
+			expands = append(expands, expands[pair / maxSymbols] + expands[pair % maxSymbols])
+		}
+		replaced := stream[:0]
+		for n := 0; n < len(stream); n++ {
+			if n + 1 < len(stream) && counts[int(stream[n]) * maxSymbols + int(stream[n+1])] < 0 {
+				replaced = append(replaced, -counts[int(stream[n]) * maxSymbols + int(stream[n+1])] - 1)
+				n++
+			} else {
+				replaced = append(replaced, stream[n])
+			}
+		}
+		stream = replaced
+		for _, pair := range candidates {
+			counts[pair] = 0
+		}
+	}
+
+	// Huffman code lengths. The symbols that only show up within the
+	// other symbols get the codes too since they need the numbers.
+	type node struct { weight, left, right int }
+	nodes := make([]node, len(d.pairs))
+	for n := range nodes {
+		nodes[n] = node{ weight: 1, left: -1, right: -1 }
+	}
+	for _, symbol := range stream {
+		nodes[symbol].weight++
+	}
+	queue := make([]int, len(nodes))
+	for n := range queue {
+		queue[n] = n
+	}
+	for len(queue) > 1 {
+		sort.Slice(queue, func(i, j int) bool { return nodes[queue[i]].weight < nodes[queue[j]].weight })
+		nodes = append(nodes, node{ nodes[queue[0]].weight + nodes[queue[1]].weight, queue[0], queue[1] })
+		queue = append(queue[2:], len(nodes) - 1)
+	}
+	bits := make([]int, len(d.pairs))
+	var depth func(n, length int)
+	depth = func(n, length int) {
+		if nodes[n].left < 0 {
+			bits[n] = length
+		} else {
+			depth(nodes[n].left, length + 1)
+			depth(nodes[n].right, length + 1)
+		}
+	}
+	depth(queue[0], 0)
+
+	// Symbols get renumbered so that the longest codes have the lowest
+	// numbers. The codes of each length are consecutive starting with
+	// the lowest code of that length.
+	order := make([]int, len(d.pairs))
+	for n := range order {
+		order[n] = n
+	}
+	sort.SliceStable(order, func(i, j int) bool { return bits[order[i]] > bits[order[j]] })
+	renumbered := make([]int, len(order))
+	for n, symbol := range order {
+		renumbered[symbol] = n
+	}
+	pairs := make([][2]int, len(d.pairs))
+	for symbol, pair := range d.pairs {
+		if pair[1] != 0xFFF {
+			pair = [2]int{ renumbered[pair[0]], renumbered[pair[1]] }
+		}
+		pairs[renumbered[symbol]] = pair
+	}
+	d.pairs = pairs
+
+	d.minLen, d.maxLen = bits[order[len(order) - 1]], bits[order[0]]
+	if d.maxLen > 32 {
+		panic(`donna: Huffman code is too long`)
+	}
+	count := make([]int, d.maxLen + 2)
+	for _, length := range bits {
+		count[length]++
+	}
+	base, lowest := make([]int, d.maxLen + 1), make([]int, d.maxLen + 1)
+	for length := d.maxLen - 1; length >= d.minLen; length-- {
+		if (base[length + 1] + count[length + 1]) & 1 != 0 {
+			panic(`donna: incomplete Huffman code`)
+		}
+		base[length] = (base[length + 1] + count[length + 1]) / 2
+		lowest[length] = lowest[length + 1] + count[length + 1]
+	}
+	d.lowest = lowest[d.minLen:]
+	codes := make([]int, len(order))
+	for n, symbol := range order {
+		codes[n] = base[bits[symbol]] + n - lowest[bits[symbol]]
+	}
+
+	// Pack whole symbols into the blocks, most significant bit first.
+	block, used, length := make([]byte, blockBits / 8), 0, 0
+	for _, symbol := range stream {
+		n := renumbered[symbol]
+		if used + bits[symbol] > blockBits || length + expands[symbol] > 65536 {
+			d.blocks, d.lengths = append(d.blocks, block), append(d.lengths, length)
+			block, used, length = make([]byte, blockBits / 8), 0, 0
+		}
+		for bit := bits[symbol] - 1; bit >= 0; bit-- {
+			if codes[n] & (1 << uint(bit)) != 0 {
+				block[used >> 3] |= 0x80 >> uint(used & 7)
+			}
+			used++
+		}
+		length += expands[symbol]
+	}
+	d.blocks, d.lengths = append(d.blocks, block), append(d.lengths, length)
+
+	// Sparse index points to the middle value of each span.
+	start, n := 0, 0
+	for middle := span / 2; middle - span / 2 < len(values); middle += span {
+		for n + 1 < len(d.blocks) && start + d.lengths[n] <= middle {
+			start += d.lengths[n]
+			n++
+		}
+		if middle - start > 0xFFFF {
+			panic(`donna: sparse index offset is out of range`)
+		}
+		d.sparse = append(d.sparse, [2]int{ n, middle - start })
+	}
+
+	return d
+}
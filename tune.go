@@ -0,0 +1,268 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`bufio`
+	`fmt`
+	`io`
+	`math`
+	`strconv`
+	`strings`
+	`sync`
+)
+
+// Texel tuner adjusts evaluation parameters to minimize the mean squared
+// error between actual game results and the results predicted by the
+// evaluation. The prediction is the evaluation score mapped by the sigmoid
+// curve with the scaling constant k.
+type Tuner struct {
+	k       float64        // Sigmoid scaling constant.
+	samples []TuneSample   // Labelled positions.
+	games   []*Game        // Evaluation scratch space for each thread.
+	profile *Profile       // Evaluation parameters shared by all the threads.
+}
+
+// Position labelled with the result of the game it comes from.
+type TuneSample struct {
+	position Position      // The position without the worker.
+	result   float64       // 1 if White won, 0.5 for a draw, and 0 if Black won.
+}
+
+// Creates the tuner that evaluates positions using given number of threads.
+func NewTuner(threads int) *Tuner {
+	tuner := &Tuner{ k: 1.0, profile: NewProfile() }
+	for i := 0; i < max(1, threads); i++ {
+		engine := NewEngine()
+		engine.profile = tuner.profile
+		tuner.games = append(tuner.games, engine.NewGame())
+	}
+
+	return tuner
+}
+
+// Returns the evaluation parameters being tuned.
+func (t *Tuner) Profile() *Profile {
+	return t.profile
+}
+
+// Returns the number of labelled positions.
+func (t *Tuner) Size() int {
+	return len(t.samples)
+}
+
+// Reads positions in EPD format labelled with the game result. The result
+// could be given as c9 "1-0" opcode, or as [1.0], [0.5], [0.0] or 1-0, 1/2-1/2,
+// 0-1 anywhere after the position. Positions with the king in check and
+// unlabelled positions are skipped. When quiet is true the positions are
+// resolved by quiescence search: the position at the end of the quiescence
+// principal variation gets labelled instead.
+func (t *Tuner) Read(r io.Reader, quiet bool) (count int, err error) {
+	game := t.games[0]
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) < 5 || line[0] == '#' {
+			continue
+		}
+
+		result, ok := tuneResult(strings.Join(fields[4:], ` `))
+		if !ok {
+			continue
+		}
+		if len(fields) >= 6 && isNumber(fields[4]) && isNumber(fields[5]) {
+			fields = fields[:6]
+		} else {
+			fields = fields[:4]
+		}
+
+		game.initial = strings.Join(fields, ` `)
+		p, e := game.Start()
+		if e != nil || p.isInCheck(p.color) {
+			continue
+		}
+		if quiet {
+			p = p.quiet()
+		}
+
+		sample := TuneSample{ position: *p, result: result }
+		sample.position.worker = nil
+		t.samples = append(t.samples, sample)
+		count++
+	}
+
+	return count, scanner.Err()
+}
+
+// Returns game result for the label, ex. "1-0" => 1.0.
+func tuneResult(label string) (result float64, ok bool) {
+	switch {
+	case strings.Contains(label, `1/2-1/2`):
+		return 0.5, true
+	case strings.Contains(label, `1-0`):
+		return 1.0, true
+	case strings.Contains(label, `0-1`):
+		return 0.0, true
+	}
+
+	if matches := reResult.FindStringSubmatch(label); matches != nil {
+		if result, err := strconv.ParseFloat(matches[1], 64); err == nil && result >= 0.0 && result <= 1.0 {
+			return result, true
+		}
+	}
+
+	return 0.0, false
+}
+
+// Returns true if the string is a non-negative integer, ex. FEN move number.
+func isNumber(str string) bool {
+	_, err := strconv.Atoi(str)
+	return err == nil
+}
+
+// Resolves the position by playing out the principal variation of quiescence
+// search. Returns the position at the end of the variation. The position is
+// expected to be the root of the tree, and the variation is never longer than
+// MaxPly so it fits in the search stack without being played into the game
+// history.
+func (p *Position) quiet() *Position {
+	game := p.game()
+	game.getReady()
+	p.searchQuiescence(-Checkmate, Checkmate, 0, false)
+
+	pv := game.workers[0].pv[0]
+	for i := 0; i < pv.size; i++ {
+		p = p.makeMove(pv.moves[i])
+	}
+
+	return p
+}
+
+// Returns the evaluation of the sample from White's point of view.
+func (t *Tuner) evaluate(worker *Worker, sample *TuneSample) int {
+	worker.tree[0], worker.node, worker.rootNode = sample.position, 0, 0
+	p := worker.position()
+	p.worker, p.score = worker, Unknown
+	p.tally = p.valuation() // <-- Needs the worker for its piece/square table.
+
+	score := p.Evaluate()
+	return let(p.color == White, score, -score)
+}
+
+// Maps the score in centipawns to expected game result for White.
+func (t *Tuner) sigmoid(score int, k float64) float64 {
+	return 1.0 / (1.0 + math.Pow(10.0, -k * float64(score) / 400.0))
+}
+
+// Evaluates all the samples in parallel, each thread taking its share of the
+// samples, and returns their scores.
+func (t *Tuner) scores() []int {
+	scores := make([]int, len(t.samples))
+	threads := len(t.games)
+
+	var wg sync.WaitGroup
+	for i, game := range t.games {
+		wg.Add(1)
+		go func(thread int, worker *Worker) {
+			defer wg.Done()
+			worker.pawnCache = PawnCache{} // <-- Parameters might have changed.
+			for j := thread; j < len(t.samples); j += threads {
+				scores[j] = t.evaluate(worker, &t.samples[j])
+			}
+		}(i, game.workers[0])
+	}
+	wg.Wait()
+
+	return scores
+}
+
+// Returns the mean squared error of predicted results for given scores.
+func (t *Tuner) meanError(scores []int, k float64) (sum float64) {
+	if len(scores) == 0 {
+		return 0.0
+	}
+	for i, score := range scores {
+		diff := t.samples[i].result - t.sigmoid(score, k)
+		sum += diff * diff
+	}
+
+	return sum / float64(len(scores))
+}
+
+// Returns the mean squared error of current evaluation parameters.
+func (t *Tuner) Error() float64 {
+	return t.meanError(t.scores(), t.k)
+}
+
+// Finds the sigmoid scaling constant that makes current evaluation fit the
+// results best. The constant stays fixed while the parameters get tuned.
+func (t *Tuner) FitK() float64 {
+	scores := t.scores()
+
+	// Golden section search between 0.1 and 3.0.
+	ratio := (math.Sqrt(5.0) - 1.0) / 2.0
+	a, b := 0.1, 3.0
+	for b - a > 0.0001 {
+		c, d := b - ratio * (b - a), a + ratio * (b - a)
+		if t.meanError(scores, c) < t.meanError(scores, d) {
+			b = d
+		} else {
+			a = c
+		}
+	}
+	t.k = (a + b) / 2.0
+
+	return t.k
+}
+
+// Tunes given parameters, or all of them if none given, by local search: each
+// value gets nudged up and down by one, and the nudge is kept if it reduces
+// the error. The search stops when a pass makes no improvement or after the
+// given number of passes. The callback, if any, gets called after each pass.
+func (t *Tuner) Tune(names []string, passes int, callback func(pass int, err float64)) (float64, error) {
+	selected := t.profile.params
+	if len(names) > 0 {
+		selected = nil
+		for _, name := range names {
+			if p := t.profile.param(name); p != nil {
+				selected = append(selected, p)
+			} else {
+				return 0.0, fmt.Errorf("donna: unknown parameter %q", name)
+			}
+		}
+	}
+
+	var values []*int
+	for _, p := range selected {
+		values = append(values, p.values()...)
+	}
+
+	best := t.Error()
+	for pass := 1; pass <= passes || passes == 0; pass++ {
+		improved := false
+		for _, value := range values {
+			for _, delta := range []int{ 1, -2 } { // +1, then -1.
+				*value += delta
+				t.profile.reinit()
+				if err := t.Error(); err < best {
+					best, improved = err, true
+					break
+				}
+				if delta < 0 {
+					*value++ // Neither helped: restore the original value.
+					t.profile.reinit()
+				}
+			}
+		}
+		if callback != nil {
+			callback(pass, best)
+		}
+		if !improved {
+			break
+		}
+	}
+	return best, nil
+}
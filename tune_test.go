@@ -0,0 +1,85 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `strings`; `testing`)
+
+const tuneSamples = `
+# White is up a knight.
+4k3/8/8/8/3N4/8/8/4K3 w - - 0 1 c9 "1-0";
+4k3/8/8/8/3N4/8/8/4K3 b - - [1.0]
+# Black is up a rook.
+r3k3/8/8/8/8/8/8/4K3 w - - 0 1 c9 "0-1";
+r3k3/8/8/8/8/8/8/4K3 b - - [0.0]
+# Even material.
+4k3/pppp4/8/8/8/8/PPPP4/4K3 w - - 1/2-1/2
+4k3/pppp4/8/8/8/8/PPPP4/4K3 b - - [0.5]
+# Skipped: in check, and unlabelled.
+4k3/8/8/8/8/8/8/R3K2r w - - 0 1 c9 "1-0";
+4k3/8/8/8/3N4/8/8/4K3 w - - 0 1
+`
+
+// Game results get parsed from various labels.
+func TestTune000(t *testing.T) {
+	for label, expected := range map[string]float64{ `c9 "1-0";`: 1.0, `0-1`: 0.0, `1/2-1/2`: 0.5, `[0.5]`: 0.5, `[1]`: 1.0, `0 1 [0.25]`: 0.25 } {
+		result, ok := tuneResult(label)
+		expect.True(t, ok)
+		expect.Eq(t, result, expected)
+	}
+	_, ok := tuneResult(`0 1`)
+	expect.False(t, ok)
+	_, ok = tuneResult(`[1.5]`)
+	expect.False(t, ok)
+}
+
+// Labelled positions get read skipping the ones in check and unlabelled.
+func TestTune010(t *testing.T) {
+	tuner := NewTuner(2)
+	count, err := tuner.Read(strings.NewReader(tuneSamples), false)
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, count, 6)
+	expect.Eq(t, tuner.Size(), 6)
+	expect.Eq(t, tuner.samples[1].result, 1.0)
+	expect.Eq(t, tuner.samples[1].position.color, uint8(Black))
+
+	// Scores are from White's point of view regardless of side to move.
+	scores := tuner.scores()
+	expect.True(t, scores[0] > 200 && scores[1] > 200)
+	expect.True(t, scores[2] < -300 && scores[3] < -300)
+	expect.True(t, scores[4] > -50 && scores[4] < 50)
+}
+
+// Quiescence search resolves hanging pieces before the position gets labelled.
+func TestTune020(t *testing.T) {
+	tuner := NewTuner(1)
+	count, err := tuner.Read(strings.NewReader(`4k3/8/8/3q4/8/8/3R4/4K3 w - - [1.0]`), true)
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, count, 1)
+	expect.True(t, tuner.samples[0].position.outposts[BlackQueen].empty())
+	expect.Eq(t, tuner.samples[0].position.pieces[D5], Piece(Rook))
+}
+
+// Fitted sigmoid scaling gives sane error, and tuning never makes it worse.
+func TestTune030(t *testing.T) {
+	tuner := NewTuner(2)
+	tuner.Read(strings.NewReader(tuneSamples), false)
+
+	k := tuner.FitK()
+	expect.True(t, k > 0.1 && k < 3.0)
+	before := tuner.Error()
+	expect.True(t, before < 0.1)
+
+	passes := 0
+	after, err := tuner.Tune([]string{ `valueKnight` }, 2, func(pass int, err float64) {
+		passes = pass
+	})
+	expect.Eq(t, err, error(nil))
+	expect.True(t, passes > 0 && passes <= 2)
+	expect.True(t, after <= before)
+	expect.Eq(t, tuner.Error(), after)
+
+	_, err = tuner.Tune([]string{ `valueUnicorn` }, 1, nil)
+	expect.Contain(t, err.Error(), `unknown parameter "valueUnicorn"`)
+}
@@ -62,11 +62,6 @@ func same(square int) Bitmask {
 	return ^maskDark
 }
 
-// Returns a distance between current node and the root one.
-func ply() int {
-	return node - rootNode
-}
-
 // Returns a score of getting mated in given number of plies.
 func matedIn(ply int) int {
 	return ply - Checkmate
@@ -129,12 +124,13 @@ func since(start time.Time) int64 {
 }
 
 // Returns nodes per second search speed for the given time duration.
-func nps(duration int64) int64 {
-	nodes := int64(game.nodes + game.qnodes) * 1000
+func (game *Game) nps(duration int64) int64 {
+	nodes, qnodes := game.nodes()
+	total := int64(nodes + qnodes) * 1000
 	if duration != 0 {
-		return nodes / duration
+		return total / duration
 	}
-	return nodes
+	return total
 }
 
 // Formats time duration in milliseconds in human readable form (MM:SS.XXX).
@@ -188,26 +184,28 @@ func Summary(metrics map[string]interface{}) {
 		float32(final.midgame)/units, float32(final.endgame)/units, float32(final.blended(phase))/units)
 }
 
+// Debugging switch for Log(). It's not tied to any engine so that logging
+// could be turned on in tests regardless of how many engines are running.
+var logging bool
+
 // Logging wrapper around fmt.Printf() that could be turned on as needed. Typical
 // usage is Log(); defer Log() in tests.
 func Log(args ...interface{}) {
 	switch len(args) {
 	case 0:
 		// Calling Log() with no arguments flips the logging setting.
-		engine.log = !engine.log
-		engine.fancy = !engine.fancy
+		logging = !logging
 	case 1:
 		switch args[0].(type) {
 		case bool:
-			engine.log = args[0].(bool)
-			engine.fancy = args[0].(bool)
+			logging = args[0].(bool)
 		default:
-			if engine.log {
+			if logging {
 				fmt.Println(args...)
 			}
 		}
 	default:
-		if engine.log {
+		if logging {
 			fmt.Printf(args[0].(string), args[1:]...)
 		}
 	}
@@ -0,0 +1,245 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (
+	`bufio`
+	`fmt`
+	`io`
+	`math`
+	`strconv`
+	`strings`
+)
+
+// Win/draw/loss model turns the score into expected outcome of the game. The
+// chance to win is the logistic curve of the score shifted by a and scaled
+// by b, and the chance to lose is its mirror image; the draw takes the rest.
+// Both a and b change linearly with the game phase since the same score
+// means different odds in the middlegame and in the endgame.
+type WdlModel struct {
+	a [2]float64  // Score of even chances to win, endgame and midgame.
+	b [2]float64  // Spread of the logistic curve, endgame and midgame.
+}
+
+// Self-play game position as seen by the side to move.
+type WdlSample struct {
+	Score  int      // Engine's score in centipawns.
+	Phase  int      // Game phase, from 0 (bare kings) to 256 (all pieces).
+	Result float64  // Game result: 1 for win, 0.5 for draw, and 0 for loss.
+}
+
+// Model parameters are rough estimates that haven't been fitted to any games
+// yet. Use cmd/wdlfit to fit them to self-play games.
+var wdlModel = WdlModel{ a: [2]float64{ 213.6, 95.1 }, b: [2]float64{ 110.1, 187.2 } }
+
+// Returns a and b model parameters for the given game phase.
+func (m WdlModel) params(phase int) (a, b float64) {
+	t := float64(max(0, min(phase, 256))) / 256.0
+	return m.a[0] + (m.a[1] - m.a[0]) * t, m.b[0] + (m.b[1] - m.b[0]) * t
+}
+
+// Returns win, draw, and loss probabilities for the score in centipawns.
+func (m WdlModel) odds(score, phase int) (win, draw, loss float64) {
+	a, b := m.params(phase)
+	win = 1.0 / (1.0 + math.Exp((a - float64(score)) / b))
+	loss = 1.0 / (1.0 + math.Exp((a + float64(score)) / b))
+
+	return win, 1.0 - win - loss, loss
+}
+
+// Returns the model as Go source so that fitted parameters could be pasted
+// into wdl.go.
+func (m WdlModel) String() string {
+	return fmt.Sprintf("WdlModel{ a: [2]float64{ %.1f, %.1f }, b: [2]float64{ %.1f, %.1f } }", m.a[0], m.a[1], m.b[0], m.b[1])
+}
+
+// Returns expected outcome of the game in per mille as reported by UCI
+// "info ... wdl W D L". The score is from the point of view of the side to
+// move in the position.
+func (p *Position) wdl(score int) (win, draw, loss int) {
+	if isMate(score) {
+		if score > 0 {
+			return 1000, 0, 0
+		}
+		return 0, 0, 1000
+	}
+
+	w, _, l := wdlModel.odds(score * 100 / onePawn, p.material().phase)
+	win, loss = int(math.Floor(w * 1000.0 + 0.5)), int(math.Floor(l * 1000.0 + 0.5))
+
+	return win, 1000 - win - loss, loss
+}
+
+// Reads self-play games in PGN format and turns the moves commented with the
+// engine's score, ex. {+0.35/12 0.51s}, into samples. Book moves, mate scores,
+// and unfinished games are skipped.
+func ReadWdlSamples(r io.Reader) (samples []WdlSample, err error) {
+	engine := NewEngine(`cache`, 1)
+	tags, pending, colors := map[string]string{}, []WdlSample{}, []uint8{}
+	var p *Position
+	var phase int
+	var color uint8
+	commented, broken := true, false
+
+	// Wraps up the game when its result is known.
+	finish := func(result string) {
+		if white, ok := map[string]float64{ `1-0`: 1.0, `1/2-1/2`: 0.5, `0-1`: 0.0 }[result]; ok && !broken {
+			for i, sample := range pending {
+				sample.Result = white
+				if colors[i] == Black {
+					sample.Result = 1.0 - white
+				}
+				samples = append(samples, sample)
+			}
+		}
+		tags, pending, colors = map[string]string{}, pending[:0], colors[:0]
+		p, commented, broken = nil, true, false
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		token, err := pgnToken(reader)
+		if err == io.EOF {
+			return samples, nil
+		} else if err != nil {
+			return samples, err
+		}
+
+		switch {
+		case token[0] == '[': // Tag pair, ex. [FEN "..."].
+			if fields := strings.SplitN(strings.Trim(token, `[]`), ` `, 2); len(fields) == 2 {
+				tags[fields[0]] = strings.Trim(fields[1], `"`)
+			}
+		case token[0] == '{': // Comment with the score of the last move.
+			if matches := reScore.FindStringSubmatch(strings.TrimSpace(strings.Trim(token, `{}`))); matches != nil && !commented {
+				if value, err := strconv.ParseFloat(matches[1], 64); err == nil {
+					pending = append(pending, WdlSample{ Score: int(math.Floor(value * 100.0 + 0.5)), Phase: phase })
+					colors = append(colors, color)
+				}
+			}
+			commented = true
+		case token == `1-0` || token == `0-1` || token == `1/2-1/2` || token == `*`:
+			finish(token)
+		default: // Move, possibly prefixed with move number, ex. 12.Nf3.
+			if token = strings.TrimLeft(token, `0123456789.`); token == `` || token[0] == '$' || broken {
+				continue
+			}
+			if p == nil {
+				fen := tags[`FEN`]
+				if fen == `` {
+					fen = `rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1`
+				}
+				if p, err = engine.NewGame(fen).Start(); err != nil {
+					broken = true
+					continue
+				}
+			}
+			move := NewMoveFromSan(p, token)
+			if move.nil() {
+				broken = true
+				continue
+			}
+			phase, color, commented = p.material().phase, p.color, false
+			p = p.playMove(move)
+		}
+	}
+}
+
+// Returns next PGN token: tag pair, comment, or space separated word.
+// Variations and rest of line comments are skipped.
+func pgnToken(reader *bufio.Reader) (string, error) {
+	var token []byte
+	nesting := 0
+
+	for {
+		char, err := reader.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(token) > 0 {
+				return string(token), nil
+			}
+			return ``, err
+		}
+
+		switch {
+		case nesting > 0: // Inside variation.
+			if char == '(' {
+				nesting++
+			} else if char == ')' {
+				nesting--
+			}
+		case char == '(':
+			nesting++
+		case char == ';':
+			reader.ReadString('\n')
+		case char == '[' || char == '{':
+			if len(token) > 0 {
+				reader.UnreadByte()
+				return string(token), nil
+			}
+			closing := byte(let(char == '[', ']', '}'))
+			rest, err := reader.ReadString(closing)
+			return string(char) + rest, err
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, char)
+		}
+	}
+}
+
+// Fits win/draw/loss model parameters to the samples by maximizing the
+// likelihood of actual game results. Starting with current model the search
+// nudges one parameter at a time and halves the step when none of the
+// nudges helps.
+func FitWdl(samples []WdlSample) WdlModel {
+	model := wdlModel
+	if len(samples) == 0 {
+		return model
+	}
+
+	best := wdlLoss(model, samples)
+	for step := 64.0; step >= 0.05; {
+		improved := false
+		for i := 0; i < 4; i++ {
+			for _, delta := range []float64{ step, -step } {
+				candidate := model
+				param := &candidate.a[i % 2]
+				if i > 1 {
+					param = &candidate.b[i % 2]
+				}
+				if *param += delta; *param < 1.0 {
+					continue // Both a and b must stay positive.
+				}
+				if loss := wdlLoss(candidate, samples); loss < best {
+					model, best, improved = candidate, loss, true
+					break
+				}
+			}
+		}
+		if !improved {
+			step /= 2.0
+		}
+	}
+
+	return model
+}
+
+// Returns average negative log-likelihood of sample game results.
+func wdlLoss(model WdlModel, samples []WdlSample) (loss float64) {
+	for _, sample := range samples {
+		win, draw, lose := model.odds(sample.Score, sample.Phase)
+		odds := draw
+		if sample.Result > 0.75 {
+			odds = win
+		} else if sample.Result < 0.25 {
+			odds = lose
+		}
+		loss -= math.Log(math.Max(odds, 1e-12))
+	}
+
+	return loss / float64(len(samples))
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import(`github.com/michaeldv/donna/expect`; `math`; `strings`; `testing`)
+
+// Win/draw/loss odds add up and mirror each other.
+func TestWdl000(t *testing.T) {
+	for _, phase := range []int{ 0, 128, 256 } {
+		for score := -500; score <= 500; score += 50 {
+			win, draw, loss := wdlModel.odds(score, phase)
+			expect.True(t, math.Abs(win + draw + loss - 1.0) < 1e-9)
+			expect.True(t, draw > 0.0)
+
+			mirror, _, _ := wdlModel.odds(-score, phase)
+			expect.True(t, math.Abs(mirror - loss) < 1e-9)
+
+			better, _, _ := wdlModel.odds(score + 50, phase)
+			expect.True(t, better > win)
+		}
+	}
+}
+
+// UCI per mille values always add up to 1000; mate scores are certain.
+func TestWdl010(t *testing.T) {
+	p := NewGame().start()
+	win, draw, loss := p.wdl(0)
+	expect.Eq(t, win + draw + loss, 1000)
+	expect.Eq(t, win, loss)
+
+	win, draw, loss = p.wdl(150)
+	expect.Eq(t, win + draw + loss, 1000)
+	expect.True(t, win > loss)
+
+	win, draw, loss = p.wdl(Checkmate - 5)
+	expect.Eq(t, []int{ win, draw, loss }, []int{ 1000, 0, 0 })
+	win, draw, loss = p.wdl(5 - Checkmate)
+	expect.Eq(t, []int{ win, draw, loss }, []int{ 0, 0, 1000 })
+}
+
+// Scored moves of finished games turn into samples.
+func TestWdl020(t *testing.T) {
+	pgn := `[Event "Self-play"]
+[Result "1-0"]
+
+1. e4 {book} e5 {book} 2. Nf3 {+0.30/10 0.1s} Nc6 {-0.25/10 0.1s} 3. Bb5
+{+0.41/11 0.1s} a6 {-M5/9 0.1s} (3... Nf6 4. O-O) 4. Bxc6 {+1.02/9} dxc6 $2 ; Oops.
+5. O-O {+0.90/10} 1-0
+
+[Event "Self-play"]
+[FEN "4k3/8/8/8/8/8/4P3/4K3 w - - 0 1"]
+[Result "1/2-1/2"]
+
+1. e4 {+2.50/20} Kd7 {-2.40/20} 1/2-1/2
+
+[Event "Unfinished"]
+[Result "*"]
+
+1. d4 {+0.20/10} *
+`
+	samples, err := ReadWdlSamples(strings.NewReader(pgn))
+	expect.Eq(t, err, error(nil))
+	expect.Eq(t, len(samples), 7)
+
+	expect.Eq(t, samples[0], WdlSample{ 30, 256, 1.0 })
+	expect.Eq(t, samples[1], WdlSample{ -25, 256, 0.0 })
+	expect.Eq(t, samples[2], WdlSample{ 41, 256, 1.0 })
+	expect.Eq(t, samples[3], WdlSample{ 102, 256, 1.0 })
+	expect.Eq(t, samples[4], WdlSample{ 90, 232, 1.0 })
+	expect.Eq(t, samples[5], WdlSample{ 250, 0, 0.5 })
+	expect.Eq(t, samples[6], WdlSample{ -240, 0, 0.5 })
+}
+
+// Fitting recovers model parameters from the games played by the model.
+func TestWdl030(t *testing.T) {
+	model := WdlModel{ a: [2]float64{ 150.0, 250.0 }, b: [2]float64{ 60.0, 90.0 } }
+
+	var samples []WdlSample
+	for _, phase := range []int{ 0, 64, 128, 192, 256 } {
+		for score := -400; score <= 400; score += 20 {
+			win, draw, _ := model.odds(score, phase)
+			for i := 0; i < 200; i++ {
+				result := 0.0
+				if odds := (float64(i) + 0.5) / 200.0; odds < win {
+					result = 1.0
+				} else if odds < win + draw {
+					result = 0.5
+				}
+				samples = append(samples, WdlSample{ score, phase, result })
+			}
+		}
+	}
+
+	fitted := FitWdl(samples)
+	for i := 0; i < 2; i++ {
+		expect.True(t, math.Abs(fitted.a[i] - model.a[i]) < 5.0)
+		expect.True(t, math.Abs(fitted.b[i] - model.b[i]) < 5.0)
+	}
+	expect.Contain(t, fitted.String(), `WdlModel{ a: [2]float64{ `)
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2014-2016 by Michael Dvorkin. All Rights Reserved.
+// Use of this source code is governed by a MIT-style license that can
+// be found in the LICENSE file.
+
+package donna
+
+import (`sync`; `sync/atomic`)
+
+// The position tree holds the root position followed by the search stack of
+// up to MaxPly positions. Tablebase probes make up to tbPieces more moves past
+// the node they probe, and a couple of spare nodes leave room for the root
+// that has been moved off the bottom with makeMove(), ex. in tests.
+const treeSize = MaxPly + tbPieces + 2
+
+// Search worker owns everything a single search thread needs to walk the tree
+// on its own: the position tree, move generators, evaluation scratch space,
+// killer moves and move history. All workers share the game's transposition
+// table which makes the helpers useful to the main worker (aka Lazy SMP).
+type Worker struct {
+	id          int 		// Worker number; the main worker is #0.
+	game        *Game 		// The game the worker is searching for.
+	nodes       atomic.Int64 	// Number of regular nodes searched.
+	qnodes      atomic.Int64 	// Number of quiescence nodes searched.
+	ticks       int 		// Nodes visited since the clock was last checked.
+	node        int 		// Current node in the position tree.
+	rootNode    int 		// Node the search has started from.
+	multipv     int 		// Number of root moves to skip in MultiPV mode.
+	history     History 		// Good moves history.
+	killers     Killers 		// Killer moves.
+	counters    [14][64]Move 	// Quiet moves that refuted opponent's last move.
+	pv          Pv 			// Principal variations for each ply.
+	eval        Evaluation 		// Evaluation scratch space.
+	profile     *Profile 		// Evaluation parameters of the engine.
+	material    MaterialEntry 	// Material of the position outside of material table.
+	pawnCache   PawnCache 		// Cache of pawn structures.
+	past        []Position 		// Game positions before the root one, oldest first.
+	tree        [treeSize]Position 	// Root position followed by the search stack.
+	moveList    [MaxPly+1]MoveGen 	// Move generators, one per ply.
+	network     *Network 		// Neural network evaluator, if any.
+	accumulators []Accumulator 	// Network sums for each node of the position tree.
+}
+
+func NewWorker(game *Game, id int) *Worker {
+	worker := &Worker{ id: id, game: game, profile: game.engine.profile, network: game.engine.network }
+	if worker.network != nil {
+		worker.accumulators = worker.network.accumulators(len(worker.tree))
+	}
+
+	return worker
+}
+
+func (w *Worker) isMain() bool {
+	return w.id == 0
+}
+
+// Returns the position the worker is currently at.
+func (w *Worker) position() *Position {
+	return &w.tree[w.node]
+}
+
+// Returns the position from the game history followed by the search stack,
+// ex. w.recall(0) is the very first position of the game.
+func (w *Worker) recall(index int) *Position {
+	if index < len(w.past) {
+		return &w.past[index]
+	}
+	return &w.tree[index - len(w.past)]
+}
+
+// Returns the number of positions that precede the current one in the game
+// history and the search stack.
+func (w *Worker) played() int {
+	return len(w.past) + w.node
+}
+
+// Returns a distance between current node and the root one.
+func (w *Worker) ply() int {
+	return w.node - w.rootNode
+}
+
+// Resets node counters, principal variations, killer moves and history before
+// the worker starts new search.
+func (w *Worker) getReady() *Worker {
+	w.nodes.Store(0); w.qnodes.Store(0)
+	w.pv = Pv{}
+	w.killers = Killers{}
+	w.counters = [14][64]Move{}
+	w.history = History{}
+	w.rootNode = w.node
+
+	return w
+}
+
+// Copies the search stack of the given worker so that the helper starts off
+// the same root position. The game history is shared since it doesn't change
+// while the search is on.
+func (w *Worker) follow(main *Worker) *Worker {
+	copy(w.tree[:main.node + 1], main.tree[:main.node + 1])
+	for i := 0; i <= main.node; i++ {
+		w.tree[i].worker = w
+	}
+	w.node, w.past = main.node, main.past
+
+	return w.getReady()
+}
+
+// Helper's iterative deepening loop. Helpers keep searching full width until
+// the main worker is done; half of them start one ply deeper to spread the
+// work across different depths. Their findings reach the main worker through
+// the shared transposition table.
+func (w *Worker) think(done *sync.WaitGroup) {
+	defer done.Done()
+
+	engine, position := w.game.engine, w.position()
+	if NewRootGen(position, 1).generateRootMoves().size() == 0 {
+		return
+	}
+
+	for depth := 1 + w.id % 2; depth <= MaxDepth && !engine.clock.halt.Load(); depth++ {
+		if engine.fixedDepth() && depth > engine.options.maxDepth {
+			break
+		}
+		position.search(-Checkmate, Checkmate, depth)
+	}
+}
+
+// Halts the search when the node budget, if any, has been spent. Much like the
+// clock the budget is only enforced once we've got the move. Only the main
+// worker keeps track of the budget which makes single threaded searches stop
+// at exactly the same node every time. The main worker also checks the clock
+// every thousand nodes or so.
+func (w *Worker) exhausted() bool {
+	engine := w.game.engine
+	if w.isMain() {
+		if budget := engine.nodeBudget(); budget > 0 && (w.game.rootpv.size > 0 || engine.options.mate > 0) {
+			if nodes, qnodes := w.game.nodes(); nodes + qnodes >= budget {
+				engine.clock.halt.Store(true)
+			}
+		}
+		if w.ticks++; w.ticks & 1023 == 0 {
+			engine.checkClock()
+		}
+	}
+
+	return engine.clock.halt.Load()
+}
+
+// Copies given ply's principal variation to the top one, appending the move
+// and principal variation found at next ply.
+func (w *Worker) saveBest(ply int, move Move) *Worker {
+	w.pv[ply].moves[ply] = move
+	w.pv[ply].size = ply + 1
+
+	next := w.pv[ply].size
+	if size := w.pv[next].size; next < MaxPly && size > next {
+		copy(w.pv[ply].moves[next:], w.pv[next].moves[next:size])
+		w.pv[ply].size += size - next
+	}
+
+	return w
+}
+
+func (w *Worker) saveGood(depth int, move Move) *Worker {
+	if move.isQuiet() {
+		if ply := w.ply(); move != w.killers[ply][0] {
+			w.killers[ply][1] = w.killers[ply][0]
+			w.killers[ply][0] = move
+		}
+		if last := w.position().last; !last.nil() {
+			w.counters[last.piece()][last.to()] = move
+		}
+		w.history[move.piece()][move.to()] += depth * depth
+	}
+
+	return w
+}
+
+func (w *Worker) updatePoor(depth int, bestMove Move, mgen *MoveGen) *Worker {
+	value := depth * depth
+
+	for move := mgen.NextMove(); move != 0; move = mgen.NextMove() {
+		if move.isQuiet() {
+			w.history[move.piece()][move.to()] = let(move == bestMove, value, -value)
+		}
+	}
+
+	return w
+}
+
+// Checks whether the move is among good moves captured so far and returns its
+// history value.
+func (w *Worker) good(move Move) int {
+	return w.history[move.piece()][move.to()]
+}
+
+// Returns true is the move is one of the killer moves at given ply.
+func (w *Worker) isKiller(move Move, ply int) bool {
+	return move != Move(0) && (move == w.killers[ply][0] || move == w.killers[ply][1])
+}